@@ -0,0 +1,494 @@
+// Code generated by schemagen from adaptive-card-1.6.json; DO NOT EDIT.
+
+package teams
+
+const (
+	TypeBadge          Type = "Badge"
+	TypeCodeBlock      Type = "CodeBlock"
+	TypeCompoundButton Type = "CompoundButton"
+	TypeIcon           Type = "Icon"
+	TypeImage          Type = "Image"
+	TypeMedia          Type = "Media"
+	TypeProgressBar    Type = "ProgressBar"
+	TypeProgressRing   Type = "ProgressRing"
+	TypeRichTextBlock  Type = "RichTextBlock"
+	TypeTextBlock      Type = "TextBlock"
+	TypeTextRun        Type = "TextRun"
+)
+
+// Displays a short, colored label such as a status or a count
+//
+// Source: https://adaptivecards.microsoft.com/?topic=Badge
+type Badge struct {
+	// Must be TypeBadge ("Badge")
+	Type Type `json:"type"`
+	// The text to display
+	Text string `json:"text,omitempty"`
+	// The name of a Fluent icon to display in the badge
+	Icon string `json:"icon,omitempty"`
+	// Where the icon is displayed relative to the text
+	IconPosition IconPosition `json:"iconPosition,omitempty"`
+	// Controls whether the badge is filled with its color or tinted
+	Appearance BadgeAppearance `json:"appearance,omitempty"`
+	// The size of the badge
+	Size BadgeSize `json:"size,omitempty"`
+	// The shape of the badge
+	Shape BadgeShape `json:"shape,omitempty"`
+	// The color scheme of the badge
+	Style BadgeStyle `json:"style,omitempty"`
+	// Text displayed when hovering over the badge
+	Tooltip string `json:"tooltip,omitempty"`
+	// Controls how this element is horizontally positioned within its parent
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func (b *Badge) IsElement() bool {
+	return true
+}
+
+// Displays a block of code with syntax highlighting
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format#codeblock-in-adaptive-cards
+type CodeBlock struct {
+	// Must be TypeCodeBlock ("CodeBlock")
+	Type Type `json:"type"`
+	// The code to display
+	CodeSnippet string `json:"codeSnippet"`
+	// The language the code is written in, used for syntax highlighting
+	Language CodeLanguage `json:"language,omitempty"`
+	// The number of the first line of the snippet. Defaults to 1
+	StartLineNumber int `json:"startLineNumber,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func (c *CodeBlock) IsElement() bool {
+	return true
+}
+
+// Displays a large button with an icon, a title, a description and an optional badge
+//
+// Source: https://adaptivecards.microsoft.com/?topic=CompoundButton
+type CompoundButton struct {
+	// Must be TypeCompoundButton ("CompoundButton")
+	Type Type `json:"type"`
+	// The title of the button
+	Title string `json:"title"`
+	// The text displayed below the title
+	Description string `json:"description,omitempty"`
+	// The icon displayed next to the title
+	Icon *IconInfo `json:"icon,omitempty"`
+	// The text of a badge displayed next to the title
+	Badge string `json:"badge,omitempty"`
+	// An Action that will be invoked when the button is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewCompoundButton(title string) *CompoundButton {
+	return &CompoundButton{
+		Type:  TypeCompoundButton,
+		Title: title,
+	}
+}
+
+func (c *CompoundButton) IsElement() bool {
+	return true
+}
+
+// Displays an icon from the Fluent UI icon catalog
+//
+// Source: https://adaptivecards.microsoft.com/?topic=Icon
+type Icon struct {
+	// Must be TypeIcon ("Icon")
+	Type Type `json:"type"`
+	// The name of the Fluent icon, e.g. "Calendar"
+	Name string `json:"name"`
+	// The size of the icon
+	Size IconSize `json:"size,omitempty"`
+	// Whether the icon is drawn as an outline or filled
+	Style IconStyle `json:"style,omitempty"`
+	// The color of the icon
+	Color Colors `json:"color,omitempty"`
+	// An Action that will be invoked when the Icon is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Controls how this element is horizontally positioned within its parent
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewIcon(name string) *Icon {
+	return &Icon{
+		Type: TypeIcon,
+		Name: name,
+	}
+}
+
+func (i *Icon) IsElement() bool {
+	return true
+}
+
+// Describes the icon of a CompoundButton
+type IconInfo struct {
+	// The name of the Fluent icon, e.g. "Calendar"
+	Name string `json:"name"`
+	// The size of the icon
+	Size IconSize `json:"size,omitempty"`
+	// Whether the icon is drawn as an outline or filled
+	Style IconStyle `json:"style,omitempty"`
+	// The color of the icon
+	Color Colors `json:"color,omitempty"`
+}
+
+// Displays an image. Acceptable formats are PNG, JPEG, and GIF
+//
+// Source: https://adaptivecards.io/explorer/Image.html
+type Image struct {
+	// Must be TypeImage ("Image")
+	Type Type `json:"type"`
+	// The URL to the image. Supports data URI in version 1.2+
+	Url string `json:"url"`
+	// Alternate text describing the image
+	AltText string `json:"altText,omitempty"`
+	// Applies a background to a transparent image. This property will respect the image style
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	// The desired height of the image. If specified as a pixel value, ending in ‘px’, E.g., 50px, the image will distort to fit that exact height. This overrides the size property
+	Height BlockElementHeight `json:"height,omitempty"`
+	// Controls how this element is horizontally positioned within its parent. When not specified, the value of horizontalAlignment is inherited from the parent container. If no parent container has horizontalAlignment set, it defaults to Left
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	// An Action that will be invoked when the Image is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Controls the approximate size of the image. The physical dimensions will vary per host
+	Size ImageSize `json:"size,omitempty"`
+	// Controls how this Image is displayed
+	Style ImageStyle `json:"style,omitempty"`
+	// The desired on-screen width of the image, ending in ‘px’. E.g., 50px. This overrides the size property
+	Width string `json:"width,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewImage(url string) *Image {
+	return &Image{
+		Type: TypeImage,
+		Url:  url,
+	}
+}
+
+func (i *Image) IsElement() bool {
+	return true
+}
+
+// Displays a media player for audio or video content
+//
+// Source: https://adaptivecards.io/explorer/Media.html
+type Media struct {
+	// Must be TypeMedia ("Media")
+	Type Type `json:"type"`
+	// Array of media sources to attempt to play
+	Sources []MediaSource `json:"sources"`
+	// URL of an image to display before playing. Supports data URI in version 1.2+
+	Poster string `json:"poster,omitempty"`
+	// Alternate text describing the audio or video
+	AltText string `json:"altText,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewMedia(sources ...MediaSource) *Media {
+	return &Media{
+		Type:    TypeMedia,
+		Sources: sources,
+	}
+}
+
+func (m *Media) IsElement() bool {
+	return true
+}
+
+// Defines a source for a Media element
+//
+// Source: https://adaptivecards.io/explorer/MediaSource.html
+type MediaSource struct {
+	// Mime type of associated media (e.g. "video/mp4")
+	MimeType string `json:"mimeType"`
+	// URL to media. Supports data URI in version 1.2+
+	Url string `json:"url"`
+}
+
+// Displays a horizontal bar indicating the progress of an operation
+//
+// Source: https://adaptivecards.microsoft.com/?topic=ProgressBar
+type ProgressBar struct {
+	// Must be TypeProgressBar ("ProgressBar")
+	Type Type `json:"type"`
+	// The current progress, between 0 and Max. When unset the bar is indeterminate
+	Value *float64 `json:"value,omitempty"`
+	// The value at which the operation is complete. Defaults to 100
+	Max float64 `json:"max,omitempty"`
+	// The color of the bar. Supported values are accent, good, warning and attention
+	Color Colors `json:"color,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewProgressBar() *ProgressBar {
+	return &ProgressBar{
+		Type: TypeProgressBar,
+	}
+}
+
+func (p *ProgressBar) IsElement() bool {
+	return true
+}
+
+// Displays a spinning ring indicating that an operation is in progress
+//
+// Source: https://adaptivecards.microsoft.com/?topic=ProgressRing
+type ProgressRing struct {
+	// Must be TypeProgressRing ("ProgressRing")
+	Type Type `json:"type"`
+	// The label displayed next to the ring
+	Label string `json:"label,omitempty"`
+	// Where the label is displayed relative to the ring
+	LabelPosition LabelPosition `json:"labelPosition,omitempty"`
+	// The size of the ring
+	Size ProgressRingSize `json:"size,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewProgressRing() *ProgressRing {
+	return &ProgressRing{
+		Type: TypeProgressRing,
+	}
+}
+
+func (p *ProgressRing) IsElement() bool {
+	return true
+}
+
+// Defines an array of inlines, allowing for inline text formatting
+//
+// Source: https://adaptivecards.io/explorer/RichTextBlock.html
+type RichTextBlock struct {
+	// Must be TypeRichTextBlock ("RichTextBlock")
+	Type Type `json:"type"`
+	// The array of inlines
+	Inlines []TextRun `json:"inlines"`
+	// Specifies the height of the element
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewRichTextBlock(inlines ...TextRun) *RichTextBlock {
+	return &RichTextBlock{
+		Type:    TypeRichTextBlock,
+		Inlines: inlines,
+	}
+}
+
+func (r *RichTextBlock) IsElement() bool {
+	return true
+}
+
+// Displays text, allowing control over font sizes, weight, and color
+//
+// Source: https://adaptivecards.io/explorer/TextBlock.html
+type TextBlock struct {
+	// Must be TypeTextBlock ("TextBlock")
+	Type Type `json:"type"`
+	// Text to display. A subset of markdown is supported (https://aka.ms/ACTextFeatures)
+	Text string `json:"text"`
+	// Controls the color of TextBlock elements
+	Color Colors `json:"color,omitempty"`
+	// Type of font to use for rendering
+	FontType FontType `json:"fontType,omitempty"`
+	// Controls the horizontal text alignment. When not specified, the value of horizontalAlignment is inherited from the parent container. If no parent container has horizontalAlignment set, it defaults to Left
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	// If true, displays text slightly toned down to appear less prominent
+	IsSubtle bool `json:"isSubtle,omitempty"`
+	// Specifies the maximum number of lines to display
+	MaxLines int `json:"maxLines,omitempty"`
+	// Controls size of text
+	Size FontSize `json:"size,omitempty"`
+	// Controls the weight of TextBlock elements
+	Weight FontWeight `json:"weight,omitempty"`
+	// If true, allow text to wrap. Otherwise, text is clipped
+	Wrap bool `json:"wrap,omitempty"`
+	// The style of this TextBlock for accessibility purposes
+	Style TextBlockStyle `json:"style,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewTextBlock(text string) *TextBlock {
+	return &TextBlock{
+		Type: TypeTextBlock,
+		Text: text,
+	}
+}
+
+func (t *TextBlock) IsElement() bool {
+	return true
+}
+
+// Defines a single run of formatted text. A TextRun with no properties set can be represented in the json as string containing the text as a shorthand for the json object. These two representations are equivalent
+//
+// Source: https://adaptivecards.io/explorer/TextRun.html
+type TextRun struct {
+	// Must be TypeTextRun ("TextRun")
+	Type Type `json:"type,omitempty"`
+	// Text to display. Markdown is not supported
+	Text string `json:"text"`
+	// Controls the color of the text
+	Color Colors `json:"color,omitempty"`
+	// The type of font to use
+	FontType FontType `json:"fontType,omitempty"`
+	// If true, displays the text highlighted
+	Highlight bool `json:"highlight,omitempty"`
+	// If true, displays text slightly toned down to appear less prominent
+	IsSubtle bool `json:"isSubtle,omitempty"`
+	// If true, displays the text using italic font
+	Italic bool `json:"italic,omitempty"`
+	// Action to invoke when this text run is clicked. Visually changes the text run into a hyperlink. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Controls size of text
+	Size FontSize `json:"size,omitempty"`
+	// If true, displays the text with strikethrough
+	Strikethrough bool `json:"strikethrough,omitempty"`
+	// If true, displays the text with an underline
+	Underline bool `json:"underline,omitempty"`
+	// Controls the weight of the text
+	Weight FontWeight `json:"weight,omitempty"`
+}
+
+func NewTextRun(text string) *TextRun {
+	return &TextRun{
+		Type: TypeTextRun,
+		Text: text,
+	}
+}
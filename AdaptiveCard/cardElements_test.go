@@ -0,0 +1,121 @@
+package teams
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewElementsRoundTrip(t *testing.T) {
+	card := roundTrip(t, `{"type":"AdaptiveCard","version":"1.6","body":[
+		{"type":"CodeBlock","codeSnippet":"panic: nil map","language":"Go","startLineNumber":12},
+		{"type":"ProgressBar","value":0,"max":10,"color":"good"},
+		{"type":"ProgressRing","label":"Deploying","size":"small"},
+		{"type":"Badge","text":"FIRING","icon":"Warning","style":"attention","appearance":"tint"},
+		{"type":"Icon","name":"Calendar","size":"Small","selectAction":{"type":"Action.OpenUrl","url":"https://example.com"}},
+		{"type":"CompoundButton","title":"Runbook","description":"Open it","icon":{"name":"Book"},"badge":"new"},
+		{"type":"Carousel","timer":5000,"initialPage":1,"pages":[
+			{"type":"CarouselPage","items":[{"type":"TextBlock","text":"one"}]},
+			{"type":"CarouselPage","items":[{"type":"TextBlock","text":"two"}],"selectAction":{"type":"Action.Submit"}}
+		]}
+	]}`)
+
+	if bar := card.Body[1].(*ProgressBar); bar.Value == nil || *bar.Value != 0 {
+		t.Errorf("ProgressBar.Value = %v, want 0", bar.Value)
+	}
+	if button := card.Body[5].(*CompoundButton); button.Icon == nil || button.Icon.Name != "Book" {
+		t.Errorf("CompoundButton.Icon = %+v, want Book", button.Icon)
+	}
+	if carousel := card.Body[6].(*Carousel); len(carousel.Pages) != 2 || carousel.Pages[1].SelectAction == nil {
+		t.Errorf("Carousel.Pages = %+v", carousel.Pages)
+	}
+	if err := card.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+func TestValidateNewElements(t *testing.T) {
+	value := func(f float64) *float64 { return &f }
+	tests := []struct {
+		name    string
+		element Element
+		want    string
+	}{
+		{"code block with negative start line", &CodeBlock{Type: TypeCodeBlock, StartLineNumber: -1}, "StartLineNumber must not be negative"},
+		{"progress bar above default max", &ProgressBar{Type: TypeProgressBar, Value: value(101)}, "Value 101 is not between 0 and 100"},
+		{"progress bar above max", &ProgressBar{Type: TypeProgressBar, Value: value(11), Max: 10}, "Value 11 is not between 0 and 10"},
+		{"progress bar with negative max", &ProgressBar{Type: TypeProgressBar, Max: -1}, "Max must not be negative"},
+		{"empty badge", NewBadge("", BadgeStyleDefault), "Text or Icon is required"},
+		{"icon without name", &Icon{Type: TypeIcon}, "Name is required"},
+		{"compound button without title", &CompoundButton{Type: TypeCompoundButton}, "Title is required"},
+		{"compound button icon without name", &CompoundButton{Type: TypeCompoundButton, Title: "t", Icon: &IconInfo{}}, "icon: Name is required"},
+		{"carousel without pages", &Carousel{Type: TypeCarousel}, "Pages is required"},
+		{"carousel initial page out of range", &Carousel{Type: TypeCarousel, InitialPage: 1, Pages: []CarouselPage{*NewCarouselPage()}}, "InitialPage 1 is out of range"},
+		{"carousel with negative timer", &Carousel{Type: TypeCarousel, Timer: -1, Pages: []CarouselPage{*NewCarouselPage()}}, "Timer must not be negative"},
+		{"carousel page with invalid item", NewCarousel(*NewCarouselPage(&TextBlock{})), "pages[0]: items[0]: Type is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := NewAdaptiveCard()
+			card.Version = Version16
+			card.Body = append(card.Body, tt.element)
+			if err := card.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewElementsRequireVersion16(t *testing.T) {
+	elements := []Element{
+		NewCodeBlock("x", CodeLanguageGo), NewProgressBar(), NewProgressRing(), NewBadge("b", BadgeStyleDefault),
+		&Icon{Type: TypeIcon, Name: "Calendar"}, NewCompoundButton("t"), NewCarousel(*NewCarouselPage()),
+	}
+	for _, el := range elements {
+		card := NewAdaptiveCard()
+		card.Version = Version15
+		card.Body = append(card.Body, el)
+		err := card.Validate()
+		if err == nil || !strings.Contains(err.Error(), "requires version 1.6 but the card declares 1.5") {
+			t.Errorf("Validate() of %T = %v, want a version error", el, err)
+		}
+
+		// a fallback lets older clients show something else
+		setFallback(el, FallbackElement(NewTextBlock("unsupported")))
+		if err := card.Validate(); err != nil {
+			t.Errorf("Validate() of %T with a fallback = %v", el, err)
+		}
+	}
+}
+
+// setFallback sets the Fallback field of an element
+func setFallback(el Element, f *Fallback) {
+	switch el := el.(type) {
+	case *CodeBlock:
+		el.Fallback = f
+	case *ProgressBar:
+		el.Fallback = f
+	case *ProgressRing:
+		el.Fallback = f
+	case *Badge:
+		el.Fallback = f
+	case *Icon:
+		el.Fallback = f
+	case *CompoundButton:
+		el.Fallback = f
+	case *Carousel:
+		el.Fallback = f
+	}
+}
+
+func TestValidateVersionChecksFallbacks(t *testing.T) {
+	carousel := NewCarousel(*NewCarouselPage(NewBadge("inside", BadgeStyleDefault)))
+	carousel.Fallback = FallbackElement(NewBadge("fallback", BadgeStyleDefault))
+	card := NewAdaptiveCard()
+	card.Version = Version15
+	card.Body = append(card.Body, carousel)
+
+	err := card.Validate()
+	if err == nil || !strings.HasPrefix(err.Error(), "/body/0/fallback: Badge requires version 1.6") {
+		t.Errorf("Validate() = %v, want an error of the fallback", err)
+	}
+}
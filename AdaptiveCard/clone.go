@@ -0,0 +1,82 @@
+package teams
+
+import "reflect"
+
+// Clone returns a deep copy of the card. Elements, actions, fallbacks, slices, maps and the Data of actions are
+// copied, so the clone can be modified without affecting the original
+func (a *AdaptiveCard) Clone() *AdaptiveCard {
+	if a == nil {
+		return nil
+	}
+
+	return deepCopy(reflect.ValueOf(a)).Interface().(*AdaptiveCard)
+}
+
+var fallbackType = reflect.TypeOf(Fallback{})
+
+func (f Fallback) clone() Fallback {
+	c := Fallback{drop: f.drop}
+	if f.element != nil {
+		c.element = deepCopy(reflect.ValueOf(f.element)).Interface().(Element)
+	}
+	if f.action != nil {
+		c.action = deepCopy(reflect.ValueOf(f.action)).Interface().(Action)
+	}
+	if f.column != nil {
+		c.column = deepCopy(reflect.ValueOf(f.column)).Interface().(*Column)
+	}
+
+	return c
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Struct:
+		if v.Type() == fallbackType {
+			return reflect.ValueOf(v.Interface().(Fallback).clone())
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	}
+
+	return v
+}
@@ -0,0 +1,49 @@
+package teams
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCloneIsDeep(t *testing.T) {
+	card := decodeCard(t, `{"type":"AdaptiveCard","version":"1.5","requires":{"adaptiveCards":"1.5"},"body":[
+		{"type":"TextBlock","text":"a","isVisible":false,"fallback":{"type":"TextBlock","text":"old"}},
+		{"type":"Container","items":[{"type":"TextBlock","text":"b"}]}
+	],"actions":[{"type":"Action.Submit","data":{"x":1,"list":[1,2]}}]}`)
+	before, err := json.Marshal(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := card.Clone()
+	tb := clone.Body[0].(*TextBlock)
+	*tb.IsVisible = true
+	tb.Text = "changed"
+	tb.Fallback.Element().(*TextBlock).Text = "changed"
+	clone.Requires["adaptiveCards"] = Version16
+	clone.Body[1].(*Container).Items[0].(*TextBlock).Text = "changed"
+	clone.Body = append(clone.Body[:1], NewTextBlock("appended"))
+	data := clone.Actions[0].(*ActionSubmit).Data.(map[string]interface{})
+	data["x"] = 2
+	data["list"].([]interface{})[0] = 3
+
+	after, err := json.Marshal(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("original after changing the clone = %s, want %s", after, before)
+	}
+}
+
+func TestCloneNil(t *testing.T) {
+	var card *AdaptiveCard
+	if card.Clone() != nil {
+		t.Error("Clone() of a nil card != nil")
+	}
+
+	clone := NewAdaptiveCard().Clone()
+	if clone.Body != nil || clone.Requires != nil {
+		t.Errorf("Clone() = %+v, want nil slices and maps to stay nil", clone)
+	}
+}
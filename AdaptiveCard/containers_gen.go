@@ -0,0 +1,460 @@
+// Code generated by schemagen from adaptive-card-1.6.json; DO NOT EDIT.
+
+package teams
+
+const (
+	TypeActionSet    Type = "ActionSet"
+	TypeCarousel     Type = "Carousel"
+	TypeCarouselPage Type = "CarouselPage"
+	TypeColumn       Type = "Column"
+	TypeColumnSet    Type = "ColumnSet"
+	TypeContainer    Type = "Container"
+	TypeFactSet      Type = "FactSet"
+	TypeImageSet     Type = "ImageSet"
+	TypeTable        Type = "Table"
+	TypeTableCell    Type = "TableCell"
+	TypeTableRow     Type = "TableRow"
+)
+
+// Displays a set of actions
+//
+// Source: https://adaptivecards.io/explorer/ActionSet.html
+type ActionSet struct {
+	// Must be TypeActionSet ("ActionSet")
+	Type Type `json:"type"`
+	// The array of Action elements to show
+	Actions []Action `json:"actions"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewActionSet(actions ...Action) *ActionSet {
+	return &ActionSet{
+		Type:    TypeActionSet,
+		Actions: actions,
+	}
+}
+
+func (a *ActionSet) IsElement() bool {
+	return true
+}
+
+// Displays a set of pages the user can swipe through, one page at a time
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format#carousel-layout
+type Carousel struct {
+	// Must be TypeCarousel ("Carousel")
+	Type Type `json:"type"`
+	// The pages of the carousel
+	Pages []CarouselPage `json:"pages"`
+	// The number of milliseconds after which the carousel advances to the next page. When unset the user has to navigate manually
+	Timer int `json:"timer,omitempty"`
+	// The index of the page displayed first
+	InitialPage int `json:"initialPage,omitempty"`
+	// If true, the carousel starts over at the first page after the last one
+	Loop bool `json:"loop,omitempty"`
+	// The fixed height of the carousel in pixels, like "200px"
+	HeightInPixels string `json:"heightInPixels,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewCarousel(pages ...CarouselPage) *Carousel {
+	return &Carousel{
+		Type:  TypeCarousel,
+		Pages: pages,
+	}
+}
+
+func (c *Carousel) IsElement() bool {
+	return true
+}
+
+// Defines a page of a Carousel
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format#carousel-layout
+type CarouselPage struct {
+	// Must be TypeCarouselPage ("CarouselPage")
+	Type Type `json:"type,omitempty"`
+	// The card elements to render inside the page
+	Items []Element `json:"items"`
+	// An Action that will be invoked when the page is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Style hint for the page
+	Style ContainerStyle `json:"style,omitempty"`
+	// Defines how the content should be aligned vertically within the page
+	VerticalContentAlignment VerticalContentAlignment `json:"verticalContentAlignment,omitempty"`
+	// Specifies the background image. Acceptable formats are PNG, JPEG, and GIF
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+	// Specifies the minimum height of the page in pixels, like "80px"
+	MinHeight string `json:"minHeight,omitempty"`
+	// When true content in this page should be presented right to left
+	Rtl bool `json:"rtl,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewCarouselPage(items ...Element) *CarouselPage {
+	return &CarouselPage{
+		Type:  TypeCarouselPage,
+		Items: items,
+	}
+}
+
+// Defines a container that is part of a ColumnSet
+//
+// Source: https://adaptivecards.io/explorer/Column.html
+type Column struct {
+	// Must be TypeColumn ("Column")
+	Type Type `json:"type,omitempty"`
+	// The card elements to render inside the Column
+	Items []Element `json:"items,omitempty"`
+	// Specifies the background image. Acceptable formats are PNG, JPEG, and GIF
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+	// Determines whether the element should bleed through its parent’s padding
+	Bleed bool `json:"bleed,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the minimum height of the container in pixels, like "80px"
+	MinHeight string `json:"minHeight,omitempty"`
+	// When true content in this container should be presented right to left. When ‘false’ content in this container should be presented left to right. When unset layout direction will inherit from parent container or column. If unset in all ancestors, the default platform behavior will apply
+	Rtl bool `json:"rtl,omitempty"`
+	// When true, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// An Action that will be invoked when the Container is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Style hint for Container
+	Style ContainerStyle `json:"style,omitempty"`
+	// Defines how the content should be aligned vertically within the container. When not specified, the value of verticalContentAlignment is inherited from the parent container. If no parent container has verticalContentAlignment set, it defaults to Top
+	VerticalContentAlignment VerticalContentAlignment `json:"verticalContentAlignment,omitempty"`
+	// "auto", "stretch", a number representing relative width of the column in the column group, or in version 1.1 and higher, a specific pixel width, like "50px"
+	Width interface{} `json:"width,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewColumn() *Column {
+	return &Column{
+		Type: TypeColumn,
+	}
+}
+
+// ColumnSet divides a region into Columns, allowing elements to sit side-by-side
+//
+// Source: https://adaptivecards.io/explorer/ColumnSet.html
+type ColumnSet struct {
+	// Must be TypeColumnSet ("ColumnSet")
+	Type Type `json:"type"`
+	// The array of Columns to divide the region into
+	Columns []Column `json:"columns,omitempty"`
+	// An Action that will be invoked when the Container is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Style hint for Container
+	Style ContainerStyle `json:"style,omitempty"`
+	// Determines whether the element should bleed through its parent’s padding
+	Bleed bool `json:"bleed,omitempty"`
+	// Specifies the minimum height of the container in pixels, like "80px"
+	MinHeight string `json:"minHeight,omitempty"`
+	// Controls the horizontal alignment of the ColumnSet. When not specified, the value of horizontalAlignment is inherited from the parent container. If no parent container has horizontalAlignment set, it defaults to Left
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewColumnSet() *ColumnSet {
+	return &ColumnSet{
+		Type: TypeColumnSet,
+	}
+}
+
+func (c *ColumnSet) IsElement() bool {
+	return true
+}
+
+// Containers group items together
+//
+// Source: https://adaptivecards.io/explorer/Container.html
+type Container struct {
+	// Must be TypeContainer ("Container")
+	Type Type `json:"type"`
+	// The card elements to render inside the Container
+	Items []Element `json:"items"`
+	// An Action that will be invoked when the Container is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Style hint for Container
+	Style ContainerStyle `json:"style,omitempty"`
+	// Defines how the content should be aligned vertically within the container. When not specified, the value of verticalContentAlignment is inherited from the parent container. If no parent container has verticalContentAlignment set, it defaults to Top
+	VerticalContentAlignment VerticalContentAlignment `json:"verticalContentAlignment,omitempty"`
+	// Determines whether the element should bleed through its parent’s padding
+	Bleed bool `json:"bleed,omitempty"`
+	// Specifies the background image. Acceptable formats are PNG, JPEG, and GIF
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+	// Specifies the minimum height of the container in pixels, like "80px"
+	MinHeight string `json:"minHeight,omitempty"`
+	// When true content in this container should be presented right to left. When ‘false’ content in this container should be presented left to right. When unset layout direction will inherit from parent container or column. If unset in all ancestors, the default platform behavior will apply
+	Rtl bool `json:"rtl,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewContainer(items ...Element) *Container {
+	return &Container{
+		Type:  TypeContainer,
+		Items: items,
+	}
+}
+
+func (c *Container) IsElement() bool {
+	return true
+}
+
+// Describes a Fact in a FactSet as a key/value pair
+//
+// Source: https://adaptivecards.io/explorer/Fact.html
+type Fact struct {
+	// The title of the fact
+	Title string `json:"title"`
+	// The value of the fact
+	Value string `json:"value"`
+}
+
+// The FactSet element displays a series of facts (i.e. name/value pairs) in a tabular form
+//
+// Source: https://adaptivecards.io/explorer/FactSet.html
+type FactSet struct {
+	// Must be TypeFactSet ("FactSet")
+	Type Type `json:"type"`
+	// The array of Fact‘s
+	Facts []Fact `json:"facts"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewFactSet(facts ...Fact) *FactSet {
+	return &FactSet{
+		Type:  TypeFactSet,
+		Facts: facts,
+	}
+}
+
+func (f *FactSet) IsElement() bool {
+	return true
+}
+
+// The ImageSet displays a collection of Images similar to a gallery. Acceptable formats are PNG, JPEG, and GIF
+//
+// Source: https://adaptivecards.io/explorer/ImageSet.html
+type ImageSet struct {
+	// Must be TypeImageSet ("ImageSet")
+	Type Type `json:"type"`
+	// The array of Image elements to show
+	Images []Image `json:"images"`
+	// Controls the approximate size of each image. The physical dimensions will vary per host. Auto and stretch are not supported for ImageSet. The size will default to medium if those values are set
+	ImageSize ImageSize `json:"imageSize,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewImageSet(images ...Image) *ImageSet {
+	return &ImageSet{
+		Type:   TypeImageSet,
+		Images: images,
+	}
+}
+
+func (i *ImageSet) IsElement() bool {
+	return true
+}
+
+// Provides a way to display data in a tabular form
+//
+// Source: https://adaptivecards.io/explorer/Table.html
+type Table struct {
+	// Must be TypeTable ("Table")
+	Type Type `json:"type"`
+	// Defines the number of columns in the table, their sizes, and more
+	Columns []TableColumnDefinition `json:"columns,omitempty"`
+	// Defines the rows of the table
+	Rows []TableRow `json:"rows,omitempty"`
+	// Specifies whether the first row of the table should be treated as a header row, and be announced as such by accessibility software
+	FirstRowAsHeader *bool `json:"firstRowAsHeader,omitempty"`
+	// Specifies whether grid lines should be displayed
+	ShowGridLines *bool `json:"showGridLines,omitempty"`
+	// Defines the style of the grid. This property currently only controls the grid’s color
+	GridStyle ContainerStyle `json:"gridStyle,omitempty"`
+	// Controls how the content of all cells is horizontally aligned by default. When not specified, horizontal alignment is defined on a per-cell basis
+	HorizontalCellContentAlignment HorizontalAlignment `json:"horizontalCellContentAlignment,omitempty"`
+	// Controls how the content of all cells is vertically aligned by default. When not specified, vertical alignment is defined on a per-cell basis
+	VerticalCellContentAlignment VerticalAlignment `json:"verticalCellContentAlignment,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// A unique identifier associated with the item
+	Id string `json:"id,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewTable() *Table {
+	return &Table{
+		Type: TypeTable,
+	}
+}
+
+func (t *Table) IsElement() bool {
+	return true
+}
+
+// Represents a cell within a row of a Table element
+//
+// Source: https://adaptivecards.io/explorer/TableCell.html
+type TableCell struct {
+	// Must be TypeTableCell ("TableCell")
+	Type Type `json:"type,omitempty"`
+	// The card elements to render inside the TableCell
+	Items []Element `json:"items"`
+	// An Action that will be invoked when the TableCell is tapped or selected. Action.ShowCard is not supported
+	SelectAction ISelectAction `json:"selectAction,omitempty"`
+	// Style hint for TableCell
+	Style ContainerStyle `json:"style,omitempty"`
+	// Defines how the content should be aligned vertically within the container. When not specified, the value is inherited from the row, column or table
+	VerticalContentAlignment VerticalContentAlignment `json:"verticalContentAlignment,omitempty"`
+	// Determines whether the element should bleed through its parent’s padding
+	Bleed bool `json:"bleed,omitempty"`
+	// Specifies the background image. Acceptable formats are PNG, JPEG, and GIF
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+	// Specifies the minimum height of the container in pixels, like "80px"
+	MinHeight string `json:"minHeight,omitempty"`
+	// When true content in this container should be presented right to left
+	Rtl bool `json:"rtl,omitempty"`
+}
+
+func NewTableCell(items ...Element) *TableCell {
+	return &TableCell{
+		Type:  TypeTableCell,
+		Items: items,
+	}
+}
+
+// Defines the characteristics of a column in a Table element
+//
+// Source: https://adaptivecards.io/explorer/TableColumnDefinition.html
+type TableColumnDefinition struct {
+	// Specifies the width of the column. If expressed as a number, represents the relative weight of the column in the table. If expressed as a string, "auto" or a specific pixel width, like "50px"
+	Width interface{} `json:"width,omitempty"`
+	// Controls how the content of all cells in the column is horizontally aligned by default. When specified, this value overrides the setting at the table level
+	HorizontalCellContentAlignment HorizontalAlignment `json:"horizontalCellContentAlignment,omitempty"`
+	// Controls how the content of all cells in the column is vertically aligned by default. When specified, this value overrides the setting at the table level
+	VerticalCellContentAlignment VerticalAlignment `json:"verticalCellContentAlignment,omitempty"`
+}
+
+// Represents a row of cells within a Table element
+//
+// Source: https://adaptivecards.io/explorer/TableRow.html
+type TableRow struct {
+	// Must be TypeTableRow ("TableRow")
+	Type Type `json:"type,omitempty"`
+	// The cells in this row. If a row contains more cells than there are columns defined on the Table element, the extra cells are ignored
+	Cells []TableCell `json:"cells,omitempty"`
+	// Defines the style of the entire row
+	Style ContainerStyle `json:"style,omitempty"`
+	// Controls how the content of all cells in the row is horizontally aligned by default. When specified, this value overrides both the setting at the table and columns level
+	HorizontalCellContentAlignment HorizontalAlignment `json:"horizontalCellContentAlignment,omitempty"`
+	// Controls how the content of all cells in the row is vertically aligned by default. When specified, this value overrides both the setting at the table and columns level
+	VerticalCellContentAlignment VerticalAlignment `json:"verticalCellContentAlignment,omitempty"`
+}
+
+func NewTableRow() *TableRow {
+	return &TableRow{
+		Type: TypeTableRow,
+	}
+}
@@ -0,0 +1,166 @@
+package teams
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// peekType returns the value of the "type" property of a JSON object
+func peekType(data []byte) (Type, error) {
+	var head struct {
+		Type Type `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return "", err
+	}
+
+	return head.Type, nil
+}
+
+func decodeElement(data []byte) (Element, error) {
+	t, err := peekType(data)
+	if err != nil {
+		return nil, err
+	}
+	if t == "" {
+		return nil, errors.New("element type is missing")
+	}
+
+	newElement, ok := elementTypes[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown element type %q", t)
+	}
+	el := newElement()
+	if err := json.Unmarshal(data, el); err != nil {
+		return nil, fmt.Errorf("%s: %w", t, err)
+	}
+
+	return el, nil
+}
+
+func decodeElements(raw []json.RawMessage) ([]Element, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	elements := make([]Element, 0, len(raw))
+	for i, data := range raw {
+		el, err := decodeElement(data)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		elements = append(elements, el)
+	}
+
+	return elements, nil
+}
+
+func decodeAction(data []byte) (Action, error) {
+	t, err := peekType(data)
+	if err != nil {
+		return nil, err
+	}
+	if t == "" {
+		return nil, errors.New("action type is missing")
+	}
+
+	newAction, ok := actionTypes[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown action type %q", t)
+	}
+	a := newAction()
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("%s: %w", t, err)
+	}
+
+	return a, nil
+}
+
+func decodeActions(raw []json.RawMessage) ([]Action, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	actions := make([]Action, 0, len(raw))
+	for i, data := range raw {
+		a, err := decodeAction(data)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, nil
+}
+
+// decodeSelectAction decodes the selectAction of a card, container, column, image or text run, rejecting actions
+// that can’t be used as one
+func decodeSelectAction(data json.RawMessage) (ISelectAction, error) {
+	if data == nil || string(data) == "null" {
+		return nil, nil
+	}
+
+	a, err := decodeAction(data)
+	if err != nil {
+		return nil, err
+	}
+	sa, ok := a.(ISelectAction)
+	if !ok {
+		t, _ := peekType(data)
+		return nil, fmt.Errorf("%s is not supported", t)
+	}
+
+	return sa, nil
+}
+
+// UnmarshalJSON accepts both the object form of a TextRun and its shorthand, a plain string holding the text
+func (t *TextRun) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*t = TextRun{Type: TypeTextRun, Text: text}
+		return nil
+	}
+
+	type alias TextRun
+	aux := struct {
+		*alias
+		SelectAction json.RawMessage `json:"selectAction,omitempty"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if t.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON accepts both the object form of a TargetElement and its shorthand, a plain string holding the id of
+// the element
+func (t *TargetElement) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*t = TargetElement{ElementId: id}
+		return nil
+	}
+
+	type alias TargetElement
+	return json.Unmarshal(data, (*alias)(t))
+}
+
+// UnmarshalJSON accepts both the object form of a BackgroundImage and its shorthand, a plain string holding the URL
+// of the image
+func (b *BackgroundImage) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*b = BackgroundImage{Url: url}
+		return nil
+	}
+
+	type alias BackgroundImage
+	return json.Unmarshal(data, (*alias)(b))
+}
@@ -0,0 +1,267 @@
+// Code generated by schemagen from adaptive-card-1.6.json; DO NOT EDIT.
+
+package teams
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// elementTypes maps the type discriminator of every known element to a constructor of its Go type
+var elementTypes = map[Type]func() Element{
+	TypeActionSet:      func() Element { return &ActionSet{} },
+	TypeBadge:          func() Element { return &Badge{} },
+	TypeCarousel:       func() Element { return &Carousel{} },
+	TypeCodeBlock:      func() Element { return &CodeBlock{} },
+	TypeColumnSet:      func() Element { return &ColumnSet{} },
+	TypeCompoundButton: func() Element { return &CompoundButton{} },
+	TypeContainer:      func() Element { return &Container{} },
+	TypeFactSet:        func() Element { return &FactSet{} },
+	TypeIcon:           func() Element { return &Icon{} },
+	TypeImage:          func() Element { return &Image{} },
+	TypeImageSet:       func() Element { return &ImageSet{} },
+	TypeInputChoiceSet: func() Element { return &InputChoiceSet{} },
+	TypeInputDate:      func() Element { return &InputDate{} },
+	TypeInputNumber:    func() Element { return &InputNumber{} },
+	TypeInputRating:    func() Element { return &InputRating{} },
+	TypeInputText:      func() Element { return &InputText{} },
+	TypeInputTime:      func() Element { return &InputTime{} },
+	TypeInputToggle:    func() Element { return &InputToggle{} },
+	TypeMedia:          func() Element { return &Media{} },
+	TypeProgressBar:    func() Element { return &ProgressBar{} },
+	TypeProgressRing:   func() Element { return &ProgressRing{} },
+	TypeRichTextBlock:  func() Element { return &RichTextBlock{} },
+	TypeTable:          func() Element { return &Table{} },
+	TypeTextBlock:      func() Element { return &TextBlock{} },
+}
+
+// actionTypes maps the type discriminator of every known action to a constructor of its Go type
+var actionTypes = map[Type]func() Action{
+	TypeActionExecute:          func() Action { return &ActionExecute{} },
+	TypeActionOpenUrl:          func() Action { return &ActionOpenUrl{} },
+	TypeActionShowCard:         func() Action { return &ActionShowCard{} },
+	TypeActionSubmit:           func() Action { return &ActionSubmit{} },
+	TypeActionToggleVisibility: func() Action { return &ActionToggleVisibility{} },
+}
+
+func (a *ActionSet) UnmarshalJSON(data []byte) error {
+	type alias ActionSet
+	aux := struct {
+		*alias
+		Actions []json.RawMessage `json:"actions"`
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if a.Actions, err = decodeActions(aux.Actions); err != nil {
+		return fmt.Errorf("actions%w", err)
+	}
+
+	return nil
+}
+
+func (a *AdaptiveCard) UnmarshalJSON(data []byte) error {
+	type alias AdaptiveCard
+	aux := struct {
+		*alias
+		Body         []json.RawMessage `json:"body,omitempty"`
+		Actions      []json.RawMessage `json:"actions,omitempty"`
+		SelectAction json.RawMessage   `json:"selectAction,omitempty"`
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if a.Body, err = decodeElements(aux.Body); err != nil {
+		return fmt.Errorf("body%w", err)
+	}
+	if a.Actions, err = decodeActions(aux.Actions); err != nil {
+		return fmt.Errorf("actions%w", err)
+	}
+	if a.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (c *CarouselPage) UnmarshalJSON(data []byte) error {
+	type alias CarouselPage
+	aux := struct {
+		*alias
+		Items        []json.RawMessage `json:"items"`
+		SelectAction json.RawMessage   `json:"selectAction,omitempty"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if c.Items, err = decodeElements(aux.Items); err != nil {
+		return fmt.Errorf("items%w", err)
+	}
+	if c.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Column) UnmarshalJSON(data []byte) error {
+	type alias Column
+	aux := struct {
+		*alias
+		Items        []json.RawMessage `json:"items,omitempty"`
+		SelectAction json.RawMessage   `json:"selectAction,omitempty"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if c.Items, err = decodeElements(aux.Items); err != nil {
+		return fmt.Errorf("items%w", err)
+	}
+	if c.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ColumnSet) UnmarshalJSON(data []byte) error {
+	type alias ColumnSet
+	aux := struct {
+		*alias
+		SelectAction json.RawMessage `json:"selectAction,omitempty"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if c.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (c *CompoundButton) UnmarshalJSON(data []byte) error {
+	type alias CompoundButton
+	aux := struct {
+		*alias
+		SelectAction json.RawMessage `json:"selectAction,omitempty"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if c.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Container) UnmarshalJSON(data []byte) error {
+	type alias Container
+	aux := struct {
+		*alias
+		Items        []json.RawMessage `json:"items"`
+		SelectAction json.RawMessage   `json:"selectAction,omitempty"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if c.Items, err = decodeElements(aux.Items); err != nil {
+		return fmt.Errorf("items%w", err)
+	}
+	if c.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (i *Icon) UnmarshalJSON(data []byte) error {
+	type alias Icon
+	aux := struct {
+		*alias
+		SelectAction json.RawMessage `json:"selectAction,omitempty"`
+	}{alias: (*alias)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if i.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (i *Image) UnmarshalJSON(data []byte) error {
+	type alias Image
+	aux := struct {
+		*alias
+		SelectAction json.RawMessage `json:"selectAction,omitempty"`
+	}{alias: (*alias)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if i.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
+
+func (i *InputText) UnmarshalJSON(data []byte) error {
+	type alias InputText
+	aux := struct {
+		*alias
+		InlineAction json.RawMessage `json:"inlineAction,omitempty"`
+	}{alias: (*alias)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if i.InlineAction, err = decodeSelectAction(aux.InlineAction); err != nil {
+		return fmt.Errorf("inlineAction: %w", err)
+	}
+
+	return nil
+}
+
+func (t *TableCell) UnmarshalJSON(data []byte) error {
+	type alias TableCell
+	aux := struct {
+		*alias
+		Items        []json.RawMessage `json:"items"`
+		SelectAction json.RawMessage   `json:"selectAction,omitempty"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if t.Items, err = decodeElements(aux.Items); err != nil {
+		return fmt.Errorf("items%w", err)
+	}
+	if t.SelectAction, err = decodeSelectAction(aux.SelectAction); err != nil {
+		return fmt.Errorf("selectAction: %w", err)
+	}
+
+	return nil
+}
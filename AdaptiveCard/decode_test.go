@@ -0,0 +1,214 @@
+package teams
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// roundTrip decodes the JSON of a card, checks that encoding it again gives the same JSON and returns the card
+func roundTrip(t *testing.T, data string) *AdaptiveCard {
+	t.Helper()
+
+	var card AdaptiveCard
+	if err := json.Unmarshal([]byte(data), &card); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	out, err := json.Marshal(&card)
+	if err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(data), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Marshal() = %s, want %s", out, data)
+	}
+
+	return &card
+}
+
+func TestFallbackRoundTrip(t *testing.T) {
+	card := roundTrip(t, `{"type":"AdaptiveCard","version":"1.2","body":[
+		{"type":"TextBlock","text":"a","fallback":"drop","requires":{"adaptiveCards":"1.2","acme.feature":"2.1"}},
+		{"type":"TextBlock","text":"b","fallback":{"type":"TextBlock","text":"old b"}},
+		{"type":"ColumnSet","columns":[{"type":"Column","fallback":{"type":"Column","width":"auto"}}]}
+	],"actions":[{"type":"Action.Submit","fallback":{"type":"Action.OpenUrl","url":"https://example.com"}}]}`)
+
+	a := card.Body[0].(*TextBlock)
+	if !a.Fallback.IsDrop() {
+		t.Errorf("body[0].Fallback = %+v, want drop", a.Fallback)
+	}
+	if want := map[string]Version{"adaptiveCards": Version12, "acme.feature": "2.1"}; !reflect.DeepEqual(a.Requires, want) {
+		t.Errorf("body[0].Requires = %v, want %v", a.Requires, want)
+	}
+	if el, ok := card.Body[1].(*TextBlock).Fallback.Element().(*TextBlock); !ok || el.Text != "old b" {
+		t.Errorf("body[1].Fallback = %+v, want a TextBlock", card.Body[1].(*TextBlock).Fallback)
+	}
+	if _, ok := card.Actions[0].(*ActionSubmit).Fallback.Action().(*ActionOpenUrl); !ok {
+		t.Errorf("actions[0].Fallback = %+v, want an Action.OpenUrl", card.Actions[0].(*ActionSubmit).Fallback)
+	}
+	if err := card.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+func TestValidateFallbackKind(t *testing.T) {
+	tests := []struct {
+		name string
+		card func() *AdaptiveCard
+		want string
+	}{
+		{
+			name: "element with an action",
+			card: func() *AdaptiveCard {
+				tb := NewTextBlock("x")
+				tb.Fallback = FallbackAction(NewActionSubmit())
+				card := NewAdaptiveCard()
+				card.Body = append(card.Body, tb)
+				return card
+			},
+			want: `body[0]: Fallback of an element must be "drop" or an element`,
+		},
+		{
+			name: "action with an element",
+			card: func() *AdaptiveCard {
+				a := NewActionSubmit()
+				a.Fallback = FallbackElement(NewTextBlock("x"))
+				card := NewAdaptiveCard()
+				card.Actions = append(card.Actions, a)
+				return card
+			},
+			want: `actions[0]: Fallback of an action must be "drop" or an action`,
+		},
+		{
+			name: "invalid replacement",
+			card: func() *AdaptiveCard {
+				tb := NewTextBlock("x")
+				tb.Fallback = FallbackElement(&TextBlock{Text: "no type"})
+				card := NewAdaptiveCard()
+				card.Body = append(card.Body, tb)
+				return card
+			},
+			want: "body[0]: fallback: Type is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.card().Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectActionRoundTrip(t *testing.T) {
+	card := roundTrip(t, `{"type":"AdaptiveCard","version":"1.4",
+		"selectAction":{"type":"Action.OpenUrl","url":"https://example.com"},
+		"body":[
+			{"type":"Container","selectAction":{"type":"Action.Submit","data":{"x":1}},"items":[
+				{"type":"Image","url":"https://example.com/a.png","selectAction":{"type":"Action.ToggleVisibility","targetElements":[{"elementId":"a"}]}}
+			]},
+			{"type":"ColumnSet","selectAction":{"type":"Action.Execute","verb":"go"},"columns":[
+				{"type":"Column","selectAction":{"type":"Action.OpenUrl","url":"https://example.com/c"}}
+			]},
+			{"type":"RichTextBlock","inlines":[{"type":"TextRun","text":"t","selectAction":{"type":"Action.Submit"}}]}
+		]}`)
+
+	if _, ok := card.SelectAction.(*ActionOpenUrl); !ok {
+		t.Errorf("SelectAction = %T, want *ActionOpenUrl", card.SelectAction)
+	}
+	container := card.Body[0].(*Container)
+	if _, ok := container.SelectAction.(*ActionSubmit); !ok {
+		t.Errorf("body[0].SelectAction = %T, want *ActionSubmit", container.SelectAction)
+	}
+	if _, ok := container.Items[0].(*Image).SelectAction.(*ActionToggleVisibility); !ok {
+		t.Errorf("body[0].items[0].SelectAction = %T, want *ActionToggleVisibility", container.Items[0].(*Image).SelectAction)
+	}
+	columnSet := card.Body[1].(*ColumnSet)
+	if _, ok := columnSet.SelectAction.(*ActionExecute); !ok {
+		t.Errorf("body[1].SelectAction = %T, want *ActionExecute", columnSet.SelectAction)
+	}
+	if _, ok := columnSet.Columns[0].SelectAction.(*ActionOpenUrl); !ok {
+		t.Errorf("body[1].columns[0].SelectAction = %T, want *ActionOpenUrl", columnSet.Columns[0].SelectAction)
+	}
+	if _, ok := card.Body[2].(*RichTextBlock).Inlines[0].SelectAction.(*ActionSubmit); !ok {
+		t.Errorf("body[2].inlines[0].SelectAction = %T, want *ActionSubmit", card.Body[2].(*RichTextBlock).Inlines[0].SelectAction)
+	}
+	if err := card.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+func TestSelectActionRejectsShowCard(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"card", `{"type":"AdaptiveCard","selectAction":{"type":"Action.ShowCard","card":{"type":"AdaptiveCard"}}}`, "Action.ShowCard is not supported"},
+		{"container", `{"type":"AdaptiveCard","body":[{"type":"Container","selectAction":{"type":"Action.ShowCard"}}]}`, "Action.ShowCard is not supported"},
+		{"column", `{"type":"AdaptiveCard","body":[{"type":"ColumnSet","columns":[{"type":"Column","selectAction":{"type":"Action.ShowCard"}}]}]}`, "Action.ShowCard is not supported"},
+		{"image", `{"type":"AdaptiveCard","body":[{"type":"Image","url":"https://example.com/a.png","selectAction":{"type":"Action.ShowCard"}}]}`, "Action.ShowCard is not supported"},
+		{"text run", `{"type":"AdaptiveCard","body":[{"type":"RichTextBlock","inlines":[{"type":"TextRun","text":"t","selectAction":{"type":"Action.ShowCard"}}]}]}`, "Action.ShowCard is not supported"},
+		{"array", `{"type":"AdaptiveCard","selectAction":[{"type":"Action.Submit"}]}`, "cannot unmarshal array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var card AdaptiveCard
+			if err := json.Unmarshal([]byte(tt.json), &card); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Unmarshal() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSelectAction(t *testing.T) {
+	card := NewAdaptiveCard()
+	card.SelectAction = NewActionOpenUrl()
+	if err := card.Validate(); err == nil || !strings.HasPrefix(err.Error(), "selectAction: ") {
+		t.Errorf("Validate() = %v, want an error of the selectAction", err)
+	}
+
+	data, err := json.Marshal(&AdaptiveCard{Type: TypeAdaptiveCard, SelectAction: &ActionSubmit{Type: TypeActionSubmit}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"selectAction":{"type":"Action.Submit"}`; !strings.Contains(string(data), want) {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestBackgroundImageForms(t *testing.T) {
+	card := decodeCard(t, `{"type":"AdaptiveCard","version":"1.5","backgroundImage":"https://example.com/card.png","body":[
+		{"type":"Container","backgroundImage":{"url":"https://example.com/container.png","fillMode":"repeat"}},
+		{"type":"ColumnSet","columns":[{"type":"Column","backgroundImage":"https://example.com/column.png"}]}
+	]}`)
+
+	if want := (BackgroundImage{Url: "https://example.com/card.png"}); card.BackgroundImage == nil || *card.BackgroundImage != want {
+		t.Errorf("card.BackgroundImage = %+v, want %+v", card.BackgroundImage, want)
+	}
+	container := card.Body[0].(*Container)
+	if want := (BackgroundImage{Url: "https://example.com/container.png", FillMode: ImageFillModeRepeat}); container.BackgroundImage == nil || *container.BackgroundImage != want {
+		t.Errorf("container.BackgroundImage = %+v, want %+v", container.BackgroundImage, want)
+	}
+	column := card.Body[1].(*ColumnSet).Columns[0]
+	if want := (BackgroundImage{Url: "https://example.com/column.png"}); column.BackgroundImage == nil || *column.BackgroundImage != want {
+		t.Errorf("column.BackgroundImage = %+v, want %+v", column.BackgroundImage, want)
+	}
+	if err := card.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	var bg BackgroundImage
+	if err := json.Unmarshal([]byte(`1`), &bg); err == nil {
+		t.Error("Unmarshal() of a number = nil, want an error")
+	}
+}
@@ -0,0 +1,146 @@
+package teams
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type DifferenceKind string
+
+const (
+	// The value only exists in the second card
+	DifferenceAdded DifferenceKind = "added"
+	// The value only exists in the first card
+	DifferenceRemoved DifferenceKind = "removed"
+	// The value exists in both cards but differs
+	DifferenceChanged DifferenceKind = "changed"
+)
+
+// A Difference between two cards, as reported by Diff
+type Difference struct {
+	// The location of the difference in the JSON representation of the cards as a JSON pointer (RFC 6901)
+	Path string
+	// Whether the value has been added, removed or changed
+	Kind DifferenceKind
+	// The value in the first card, nil if it has been added
+	From interface{}
+	// The value in the second card, nil if it has been removed
+	To interface{}
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DifferenceAdded:
+		return fmt.Sprintf("+ %s: %s", d.Path, diffValue(d.To))
+	case DifferenceRemoved:
+		return fmt.Sprintf("- %s: %s", d.Path, diffValue(d.From))
+	}
+
+	return fmt.Sprintf("~ %s: %s -> %s", d.Path, diffValue(d.From), diffValue(d.To))
+}
+
+// Diff compares the JSON representations of two cards and reports every property and array item that differs,
+// ordered by path. Arrays are compared item by item, so inserting an element reports the items after it as changed
+func Diff(a *AdaptiveCard, b *AdaptiveCard) ([]Difference, error) {
+	av, err := diffTree(a)
+	if err != nil {
+		return nil, err
+	}
+	bv, err := diffTree(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []Difference
+	diffValues("", av, bv, &diffs)
+
+	return diffs, nil
+}
+
+// diffTree converts a card to the generic representation produced by encoding/json
+func diffTree(card *AdaptiveCard) (interface{}, error) {
+	if card == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func diffValues(path string, a interface{}, b interface{}, diffs *[]Difference) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, ok := av[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				p := path + "/" + escapePointerToken(k)
+				va, inA := av[k]
+				vb, inB := bv[k]
+				switch {
+				case !inA:
+					*diffs = append(*diffs, Difference{Path: p, Kind: DifferenceAdded, To: vb})
+				case !inB:
+					*diffs = append(*diffs, Difference{Path: p, Kind: DifferenceRemoved, From: va})
+				default:
+					diffValues(p, va, vb, diffs)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			for i := 0; i < len(av) || i < len(bv); i++ {
+				p := path + "/" + strconv.Itoa(i)
+				switch {
+				case i >= len(av):
+					*diffs = append(*diffs, Difference{Path: p, Kind: DifferenceAdded, To: bv[i]})
+				case i >= len(bv):
+					*diffs = append(*diffs, Difference{Path: p, Kind: DifferenceRemoved, From: av[i]})
+				default:
+					diffValues(p, av[i], bv[i], diffs)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, Difference{Path: path, Kind: DifferenceChanged, From: a, To: b})
+	}
+}
+
+func diffValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+// escapePointerToken escapes a reference token of a JSON pointer as described in RFC 6901
+func escapePointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
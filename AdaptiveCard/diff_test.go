@@ -0,0 +1,99 @@
+package teams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want []string
+	}{
+		{
+			name: "equal",
+			a:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"}]}`,
+			b:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"}]}`,
+		},
+		{
+			name: "replace",
+			a:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"}]}`,
+			b:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"b"}]}`,
+			want: []string{`~ /body/0/text: "a" -> "b"`},
+		},
+		{
+			name: "add property",
+			a:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"}]}`,
+			b:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a","wrap":true}]}`,
+			want: []string{`+ /body/0/wrap: true`},
+		},
+		{
+			name: "remove property",
+			a:    `{"type":"AdaptiveCard","version":"1.5"}`,
+			b:    `{"type":"AdaptiveCard"}`,
+			want: []string{`- /version: "1.5"`},
+		},
+		{
+			name: "append item",
+			a:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"}]}`,
+			b:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"},{"type":"TextBlock","text":"b"}]}`,
+			want: []string{`+ /body/1: {"text":"b","type":"TextBlock"}`},
+		},
+		{
+			name: "remove last item",
+			a:    `{"type":"AdaptiveCard","actions":[{"type":"Action.Submit"},{"type":"Action.Submit","title":"b"}]}`,
+			b:    `{"type":"AdaptiveCard","actions":[{"type":"Action.Submit"}]}`,
+			want: []string{`- /actions/1: {"title":"b","type":"Action.Submit"}`},
+		},
+		{
+			name: "insert item",
+			a:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"},{"type":"TextBlock","text":"c"}]}`,
+			b:    `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a"},{"type":"TextBlock","text":"b"},{"type":"TextBlock","text":"c"}]}`,
+			want: []string{`~ /body/1/text: "c" -> "b"`, `+ /body/2: {"text":"c","type":"TextBlock"}`},
+		},
+		{
+			name: "nested and escaped",
+			a:    `{"type":"AdaptiveCard","body":[{"type":"Container","items":[{"type":"TextBlock","text":"a"}]}],"requires":{"acme/x~y":"1.0"}}`,
+			b:    `{"type":"AdaptiveCard","body":[{"type":"Container","items":[{"type":"Image","url":"https://example.com/a.png"}]}],"requires":{"acme/x~y":"2.0"}}`,
+			want: []string{
+				`- /body/0/items/0/text: "a"`,
+				`~ /body/0/items/0/type: "TextBlock" -> "Image"`,
+				`+ /body/0/items/0/url: "https://example.com/a.png"`,
+				`~ /requires/acme~1x~0y: "1.0" -> "2.0"`,
+			},
+		},
+		{
+			name: "changed type of value",
+			a:    `{"type":"AdaptiveCard","actions":[{"type":"Action.Submit","data":{"x":[1]}}]}`,
+			b:    `{"type":"AdaptiveCard","actions":[{"type":"Action.Submit","data":{"x":{"y":1}}}]}`,
+			want: []string{`~ /actions/0/data/x: [1] -> {"y":1}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := Diff(decodeCard(t, tt.a), decodeCard(t, tt.b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, d := range diffs {
+				got = append(got, d.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffNil(t *testing.T) {
+	diffs, err := Diff(nil, NewAdaptiveCard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Path != "" || diffs[0].Kind != DifferenceChanged {
+		t.Errorf("Diff(nil, card) = %v, want the whole card changed", diffs)
+	}
+}
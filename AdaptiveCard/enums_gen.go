@@ -0,0 +1,328 @@
+// Code generated by schemagen from adaptive-card-1.6.json; DO NOT EDIT.
+
+package teams
+
+// Determines whether an action is displayed with a button or is moved to the overflow menu
+type ActionMode string
+
+const (
+	ActionModePrimary   ActionMode = "primary"
+	ActionModeSecondary ActionMode = "secondary"
+)
+
+// Controls the style of an Action, which influences how the action is displayed, spoken, etc.
+type ActionStyle string
+
+const (
+	ActionStyleDefault     ActionStyle = "default"
+	ActionStylePositive    ActionStyle = "positive"
+	ActionStyleDestructive ActionStyle = "destructive"
+)
+
+// Controls which inputs are associated with the submit action
+type AssociatedInputs string
+
+const (
+	AssociatedInputAuto AssociatedInputs = "Auto"
+	AssociatedInputNone AssociatedInputs = "None"
+)
+
+// The appearance of a badge
+type BadgeAppearance string
+
+const (
+	BadgeAppearanceFilled BadgeAppearance = "filled"
+	BadgeAppearanceTint   BadgeAppearance = "tint"
+)
+
+// The shape of a badge
+type BadgeShape string
+
+const (
+	BadgeShapeSquare   BadgeShape = "square"
+	BadgeShapeRounded  BadgeShape = "rounded"
+	BadgeShapeCircular BadgeShape = "circular"
+)
+
+// The size of a badge
+type BadgeSize string
+
+const (
+	BadgeSizeMedium     BadgeSize = "medium"
+	BadgeSizeLarge      BadgeSize = "large"
+	BadgeSizeExtraLarge BadgeSize = "extraLarge"
+)
+
+// The style of a badge
+type BadgeStyle string
+
+const (
+	BadgeStyleDefault     BadgeStyle = "default"
+	BadgeStyleSubtle      BadgeStyle = "subtle"
+	BadgeStyleInformative BadgeStyle = "informative"
+	BadgeStyleAccent      BadgeStyle = "accent"
+	BadgeStyleGood        BadgeStyle = "good"
+	BadgeStyleAttention   BadgeStyle = "attention"
+	BadgeStyleWarning     BadgeStyle = "warning"
+)
+
+// Specifies the height of the element
+type BlockElementHeight string
+
+const (
+	BlockElementHeightAuto    BlockElementHeight = "auto"
+	BlockElementHeightStretch BlockElementHeight = "stretch"
+)
+
+// Style hint for Input.ChoiceSet
+type ChoiceInputStyle string
+
+const (
+	ChoiceInputStyleCompact  ChoiceInputStyle = "compact"
+	ChoiceInputStyleExpanded ChoiceInputStyle = "expanded"
+	ChoiceInputStyleFiltered ChoiceInputStyle = "filtered"
+)
+
+// The language of a code snippet
+type CodeLanguage string
+
+const (
+	CodeLanguageBash       CodeLanguage = "Bash"
+	CodeLanguageC          CodeLanguage = "C"
+	CodeLanguageCpp        CodeLanguage = "Cpp"
+	CodeLanguageCSharp     CodeLanguage = "CSharp"
+	CodeLanguageCss        CodeLanguage = "Css"
+	CodeLanguageDos        CodeLanguage = "Dos"
+	CodeLanguageGo         CodeLanguage = "Go"
+	CodeLanguageGraphql    CodeLanguage = "Graphql"
+	CodeLanguageHtml       CodeLanguage = "Html"
+	CodeLanguageJava       CodeLanguage = "Java"
+	CodeLanguageJavaScript CodeLanguage = "JavaScript"
+	CodeLanguageJson       CodeLanguage = "Json"
+	CodeLanguageObjectiveC CodeLanguage = "ObjectiveC"
+	CodeLanguagePerl       CodeLanguage = "Perl"
+	CodeLanguagePhp        CodeLanguage = "Php"
+	CodeLanguagePlainText  CodeLanguage = "PlainText"
+	CodeLanguagePowerShell CodeLanguage = "PowerShell"
+	CodeLanguagePython     CodeLanguage = "Python"
+	CodeLanguageSql        CodeLanguage = "Sql"
+	CodeLanguageTypeScript CodeLanguage = "TypeScript"
+	CodeLanguageVbNet      CodeLanguage = "VbNet"
+	CodeLanguageVerilog    CodeLanguage = "Verilog"
+	CodeLanguageVhdl       CodeLanguage = "Vhdl"
+	CodeLanguageXml        CodeLanguage = "Xml"
+)
+
+// Controls the color of text
+type Colors string
+
+const (
+	ColorDefault   Colors = "default"
+	ColorDark      Colors = "dark"
+	ColorLight     Colors = "light"
+	ColorAccent    Colors = "accent"
+	ColorGood      Colors = "good"
+	ColorWarning   Colors = "warning"
+	ColorAttention Colors = "attention"
+)
+
+// Style hint for containers
+type ContainerStyle string
+
+const (
+	ContainerStyleDefault   ContainerStyle = "default"
+	ContainerStyleEmphasis  ContainerStyle = "emphasis"
+	ContainerStyleGood      ContainerStyle = "good"
+	ContainerStyleAttention ContainerStyle = "attention"
+	ContainerStyleWarning   ContainerStyle = "warning"
+	ContainerStyleAccent    ContainerStyle = "accent"
+)
+
+// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+type FallbackOption string
+
+const (
+	FallbackOptionDrop FallbackOption = "drop"
+)
+
+// Controls the size of text
+type FontSize string
+
+const (
+	FontSizeDefault    FontSize = "default"
+	FontSizeSmall      FontSize = "small"
+	FontSizeMedium     FontSize = "medium"
+	FontSizeLarge      FontSize = "large"
+	FontSizeExtraLarge FontSize = "extraLarge"
+)
+
+// Type of font to use for rendering
+type FontType string
+
+const (
+	FontTypeDefault   FontType = "default"
+	FontTypeMonospace FontType = "monospace"
+)
+
+// Controls the weight of text
+type FontWeight string
+
+const (
+	FontWeightDefault FontWeight = "default"
+	FontWeightLighter FontWeight = "lighter"
+	FontWeightBolder  FontWeight = "bolder"
+)
+
+// Controls how content is horizontally positioned within its container
+type HorizontalAlignment string
+
+const (
+	HorizontalAlignmentLeft   HorizontalAlignment = "left"
+	HorizontalAlignmentCenter HorizontalAlignment = "center"
+	HorizontalAlignmentRight  HorizontalAlignment = "right"
+)
+
+// The position of the icon of a badge relative to its text
+type IconPosition string
+
+const (
+	IconPositionBefore IconPosition = "before"
+	IconPositionAfter  IconPosition = "after"
+)
+
+// The size of an icon
+type IconSize string
+
+const (
+	IconSizeXxSmall  IconSize = "xxSmall"
+	IconSizeXSmall   IconSize = "xSmall"
+	IconSizeSmall    IconSize = "Small"
+	IconSizeStandard IconSize = "Standard"
+	IconSizeMedium   IconSize = "Medium"
+	IconSizeLarge    IconSize = "Large"
+	IconSizeXLarge   IconSize = "xLarge"
+	IconSizeXxLarge  IconSize = "xxLarge"
+)
+
+// The style of an icon
+type IconStyle string
+
+const (
+	IconStyleRegular IconStyle = "Regular"
+	IconStyleFilled  IconStyle = "Filled"
+)
+
+// Describes how the image should fill the area
+type ImageFillMode string
+
+const (
+	ImageFillModeCover              ImageFillMode = "cover"
+	ImageFillModeRepeatHorizontally ImageFillMode = "repeatHorizontally"
+	ImageFillModeRepeatVertically   ImageFillMode = "repeatVertically"
+	ImageFillModeRepeat             ImageFillMode = "repeat"
+)
+
+// Controls the approximate size of the image. The physical dimensions will vary per host
+type ImageSize string
+
+const (
+	ImageSizeAuto    ImageSize = "auto"
+	ImageSizeStretch ImageSize = "stretch"
+	ImageSizeSmall   ImageSize = "small"
+	ImageSizeMedium  ImageSize = "medium"
+	ImageSizeLarge   ImageSize = "large"
+)
+
+// Controls how this Image is displayed
+type ImageStyle string
+
+const (
+	ImageStyleDefault ImageStyle = "default"
+	ImageStylePerson  ImageStyle = "person"
+)
+
+// The position of the label of a progress ring
+type LabelPosition string
+
+const (
+	LabelPositionBefore LabelPosition = "before"
+	LabelPositionAfter  LabelPosition = "after"
+	LabelPositionAbove  LabelPosition = "above"
+	LabelPositionBelow  LabelPosition = "below"
+)
+
+// The size of a progress ring
+type ProgressRingSize string
+
+const (
+	ProgressRingSizeTiny   ProgressRingSize = "tiny"
+	ProgressRingSizeSmall  ProgressRingSize = "small"
+	ProgressRingSizeMedium ProgressRingSize = "medium"
+	ProgressRingSizeLarge  ProgressRingSize = "large"
+)
+
+// The color of the stars of a rating input
+type RatingColor string
+
+const (
+	RatingColorNeutral  RatingColor = "neutral"
+	RatingColorMarigold RatingColor = "marigold"
+)
+
+// The size of the stars of a rating input
+type RatingSize string
+
+const (
+	RatingSizeMedium RatingSize = "medium"
+	RatingSizeLarge  RatingSize = "large"
+)
+
+// Specifies how much spacing. Hosts pick the exact pixel amounts for each of these
+type Spacing string
+
+const (
+	SpacingDefault    Spacing = "default"
+	SpacingNone       Spacing = "none"
+	SpacingSmall      Spacing = "small"
+	SpacingMedium     Spacing = "medium"
+	SpacingLarge      Spacing = "large"
+	SpacingExtraLarge Spacing = "extraLarge"
+	SpacingPadding    Spacing = "padding"
+)
+
+// Controls how a TextBlock behaves
+type TextBlockStyle string
+
+const (
+	TextBlockStyleDefault TextBlockStyle = "default"
+	TextBlockStyleHeading TextBlockStyle = "heading"
+)
+
+// Style hint for text input
+type TextInputStyle string
+
+const (
+	TextInputStyleText     TextInputStyle = "text"
+	TextInputStyleTel      TextInputStyle = "tel"
+	TextInputStyleUrl      TextInputStyle = "url"
+	TextInputStyleEmail    TextInputStyle = "email"
+	TextInputStylePassword TextInputStyle = "password"
+)
+
+// Controls how content is vertically positioned within its container
+type VerticalAlignment string
+
+const (
+	VerticalAlignmentTop    VerticalAlignment = "top"
+	VerticalAlignmentCenter VerticalAlignment = "center"
+	VerticalAlignmentBottom VerticalAlignment = "bottom"
+)
+
+// Defines how the content should be aligned vertically within the container
+type VerticalContentAlignment string
+
+const (
+	VerticalContentAlignmentTop    VerticalContentAlignment = "top"
+	VerticalContentAlignmentCenter VerticalContentAlignment = "center"
+	VerticalContentAlignmentBottom VerticalContentAlignment = "bottom"
+)
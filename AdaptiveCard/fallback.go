@@ -0,0 +1,166 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const fallbackDrop = "drop"
+
+// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met.
+// A Fallback is either "drop", which causes the item to be removed from the visual tree, or a replacement of the
+// same kind: an Element for elements, an Action for actions and a Column for columns.
+//
+// Source: https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/fallback
+type Fallback struct {
+	drop    bool
+	element Element
+	action  Action
+	column  *Column
+}
+
+// FallbackDrop removes the item from the visual tree when it can’t be rendered
+func FallbackDrop() *Fallback {
+	return &Fallback{drop: true}
+}
+
+// FallbackElement renders el instead of an element that can’t be rendered
+func FallbackElement(el Element) *Fallback {
+	return &Fallback{element: el}
+}
+
+// FallbackAction renders a instead of an action that can’t be rendered
+func FallbackAction(a Action) *Fallback {
+	return &Fallback{action: a}
+}
+
+// FallbackColumn renders c instead of a column that can’t be rendered
+func FallbackColumn(c *Column) *Fallback {
+	return &Fallback{column: c}
+}
+
+// IsDrop reports whether the fallback is "drop"
+func (f *Fallback) IsDrop() bool {
+	return f != nil && f.drop
+}
+
+// Element returns the replacement element, or nil if the fallback is not an element
+func (f *Fallback) Element() Element {
+	if f == nil {
+		return nil
+	}
+	return f.element
+}
+
+// Action returns the replacement action, or nil if the fallback is not an action
+func (f *Fallback) Action() Action {
+	if f == nil {
+		return nil
+	}
+	return f.action
+}
+
+// Column returns the replacement column, or nil if the fallback is not a column
+func (f *Fallback) Column() *Column {
+	if f == nil {
+		return nil
+	}
+	return f.column
+}
+
+func (f Fallback) MarshalJSON() ([]byte, error) {
+	switch {
+	case f.drop:
+		return json.Marshal(fallbackDrop)
+	case f.element != nil:
+		return json.Marshal(f.element)
+	case f.action != nil:
+		return json.Marshal(f.action)
+	case f.column != nil:
+		return json.Marshal(f.column)
+	}
+
+	return []byte("null"), nil
+}
+
+func (f *Fallback) UnmarshalJSON(data []byte) error {
+	*f = Fallback{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s != fallbackDrop {
+			return fmt.Errorf("fallback: expected %q or an object, got %q", fallbackDrop, s)
+		}
+		f.drop = true
+		return nil
+	}
+
+	t, err := peekType(data)
+	if err != nil {
+		return fmt.Errorf("fallback: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(string(t), "Action."):
+		f.action, err = decodeAction(data)
+	case t == TypeColumn:
+		f.column = &Column{}
+		err = json.Unmarshal(data, f.column)
+	default:
+		f.element, err = decodeElement(data)
+	}
+	if err != nil {
+		return fmt.Errorf("fallback: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Fallback) validateElement() error {
+	if f == nil || f.drop {
+		return nil
+	}
+	if f.element == nil {
+		return errors.New("Fallback of an element must be \"drop\" or an element")
+	}
+	if err := validateItem(f.element); err != nil {
+		return fmt.Errorf("fallback: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Fallback) validateAction() error {
+	if f == nil || f.drop {
+		return nil
+	}
+	if f.action == nil {
+		return errors.New("Fallback of an action must be \"drop\" or an action")
+	}
+	if err := validateItem(f.action); err != nil {
+		return fmt.Errorf("fallback: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Fallback) validateColumn() error {
+	if f == nil || f.drop {
+		return nil
+	}
+	if f.column == nil {
+		return errors.New("Fallback of a column must be \"drop\" or a column")
+	}
+	if err := f.column.validate(); err != nil {
+		return fmt.Errorf("fallback: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package teams
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFallbackDropIsNotShared(t *testing.T) {
+	tb := NewTextBlock("new")
+	tb.Fallback = FallbackDrop()
+	if err := json.Unmarshal([]byte(`{"type":"TextBlock","text":"new","fallback":{"type":"TextBlock","text":"old"}}`), tb); err != nil {
+		t.Fatal(err)
+	}
+	if tb.Fallback.IsDrop() || tb.Fallback.Element() == nil {
+		t.Fatalf("decoded fallback = %+v, want an element", tb.Fallback)
+	}
+
+	if !FallbackDrop().IsDrop() {
+		t.Fatal("FallbackDrop().IsDrop() = false after decoding into a drop fallback")
+	}
+	data, err := json.Marshal(FallbackDrop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"drop"` {
+		t.Fatalf("FallbackDrop() marshals to %s, want \"drop\"", data)
+	}
+}
+
+func TestFallbackJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		drop    bool
+		element bool
+		action  bool
+		column  bool
+		wantErr bool
+	}{
+		{name: "drop", json: `"drop"`, drop: true},
+		{name: "element", json: `{"type":"TextBlock","text":"x"}`, element: true},
+		{name: "action", json: `{"type":"Action.OpenUrl","url":"https://example.com"}`, action: true},
+		{name: "column", json: `{"type":"Column"}`, column: true},
+		{name: "other string", json: `"keep"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f Fallback
+			err := json.Unmarshal([]byte(tt.json), &f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if f.IsDrop() != tt.drop || (f.Element() != nil) != tt.element || (f.Action() != nil) != tt.action ||
+				(f.Column() != nil) != tt.column {
+				t.Fatalf("Unmarshal(%s) = %+v", tt.json, f)
+			}
+		})
+	}
+}
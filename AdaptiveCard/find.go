@@ -0,0 +1,119 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when no node of a card has the requested id
+var ErrNotFound = errors.New("no node with this id")
+
+// DuplicateIDError is returned when more than one node of a card has the requested id
+type DuplicateIDError struct {
+	// The duplicated id
+	ID string
+	// The JSON pointers of the nodes having the id
+	Paths []string
+}
+
+func (e *DuplicateIDError) Error() string {
+	return fmt.Sprintf("id %q is used by %d nodes: %s", e.ID, len(e.Paths), strings.Join(e.Paths, ", "))
+}
+
+// FindByID returns the element, action, column or carousel page with the given id, searching nested containers,
+// columns, carousels and the cards of Action.ShowCard. The content of fallbacks is not searched, since it commonly
+// reuses the id of the item it replaces
+func (a *AdaptiveCard) FindByID(id string) (interface{}, error) {
+	var node interface{}
+	err := a.atID(id, func(c *Cursor) error {
+		node = c.Node()
+		return nil
+	})
+
+	return node, err
+}
+
+// ReplaceByID replaces the node with the given id with n, which must fit the place of the replaced node
+func (a *AdaptiveCard) ReplaceByID(id string, n interface{}) error {
+	return a.atID(id, func(c *Cursor) error {
+		return c.Replace(n)
+	})
+}
+
+// RemoveByID removes the node with the given id from the card
+func (a *AdaptiveCard) RemoveByID(id string) error {
+	return a.atID(id, func(c *Cursor) error {
+		return c.Remove()
+	})
+}
+
+// InsertAfter inserts n right after the node with the given id, into the same array. n must fit the array, e.g. an
+// Element after an element or an Action after an action
+func (a *AdaptiveCard) InsertAfter(id string, n interface{}) error {
+	return a.atID(id, func(c *Cursor) error {
+		return c.InsertAfter(n)
+	})
+}
+
+// FindByIDAs returns the node with the given id as a T, e.g. FindByIDAs[*TextBlock](card, "status")
+func FindByIDAs[T any](card *AdaptiveCard, id string) (T, error) {
+	var zero T
+
+	node, err := card.FindByID(id)
+	if err != nil {
+		return zero, err
+	}
+	t, ok := node.(T)
+	if !ok {
+		return zero, fmt.Errorf("id %q: node is a %T, not a %T", id, node, zero)
+	}
+
+	return t, nil
+}
+
+// atID calls f with the cursor of the only node having the given id
+func (a *AdaptiveCard) atID(id string, f func(c *Cursor) error) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	var paths []string
+	walkIDs(a, func(c *Cursor) {
+		if itemId(c.Node()) == id {
+			paths = append(paths, c.Path())
+		}
+	})
+	switch len(paths) {
+	case 0:
+		return fmt.Errorf("id %q: %w", id, ErrNotFound)
+	case 1:
+	default:
+		return &DuplicateIDError{ID: id, Paths: paths}
+	}
+
+	// Remove makes the walk visit the next sibling at the same path, so f must only be called once
+	var err error
+	done := false
+	walkIDs(a, func(c *Cursor) {
+		if !done && c.Path() == paths[0] {
+			done = true
+			err = f(c)
+		}
+	})
+
+	return err
+}
+
+// walkIDs walks the nodes of the card that can be addressed by id, skipping the content of fallbacks
+func walkIDs(card *AdaptiveCard, f func(c *Cursor)) {
+	Walk(card, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			if strings.HasSuffix(c.Path(), "/fallback") {
+				return false
+			}
+			f(c)
+			return c.Node() != nil
+		},
+	})
+}
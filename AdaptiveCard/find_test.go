@@ -0,0 +1,86 @@
+package teams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// bodyIds returns the ids of the TextBlocks of the body of the card
+func bodyIds(card *AdaptiveCard) []string {
+	ids := []string{}
+	for _, el := range card.Body {
+		ids = append(ids, el.(*TextBlock).Id)
+	}
+
+	return ids
+}
+
+func newIdCard(ids ...string) *AdaptiveCard {
+	card := NewAdaptiveCard()
+	for _, id := range ids {
+		tb := NewTextBlock(id)
+		tb.Id = id
+		card.Body = append(card.Body, tb)
+	}
+
+	return card
+}
+
+func TestRemoveByID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want []string
+	}{
+		{name: "first", id: "a", want: []string{"b", "c"}},
+		{name: "middle", id: "b", want: []string{"a", "c"}},
+		{name: "last", id: "c", want: []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := newIdCard("a", "b", "c")
+			if err := card.RemoveByID(tt.id); err != nil {
+				t.Fatal(err)
+			}
+			if got := bodyIds(card); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceByID(t *testing.T) {
+	card := newIdCard("a", "b", "c")
+	replacement := NewTextBlock("x")
+	replacement.Id = "x"
+	if err := card.ReplaceByID("a", replacement); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bodyIds(card), []string{"x", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("body = %v, want %v", got, want)
+	}
+}
+
+func TestInsertAfter(t *testing.T) {
+	card := newIdCard("a", "b")
+	inserted := NewTextBlock("x")
+	inserted.Id = "x"
+	if err := card.InsertAfter("a", inserted); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bodyIds(card), []string{"a", "x", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("body = %v, want %v", got, want)
+	}
+}
+
+func TestFindByIDErrors(t *testing.T) {
+	card := newIdCard("a", "a")
+	var dup *DuplicateIDError
+	if _, err := card.FindByID("a"); !errors.As(err, &dup) || len(dup.Paths) != 2 {
+		t.Fatalf("FindByID(duplicate) error = %v, want a DuplicateIDError with 2 paths", err)
+	}
+	if _, err := card.FindByID("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("FindByID(missing) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,537 @@
+package teams
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// The enums of a card like Spacing, FontSize or Colors are only hints; every host maps them to concrete values with
+// its HostConfig.
+//
+// Source: https://learn.microsoft.com/en-us/adaptive-cards/rendering-cards/host-config
+
+// A HostConfig maps the sizes, spacings and colours of a card to concrete values
+type HostConfig struct {
+	// Controls how elements are to be laid out
+	Spacing SpacingConfig `json:"spacing"`
+	// Controls how separators are to be drawn
+	Separator SeparatorConfig `json:"separator"`
+	// The font families, sizes and weights of the default and the monospace font
+	FontTypes FontTypesConfig `json:"fontTypes"`
+	// The background and foreground colours of every container style
+	ContainerStyles ContainerStylesConfig `json:"containerStyles"`
+	// The width of images of size small, medium and large
+	ImageSizes ImageSizesConfig `json:"imageSizes"`
+	// Controls how actions are displayed
+	Actions ActionsConfig `json:"actions"`
+	// Controls how FactSets are displayed
+	FactSet FactSetConfig `json:"factSet"`
+	// Controls how the labels and error messages of inputs are displayed
+	Inputs InputsConfig `json:"inputs"`
+	// The text styles applied to TextBlocks with a style
+	TextStyles TextStylesConfig `json:"textStyles"`
+	// Controls how ImageSets are displayed
+	ImageSet ImageSetConfig `json:"imageSet"`
+	// Whether the host supports interactive elements like inputs and actions
+	SupportsInteractivity bool `json:"supportsInteractivity"`
+}
+
+// The spacings in pixels
+type SpacingConfig struct {
+	Small      int `json:"small"`
+	Default    int `json:"default"`
+	Medium     int `json:"medium"`
+	Large      int `json:"large"`
+	ExtraLarge int `json:"extraLarge"`
+	// The padding of the card and of containers with a style
+	Padding int `json:"padding"`
+}
+
+type SeparatorConfig struct {
+	// The thickness of the separator line in pixels
+	LineThickness int `json:"lineThickness"`
+	// The colour of the separator line, like "#EEEEEE"
+	LineColor string `json:"lineColor"`
+}
+
+type FontTypesConfig struct {
+	Default   FontTypeConfig `json:"default"`
+	Monospace FontTypeConfig `json:"monospace"`
+}
+
+type FontTypeConfig struct {
+	// The CSS font-family, like "Segoe UI, sans-serif"
+	FontFamily  string            `json:"fontFamily"`
+	FontSizes   FontSizesConfig   `json:"fontSizes"`
+	FontWeights FontWeightsConfig `json:"fontWeights"`
+}
+
+// The font sizes in pixels
+type FontSizesConfig struct {
+	Small      int `json:"small"`
+	Default    int `json:"default"`
+	Medium     int `json:"medium"`
+	Large      int `json:"large"`
+	ExtraLarge int `json:"extraLarge"`
+}
+
+// The CSS font weights, like 400 for normal
+type FontWeightsConfig struct {
+	Lighter int `json:"lighter"`
+	Default int `json:"default"`
+	Bolder  int `json:"bolder"`
+}
+
+type ContainerStylesConfig struct {
+	Default   ContainerStyleConfig `json:"default"`
+	Emphasis  ContainerStyleConfig `json:"emphasis"`
+	Good      ContainerStyleConfig `json:"good"`
+	Attention ContainerStyleConfig `json:"attention"`
+	Warning   ContainerStyleConfig `json:"warning"`
+	Accent    ContainerStyleConfig `json:"accent"`
+}
+
+type ContainerStyleConfig struct {
+	// The background colour of the container, like "#FFFFFF"
+	BackgroundColor string `json:"backgroundColor"`
+	// The text colours inside the container
+	ForegroundColors ForegroundColorsConfig `json:"foregroundColors"`
+}
+
+type ForegroundColorsConfig struct {
+	Default   FontColorConfig `json:"default"`
+	Dark      FontColorConfig `json:"dark"`
+	Light     FontColorConfig `json:"light"`
+	Accent    FontColorConfig `json:"accent"`
+	Good      FontColorConfig `json:"good"`
+	Warning   FontColorConfig `json:"warning"`
+	Attention FontColorConfig `json:"attention"`
+}
+
+type FontColorConfig struct {
+	// The colour of regular text
+	Default string `json:"default"`
+	// The colour of text with IsSubtle set
+	Subtle string `json:"subtle"`
+}
+
+// The widths of images in pixels
+type ImageSizesConfig struct {
+	Small  int `json:"small"`
+	Medium int `json:"medium"`
+	Large  int `json:"large"`
+}
+
+type ActionsOrientation string
+
+const (
+	ActionsOrientationHorizontal ActionsOrientation = "horizontal"
+	ActionsOrientationVertical   ActionsOrientation = "vertical"
+)
+
+type ActionAlignment string
+
+const (
+	ActionAlignmentLeft    ActionAlignment = "left"
+	ActionAlignmentCenter  ActionAlignment = "center"
+	ActionAlignmentRight   ActionAlignment = "right"
+	ActionAlignmentStretch ActionAlignment = "stretch"
+)
+
+type IconPlacement string
+
+const (
+	IconPlacementAboveTitle  IconPlacement = "aboveTitle"
+	IconPlacementLeftOfTitle IconPlacement = "leftOfTitle"
+)
+
+type ShowCardActionMode string
+
+const (
+	// The card is expanded below the actions
+	ShowCardActionModeInline ShowCardActionMode = "inline"
+	// The card is shown in a dialog
+	ShowCardActionModePopup ShowCardActionMode = "popup"
+)
+
+type ActionsConfig struct {
+	// The maximum number of actions displayed; additional actions are dropped
+	MaxActions int `json:"maxActions"`
+	// The spacing between the actions and the content above them
+	Spacing Spacing `json:"spacing"`
+	// The spacing between buttons in pixels
+	ButtonSpacing int `json:"buttonSpacing"`
+	// Controls how the card of an Action.ShowCard is displayed
+	ShowCard ShowCardConfig `json:"showCard"`
+	// Whether actions are laid out in a row or in a column
+	ActionsOrientation ActionsOrientation `json:"actionsOrientation"`
+	// The alignment of the buttons
+	ActionAlignment ActionAlignment `json:"actionAlignment"`
+	// Where the icon of an action is displayed relative to its title
+	IconPlacement IconPlacement `json:"iconPlacement"`
+	// The size of the icon of an action in pixels
+	IconSize int `json:"iconSize"`
+}
+
+type ShowCardConfig struct {
+	// Whether the card is expanded below the actions or shown in a dialog
+	ActionMode ShowCardActionMode `json:"actionMode"`
+	// The container style of the expanded card
+	Style ContainerStyle `json:"style"`
+	// The spacing between the actions and the expanded card in pixels
+	InlineTopMargin int `json:"inlineTopMargin"`
+}
+
+type FactSetConfig struct {
+	// The text style of the titles
+	Title FactSetTextConfig `json:"title"`
+	// The text style of the values
+	Value FactSetTextConfig `json:"value"`
+	// The spacing between titles and values in pixels
+	Spacing int `json:"spacing"`
+}
+
+type FactSetTextConfig struct {
+	Size     FontSize   `json:"size"`
+	Weight   FontWeight `json:"weight"`
+	Color    Colors     `json:"color"`
+	IsSubtle bool       `json:"isSubtle"`
+	Wrap     bool       `json:"wrap"`
+	// The maximum width in pixels, unlimited if 0
+	MaxWidth int `json:"maxWidth,omitempty"`
+}
+
+type InputsConfig struct {
+	// The style of the labels
+	Label InputLabelsConfig `json:"label"`
+	// The style of the error messages
+	ErrorMessage ErrorMessageConfig `json:"errorMessage"`
+}
+
+type InputLabelsConfig struct {
+	// The spacing between the label and the input
+	InputSpacing Spacing `json:"inputSpacing"`
+	// The style of the labels of required inputs
+	RequiredInputs InputLabelConfig `json:"requiredInputs"`
+	// The style of the labels of optional inputs
+	OptionalInputs InputLabelConfig `json:"optionalInputs"`
+}
+
+type InputLabelConfig struct {
+	Size     FontSize   `json:"size"`
+	Weight   FontWeight `json:"weight"`
+	Color    Colors     `json:"color"`
+	IsSubtle bool       `json:"isSubtle"`
+	// The text appended to the label, like " *" for required inputs
+	Suffix string `json:"suffix,omitempty"`
+}
+
+type ErrorMessageConfig struct {
+	Size   FontSize   `json:"size"`
+	Weight FontWeight `json:"weight"`
+	// The spacing between the input and the error message
+	Spacing Spacing `json:"spacing"`
+}
+
+type TextStylesConfig struct {
+	// The style of TextBlocks with TextBlockStyleHeading
+	Heading TextStyleConfig `json:"heading"`
+	// The style of the cells of the header row of a Table
+	ColumnHeader TextStyleConfig `json:"columnHeader"`
+}
+
+type TextStyleConfig struct {
+	Size     FontSize   `json:"size"`
+	Weight   FontWeight `json:"weight"`
+	Color    Colors     `json:"color"`
+	FontType FontType   `json:"fontType"`
+	IsSubtle bool       `json:"isSubtle"`
+}
+
+type ImageSetConfig struct {
+	// The size of the images if the ImageSet doesn’t specify one
+	ImageSize ImageSize `json:"imageSize"`
+	// The maximum height of the images in pixels
+	MaxImageHeight int `json:"maxImageHeight"`
+}
+
+// SpacingValue returns the spacing in pixels. SpacingPadding returns the padding of containers
+func (h *HostConfig) SpacingValue(s Spacing) int {
+	switch s {
+	case SpacingNone:
+		return 0
+	case SpacingSmall:
+		return h.Spacing.Small
+	case SpacingMedium:
+		return h.Spacing.Medium
+	case SpacingLarge:
+		return h.Spacing.Large
+	case SpacingExtraLarge:
+		return h.Spacing.ExtraLarge
+	case SpacingPadding:
+		return h.Spacing.Padding
+	}
+
+	return h.Spacing.Default
+}
+
+// FontType returns the configuration of the font type, the default font for unknown types
+func (h *HostConfig) FontType(t FontType) *FontTypeConfig {
+	if t == FontTypeMonospace {
+		return &h.FontTypes.Monospace
+	}
+	return &h.FontTypes.Default
+}
+
+// FontSizeValue returns the font size in pixels
+func (h *HostConfig) FontSizeValue(t FontType, s FontSize) int {
+	sizes := h.FontType(t).FontSizes
+	switch s {
+	case FontSizeSmall:
+		return sizes.Small
+	case FontSizeMedium:
+		return sizes.Medium
+	case FontSizeLarge:
+		return sizes.Large
+	case FontSizeExtraLarge:
+		return sizes.ExtraLarge
+	}
+
+	return sizes.Default
+}
+
+// FontWeightValue returns the CSS font weight
+func (h *HostConfig) FontWeightValue(t FontType, w FontWeight) int {
+	weights := h.FontType(t).FontWeights
+	switch w {
+	case FontWeightLighter:
+		return weights.Lighter
+	case FontWeightBolder:
+		return weights.Bolder
+	}
+
+	return weights.Default
+}
+
+// ContainerStyle returns the configuration of the container style, the default style for unknown styles
+func (h *HostConfig) ContainerStyle(s ContainerStyle) *ContainerStyleConfig {
+	switch s {
+	case ContainerStyleEmphasis:
+		return &h.ContainerStyles.Emphasis
+	case ContainerStyleGood:
+		return &h.ContainerStyles.Good
+	case ContainerStyleAttention:
+		return &h.ContainerStyles.Attention
+	case ContainerStyleWarning:
+		return &h.ContainerStyles.Warning
+	case ContainerStyleAccent:
+		return &h.ContainerStyles.Accent
+	}
+
+	return &h.ContainerStyles.Default
+}
+
+// ForegroundColor returns the colour of text inside a container of the given style
+func (h *HostConfig) ForegroundColor(style ContainerStyle, c Colors, subtle bool) string {
+	colors := h.ContainerStyle(style).ForegroundColors
+
+	color := colors.Default
+	switch c {
+	case ColorDark:
+		color = colors.Dark
+	case ColorLight:
+		color = colors.Light
+	case ColorAccent:
+		color = colors.Accent
+	case ColorGood:
+		color = colors.Good
+	case ColorWarning:
+		color = colors.Warning
+	case ColorAttention:
+		color = colors.Attention
+	}
+
+	if subtle {
+		return color.Subtle
+	}
+	return color.Default
+}
+
+// ImageSizeValue returns the width of images of the given size in pixels, or 0 for ImageSizeAuto and
+// ImageSizeStretch, whose width depends on the image and the available space
+func (h *HostConfig) ImageSizeValue(s ImageSize) int {
+	switch s {
+	case ImageSizeSmall:
+		return h.ImageSizes.Small
+	case ImageSizeMedium:
+		return h.ImageSizes.Medium
+	case ImageSizeLarge:
+		return h.ImageSizes.Large
+	}
+
+	return 0
+}
+
+// Theme is a colour theme of the Teams clients
+type Theme string
+
+const (
+	ThemeLight        Theme = "light"
+	ThemeDark         Theme = "dark"
+	ThemeHighContrast Theme = "highContrast"
+)
+
+// the HostConfigs of the Teams clients, bundled with the library
+//
+//go:embed hostconfigs/*.json
+var hostConfigs embed.FS
+
+var teamsHostConfigs = map[Theme]string{
+	ThemeLight:        "hostconfigs/teams-light.json",
+	ThemeDark:         "hostconfigs/teams-dark.json",
+	ThemeHighContrast: "hostconfigs/teams-high-contrast.json",
+}
+
+// TeamsHostConfig returns the HostConfig of the Teams clients for the given theme, the light theme for unknown
+// themes. Every call returns a new HostConfig which may be modified freely
+func TeamsHostConfig(theme Theme) *HostConfig {
+	name, ok := teamsHostConfigs[theme]
+	if !ok {
+		name = teamsHostConfigs[ThemeLight]
+	}
+
+	h := &HostConfig{}
+	data, err := hostConfigs.ReadFile(name)
+	if err == nil {
+		err = json.Unmarshal(data, h)
+	}
+	if err != nil {
+		// the bundled files are valid
+		panic(fmt.Sprintf("teams: %s: %v", name, err))
+	}
+
+	return h
+}
+
+// ParseHostConfig parses a HostConfig in JSON, like the ones of the official designer. Properties that aren’t set keep
+// the values of the light theme of Teams, so a partial HostConfig only needs to contain what differs. Enum values are
+// matched case-insensitively
+func ParseHostConfig(data []byte) (*HostConfig, error) {
+	h := TeamsHostConfig(ThemeLight)
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, err
+	}
+	if err := h.validate(); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// LoadHostConfig reads and parses the HostConfig in the given JSON file
+func LoadHostConfig(path string) (*HostConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := ParseHostConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return h, nil
+}
+
+func (h *HostConfig) validate() error {
+	spacings := []Spacing{SpacingDefault, SpacingNone, SpacingSmall, SpacingMedium, SpacingLarge, SpacingExtraLarge, SpacingPadding}
+	sizes := []FontSize{FontSizeDefault, FontSizeSmall, FontSizeMedium, FontSizeLarge, FontSizeExtraLarge}
+	weights := []FontWeight{FontWeightDefault, FontWeightLighter, FontWeightBolder}
+	colors := []Colors{ColorDefault, ColorDark, ColorLight, ColorAccent, ColorGood, ColorWarning, ColorAttention}
+	styles := []ContainerStyle{ContainerStyleDefault, ContainerStyleEmphasis, ContainerStyleGood, ContainerStyleAttention, ContainerStyleWarning, ContainerStyleAccent}
+
+	errs := []error{
+		normalizeEnum("actions.spacing", &h.Actions.Spacing, spacings...),
+		normalizeEnum("actions.actionsOrientation", &h.Actions.ActionsOrientation, ActionsOrientationHorizontal, ActionsOrientationVertical),
+		normalizeEnum("actions.actionAlignment", &h.Actions.ActionAlignment, ActionAlignmentLeft, ActionAlignmentCenter, ActionAlignmentRight, ActionAlignmentStretch),
+		normalizeEnum("actions.iconPlacement", &h.Actions.IconPlacement, IconPlacementAboveTitle, IconPlacementLeftOfTitle),
+		normalizeEnum("actions.showCard.actionMode", &h.Actions.ShowCard.ActionMode, ShowCardActionModeInline, ShowCardActionModePopup),
+		normalizeEnum("actions.showCard.style", &h.Actions.ShowCard.Style, styles...),
+		normalizeEnum("imageSet.imageSize", &h.ImageSet.ImageSize, ImageSizeAuto, ImageSizeStretch, ImageSizeSmall, ImageSizeMedium, ImageSizeLarge),
+		normalizeEnum("inputs.label.inputSpacing", &h.Inputs.Label.InputSpacing, spacings...),
+		normalizeEnum("inputs.errorMessage.spacing", &h.Inputs.ErrorMessage.Spacing, spacings...),
+		normalizeEnum("inputs.errorMessage.size", &h.Inputs.ErrorMessage.Size, sizes...),
+		normalizeEnum("inputs.errorMessage.weight", &h.Inputs.ErrorMessage.Weight, weights...),
+	}
+	texts := []struct {
+		name   string
+		size   *FontSize
+		weight *FontWeight
+		color  *Colors
+	}{
+		{"factSet.title", &h.FactSet.Title.Size, &h.FactSet.Title.Weight, &h.FactSet.Title.Color},
+		{"factSet.value", &h.FactSet.Value.Size, &h.FactSet.Value.Weight, &h.FactSet.Value.Color},
+		{"inputs.label.requiredInputs", &h.Inputs.Label.RequiredInputs.Size, &h.Inputs.Label.RequiredInputs.Weight, &h.Inputs.Label.RequiredInputs.Color},
+		{"inputs.label.optionalInputs", &h.Inputs.Label.OptionalInputs.Size, &h.Inputs.Label.OptionalInputs.Weight, &h.Inputs.Label.OptionalInputs.Color},
+		{"textStyles.heading", &h.TextStyles.Heading.Size, &h.TextStyles.Heading.Weight, &h.TextStyles.Heading.Color},
+		{"textStyles.columnHeader", &h.TextStyles.ColumnHeader.Size, &h.TextStyles.ColumnHeader.Weight, &h.TextStyles.ColumnHeader.Color},
+	}
+	for _, t := range texts {
+		errs = append(errs,
+			normalizeEnum(t.name+".size", t.size, sizes...),
+			normalizeEnum(t.name+".weight", t.weight, weights...),
+			normalizeEnum(t.name+".color", t.color, colors...))
+	}
+	errs = append(errs,
+		normalizeEnum("textStyles.heading.fontType", &h.TextStyles.Heading.FontType, FontTypeDefault, FontTypeMonospace),
+		normalizeEnum("textStyles.columnHeader.fontType", &h.TextStyles.ColumnHeader.FontType, FontTypeDefault, FontTypeMonospace))
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, v := range map[string]int{
+		"spacing.small":      h.Spacing.Small,
+		"spacing.default":    h.Spacing.Default,
+		"spacing.medium":     h.Spacing.Medium,
+		"spacing.large":      h.Spacing.Large,
+		"spacing.extraLarge": h.Spacing.ExtraLarge,
+		"spacing.padding":    h.Spacing.Padding,
+		"imageSizes.small":   h.ImageSizes.Small,
+		"imageSizes.medium":  h.ImageSizes.Medium,
+		"imageSizes.large":   h.ImageSizes.Large,
+		"actions.maxActions": h.Actions.MaxActions,
+	} {
+		if v < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", name, v)
+		}
+	}
+
+	return nil
+}
+
+// normalizeEnum replaces the value pointed to by v with the one of values it matches case-insensitively. An empty
+// value is kept
+func normalizeEnum[T ~string](name string, v *T, values ...T) error {
+	if *v == "" {
+		return nil
+	}
+	for _, value := range values {
+		if strings.EqualFold(string(*v), string(value)) {
+			*v = value
+			return nil
+		}
+	}
+
+	expected := make([]string, len(values))
+	for i, value := range values {
+		expected[i] = string(value)
+	}
+
+	return fmt.Errorf("%s is invalid; expected one of: %s, got %s", name, strings.Join(expected, ", "), *v)
+}
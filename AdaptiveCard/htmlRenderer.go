@@ -0,0 +1,1403 @@
+package teams
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The HTML renderer produces a static preview of a card that approximates the official renderers, e.g. for
+// dashboards or code reviews. The output is self-contained: it doesn’t load any script or stylesheet, so inputs are
+// inert, Action.ShowCard is expanded with plain CSS and only Action.OpenUrl and select actions opening a URL are
+// functional. Hidden elements are omitted and DATE() and TIME() functions are displayed in UTC.
+
+// HTMLRenderer renders cards as HTML
+type HTMLRenderer struct {
+	// Maps the sizes, spacings and colours of the card to concrete values. The light theme of Teams when nil
+	HostConfig *HostConfig
+	// If true, only the card is rendered instead of a complete HTML document, e.g. to embed it in another page. The
+	// card still contains the styles it depends on
+	Fragment bool
+}
+
+// RenderHTML renders the card as a complete HTML document using the given HostConfig, or the light theme of Teams
+// if it is nil
+func RenderHTML(card *AdaptiveCard, hc *HostConfig) string {
+	return (&HTMLRenderer{HostConfig: hc}).Render(card)
+}
+
+// Render renders the card as HTML
+func (h *HTMLRenderer) Render(card *AdaptiveCard) string {
+	var hc *HostConfig
+	if h.HostConfig == nil {
+		hc = TeamsHostConfig(ThemeLight)
+	} else {
+		c := *h.HostConfig
+		hc = &c
+	}
+	cssColors(reflect.ValueOf(hc).Elem())
+
+	r := &htmlRenderer{hc: hc, styles: []ContainerStyle{ContainerStyleDefault}}
+	if card != nil {
+		r.card(card, true)
+	}
+	css := r.css()
+
+	if h.Fragment {
+		return "<div class=\"ac-root\"><style>" + css + "</style>" + r.b.String() + "</div>"
+	}
+
+	title := ""
+	if card != nil {
+		title = summarize(RenderText(card))
+	}
+
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n" +
+		"<title>" + html.EscapeString(title) + "</title>\n<style>" + css + "</style>\n</head>\n" +
+		"<body style=\"margin:0;padding:24px;background:" + hc.ContainerStyle(ContainerStyleEmphasis).BackgroundColor + "\">\n" +
+		r.b.String() + "\n</body>\n</html>\n"
+}
+
+var (
+	htmlSafeURL = regexp.MustCompile(`^(?i)(https?:|mailto:|tel:|data:image/)`)
+	argbColor   = regexp.MustCompile(`^#[0-9a-fA-F]{8}$`)
+	// the pixel lengths card properties like minHeight may contain. Other values are dropped, since they end up in
+	// style attributes
+	cssPixels = regexp.MustCompile(`^[0-9]+px$`)
+	// the colours card properties like the backgroundColor of an image may contain
+	cssColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+)
+
+// pixelsCSS returns the declaration of the CSS property with the pixel length of a card property, or nothing if the
+// value isn’t a pixel length
+func pixelsCSS(property string, value string) []string {
+	if !cssPixels.MatchString(value) {
+		return nil
+	}
+
+	return []string{property + ":" + value}
+}
+
+// colorCSS returns the declaration of the CSS property with the colour of a card property, converted from
+// #AARRGGBB, or nothing if the value isn’t a colour
+func colorCSS(property string, value string) []string {
+	if !cssColor.MatchString(value) {
+		return nil
+	}
+	if argbColor.MatchString(value) {
+		n, _ := strconv.ParseUint(value[1:], 16, 32)
+		value = fmt.Sprintf("rgba(%d,%d,%d,%.3g)", n>>16&0xff, n>>8&0xff, n&0xff, float64(n>>24)/255)
+	}
+
+	return []string{property + ":" + value}
+}
+
+// cssColors converts the colours of a HostConfig from #AARRGGBB, as used by the official HostConfigs, to CSS
+func cssColors(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			cssColors(v.Field(i))
+		}
+	case reflect.String:
+		if c := v.String(); argbColor.MatchString(c) {
+			n, _ := strconv.ParseUint(c[1:], 16, 32)
+			v.SetString(fmt.Sprintf("rgba(%d,%d,%d,%.3g)", n>>16&0xff, n>>8&0xff, n&0xff, float64(n>>24)/255))
+		}
+	}
+}
+
+type htmlRenderer struct {
+	hc *HostConfig
+	b  strings.Builder
+	// the styles of the enclosing containers, which determine the foreground colours
+	styles []ContainerStyle
+	// the number of Action.ShowCard rendered so far, to generate unique ids
+	showCards int
+	// CSS rules expanding the cards of Action.ShowCard
+	rules []string
+}
+
+func (r *htmlRenderer) style() ContainerStyle {
+	return r.styles[len(r.styles)-1]
+}
+
+func (r *htmlRenderer) color(c Colors, subtle bool) string {
+	return r.hc.ForegroundColor(r.style(), c, subtle)
+}
+
+func (r *htmlRenderer) css() string {
+	hc := r.hc
+	font := hc.FontType(FontTypeDefault)
+	defaults := hc.ContainerStyle(ContainerStyleDefault)
+	accent := defaults.ForegroundColors.Accent.Default
+
+	rules := []string{
+		fmt.Sprintf(".ac-card{box-sizing:border-box;max-width:600px;overflow:hidden;border-radius:4px;border:%dpx solid %s;font-family:%s;font-size:%dpx;font-weight:%d;line-height:1.4;background:%s;color:%s}",
+			hc.Separator.LineThickness, hc.Separator.LineColor, font.FontFamily, font.FontSizes.Default, font.FontWeights.Default, defaults.BackgroundColor, defaults.ForegroundColors.Default.Default),
+		".ac-card *{box-sizing:border-box}",
+		".ac-card p{margin:0}",
+		".ac-card ul,.ac-card ol{margin:0;padding-left:20px}",
+		".ac-card a{color:" + accent + "}",
+		".ac-select{display:block;color:inherit!important;text-decoration:none}",
+		".ac-columns{display:flex}",
+		".ac-column{min-width:0}",
+		".ac-table>table{width:100%;border-collapse:collapse}",
+		".ac-table td,.ac-table th{padding:8px;text-align:left;vertical-align:top;font-weight:inherit}",
+		".ac-factset>table{border-collapse:collapse}",
+		".ac-factset td{padding:0;vertical-align:top}",
+		fmt.Sprintf(".ac-actions{display:flex;flex-wrap:wrap;gap:%dpx}", hc.Actions.ButtonSpacing),
+		".ac-actions.ac-vertical{flex-direction:column}",
+		fmt.Sprintf(".ac-button{display:inline-flex;align-items:center;justify-content:center;gap:6px;min-height:32px;padding:4px 12px;border:1px solid %s;border-radius:4px;background:%s;color:%s!important;font:inherit;font-weight:%d;text-decoration:none;cursor:pointer;white-space:nowrap;overflow:hidden;text-overflow:ellipsis}",
+			hc.Separator.LineColor, defaults.BackgroundColor, defaults.ForegroundColors.Default.Default, font.FontWeights.Bolder),
+		".ac-actions.ac-stretch .ac-button{flex:1 1 0}",
+		fmt.Sprintf(".ac-button img{width:%dpx;height:%dpx}", hc.Actions.IconSize, hc.Actions.IconSize),
+		".ac-button.ac-icon-above{flex-direction:column}",
+		fmt.Sprintf(".ac-button.ac-positive{background:%s;border-color:%s;color:%s!important}", accent, accent, defaults.ForegroundColors.Light.Default),
+		fmt.Sprintf(".ac-button.ac-destructive{color:%s!important}", defaults.ForegroundColors.Attention.Default),
+		".ac-toggle{position:absolute;opacity:0;pointer-events:none}",
+		".ac-showcard{display:none}",
+		".ac-input{width:100%;padding:4px 8px;font:inherit;border:1px solid " + hc.Separator.LineColor + ";border-radius:4px}",
+		".ac-label{display:block}",
+		".ac-required{color:" + defaults.ForegroundColors.Attention.Default + "}",
+		"@keyframes ac-spin{to{transform:rotate(360deg)}}",
+	}
+
+	return strings.Join(append(rules, r.rules...), "\n")
+}
+
+// card renders a card. The root card has a border and the padding of the HostConfig
+func (r *htmlRenderer) card(card *AdaptiveCard, root bool) {
+	css := []string{}
+	if root {
+		css = append(css, fmt.Sprintf("padding:%dpx", r.hc.Spacing.Padding))
+	}
+	css = append(css, pixelsCSS("min-height", card.MinHeight)...)
+	css = append(css, backgroundImageCSS(card.BackgroundImage)...)
+
+	class := "ac-card"
+	if !root {
+		class = "ac-subcard"
+	}
+	r.open("div", class, css, card.Rtl)
+	r.selectAction(card.SelectAction, func() {
+		r.elements(card.Body)
+	})
+	if r.hc.SupportsInteractivity {
+		r.actions(card.Actions, true, len(card.Body) > 0)
+	}
+	r.b.WriteString("</div>")
+}
+
+// open writes the start tag of an element with the given class and inline CSS
+func (r *htmlRenderer) open(tag string, class string, css []string, rtl bool) {
+	r.b.WriteString("<" + tag)
+	if class != "" {
+		r.b.WriteString(` class="` + class + `"`)
+	}
+	if rtl {
+		r.b.WriteString(` dir="rtl"`)
+	}
+	r.attr("style", strings.Join(css, ";"))
+	r.b.WriteString(">")
+}
+
+// attr writes an attribute if its value isn’t empty
+func (r *htmlRenderer) attr(name string, value string) {
+	if value != "" {
+		r.b.WriteString(" " + name + `="` + html.EscapeString(value) + `"`)
+	}
+}
+
+func (r *htmlRenderer) text(s string) {
+	r.b.WriteString(html.EscapeString(s))
+}
+
+// selectAction wraps the content written by f in a link if a is an Action.OpenUrl
+func (r *htmlRenderer) selectAction(a ISelectAction, f func()) {
+	openUrl, ok := a.(*ActionOpenUrl)
+	if !ok || !htmlSafeURL.MatchString(openUrl.Url) {
+		f()
+		return
+	}
+
+	r.b.WriteString(`<a class="ac-select" target="_blank" rel="noopener noreferrer"`)
+	r.attr("href", openUrl.Url)
+	r.attr("title", openUrl.Tooltip)
+	r.b.WriteString(">")
+	f()
+	r.b.WriteString("</a>")
+}
+
+// spacing returns the CSS separating an element from the preceding one
+func (r *htmlRenderer) spacing(spacing Spacing, separator bool, vertical bool) []string {
+	side, padding := "top", "padding-top"
+	if !vertical {
+		side, padding = "left", "padding-left"
+	}
+
+	s := r.hc.SpacingValue(spacing)
+	if !separator {
+		return []string{fmt.Sprintf("margin-%s:%dpx", side, s)}
+	}
+
+	return []string{
+		fmt.Sprintf("margin-%s:%dpx", side, s/2),
+		fmt.Sprintf("%s:%dpx", padding, s-s/2),
+		fmt.Sprintf("border-%s:%dpx solid %s", side, r.hc.Separator.LineThickness, r.hc.Separator.LineColor),
+	}
+}
+
+func (r *htmlRenderer) elements(elements []Element) {
+	first := true
+	for _, el := range elements {
+		if el == nil || isHidden(el) || !r.hc.SupportsInteractivity && isInteractive(el) {
+			continue
+		}
+
+		css := []string{}
+		if !first {
+			spacing, separator := itemSpacing(el)
+			css = r.spacing(spacing, separator, true)
+		}
+		first = false
+
+		class := "ac-" + strings.ToLower(strings.ReplaceAll(string(itemType(el)), ".", "-"))
+		r.open("div", class, css, false)
+		r.element(el)
+		r.b.WriteString("</div>")
+	}
+}
+
+func (r *htmlRenderer) element(el Element) {
+	switch el := el.(type) {
+	case *TextBlock:
+		r.textBlock(el)
+	case *RichTextBlock:
+		r.richTextBlock(el)
+	case *Image:
+		r.image(el, "")
+	case *ImageSet:
+		r.imageSet(el)
+	case *Media:
+		r.media(el)
+	case *CodeBlock:
+		r.codeBlock(el)
+	case *ProgressBar:
+		r.progressBar(el)
+	case *ProgressRing:
+		r.progressRing(el)
+	case *Badge:
+		r.badge(el)
+	case *Icon:
+		r.alignment(el.HorizontalAlignment, func() {
+			r.selectAction(el.SelectAction, func() { r.icon(el.Name, el.Size, el.Color) })
+		})
+	case *CompoundButton:
+		r.compoundButton(el)
+	case *FactSet:
+		r.factSet(el)
+	case *Container:
+		r.container(el.Style, el.SelectAction, el.MinHeight, el.VerticalContentAlignment, el.BackgroundImage, el.Bleed, el.Rtl, el.Items)
+	case *ColumnSet:
+		r.columnSet(el)
+	case *Table:
+		r.table(el)
+	case *Carousel:
+		r.carousel(el)
+	case *ActionSet:
+		r.actions(el.Actions, false, false)
+	case *InputText:
+		r.inputText(el)
+	case *InputNumber:
+		value := ""
+		if el.Value != nil {
+			value = strconv.FormatFloat(*el.Value, 'f', -1, 64)
+		}
+		attrs := map[string]string{"value": value, "placeholder": el.Placeholder}
+		if el.Min != nil {
+			attrs["min"] = strconv.FormatFloat(*el.Min, 'f', -1, 64)
+		}
+		if el.Max != nil {
+			attrs["max"] = strconv.FormatFloat(*el.Max, 'f', -1, 64)
+		}
+		r.input(el.Id, el.Label, el.IsRequired, "number", attrs)
+	case *InputDate:
+		r.input(el.Id, el.Label, el.IsRequired, "date", map[string]string{"value": el.Value, "min": el.Min, "max": el.Max, "placeholder": el.Placeholder})
+	case *InputTime:
+		r.input(el.Id, el.Label, el.IsRequired, "time", map[string]string{"value": el.Value, "min": el.Min, "max": el.Max, "placeholder": el.Placeholder})
+	case *InputToggle:
+		r.inputToggle(el)
+	case *InputChoiceSet:
+		r.inputChoiceSet(el)
+	case *InputRating:
+		r.inputRating(el)
+	}
+}
+
+// isInteractive reports whether the element is an input or an ActionSet, which aren’t displayed by hosts without
+// support for interactivity
+func isInteractive(el Element) bool {
+	switch el.(type) {
+	case *ActionSet, *InputText, *InputNumber, *InputDate, *InputTime, *InputToggle, *InputChoiceSet, *InputRating:
+		return true
+	}
+
+	return false
+}
+
+// alignment wraps the content written by f in a block aligned as given
+func (r *htmlRenderer) alignment(a HorizontalAlignment, f func()) {
+	css := textAlignCSS(a)
+	if css == nil {
+		f()
+		return
+	}
+
+	r.open("div", "", css, false)
+	f()
+	r.b.WriteString("</div>")
+}
+
+// textAlignCSS returns the text-align of a horizontal alignment, or nothing if the alignment is unset or unknown
+func textAlignCSS(a HorizontalAlignment) []string {
+	switch a {
+	case HorizontalAlignmentLeft, HorizontalAlignmentCenter, HorizontalAlignmentRight:
+		return []string{"text-align:" + string(a)}
+	}
+
+	return nil
+}
+
+// fontCSS returns the CSS of text. Unset properties are inherited from the enclosing container
+func (r *htmlRenderer) fontCSS(t FontType, size FontSize, weight FontWeight, c Colors, subtle bool) []string {
+	var css []string
+	if size != "" || t == FontTypeMonospace {
+		css = append(css, fmt.Sprintf("font-size:%dpx", r.hc.FontSizeValue(t, size)))
+	}
+	if weight != "" || t == FontTypeMonospace {
+		css = append(css, fmt.Sprintf("font-weight:%d", r.hc.FontWeightValue(t, weight)))
+	}
+	if (c != "" && c != ColorDefault) || subtle {
+		css = append(css, "color:"+r.color(c, subtle))
+	}
+	if t == FontTypeMonospace {
+		css = append(css, "font-family:"+r.hc.FontType(t).FontFamily)
+	}
+
+	return css
+}
+
+func (r *htmlRenderer) textBlock(t *TextBlock) {
+	fontType, size, weight, color, subtle := t.FontType, t.Size, t.Weight, t.Color, t.IsSubtle
+	if t.Style == TextBlockStyleHeading {
+		// the properties of the TextBlock take precedence over its style
+		heading := r.hc.TextStyles.Heading
+		if fontType == "" {
+			fontType = heading.FontType
+		}
+		if size == "" {
+			size = heading.Size
+		}
+		if weight == "" {
+			weight = heading.Weight
+		}
+		if color == "" {
+			color = heading.Color
+		}
+		subtle = subtle || heading.IsSubtle
+	}
+
+	css := r.fontCSS(fontType, size, weight, color, subtle)
+	css = append(css, textAlignCSS(t.HorizontalAlignment)...)
+	switch {
+	case !t.Wrap:
+		css = append(css, "white-space:nowrap", "overflow:hidden", "text-overflow:ellipsis")
+	case t.MaxLines > 0:
+		css = append(css, "display:-webkit-box", "-webkit-box-orient:vertical", "overflow:hidden", fmt.Sprintf("-webkit-line-clamp:%d", t.MaxLines))
+	}
+
+	tag := "div"
+	if t.Style == TextBlockStyleHeading {
+		tag = "h2"
+		css = append(css, "margin:0")
+	}
+	r.open(tag, "", css, false)
+	r.b.WriteString(markdownToHTML(expandTextFunctions(t.Text, time.UTC), !t.Wrap))
+	r.b.WriteString("</" + tag + ">")
+}
+
+func (r *htmlRenderer) richTextBlock(rt *RichTextBlock) {
+	css := textAlignCSS(rt.HorizontalAlignment)
+	r.open("p", "", css, false)
+	for i := range rt.Inlines {
+		run := &rt.Inlines[i]
+		css := r.fontCSS(run.FontType, run.Size, run.Weight, run.Color, run.IsSubtle)
+		if run.Italic {
+			css = append(css, "font-style:italic")
+		}
+		var decorations []string
+		if run.Underline {
+			decorations = append(decorations, "underline")
+		}
+		if run.Strikethrough {
+			decorations = append(decorations, "line-through")
+		}
+		if len(decorations) > 0 {
+			css = append(css, "text-decoration:"+strings.Join(decorations, " "))
+		}
+		if run.Highlight {
+			css = append(css, "background:"+r.hc.ForegroundColor(r.style(), ColorWarning, true))
+		}
+
+		r.selectAction(run.SelectAction, func() {
+			r.open("span", "", css, false)
+			r.b.WriteString(strings.ReplaceAll(html.EscapeString(expandTextFunctions(run.Text, time.UTC)), "\n", "<br>"))
+			r.b.WriteString("</span>")
+		})
+	}
+	r.b.WriteString("</p>")
+}
+
+// image renders an image. size overrides the size of the image, for the images of an ImageSet
+func (r *htmlRenderer) image(i *Image, size ImageSize) {
+	if size == "" {
+		size = i.Size
+	}
+
+	css := []string{"display:inline-block", "max-width:100%"}
+	switch {
+	case cssPixels.MatchString(i.Width):
+		css = append(css, "width:"+i.Width)
+	case size == ImageSizeStretch:
+		css = append(css, "width:100%")
+	case size == "" || size == ImageSizeAuto:
+	default:
+		css = append(css, fmt.Sprintf("width:%dpx", r.hc.ImageSizeValue(size)))
+	}
+	if cssPixels.MatchString(string(i.Height)) {
+		css = append(css, "height:"+string(i.Height), "object-fit:contain")
+	}
+	if i.Style == ImageStylePerson {
+		css = append(css, "border-radius:50%", "aspect-ratio:1", "object-fit:cover")
+	}
+	css = append(css, colorCSS("background-color", i.BackgroundColor)...)
+
+	r.alignment(i.HorizontalAlignment, func() {
+		r.selectAction(i.SelectAction, func() {
+			r.b.WriteString("<img")
+			if htmlSafeURL.MatchString(i.Url) {
+				r.attr("src", i.Url)
+			}
+			r.b.WriteString(` alt="` + html.EscapeString(i.AltText) + `"`)
+			r.attr("style", strings.Join(css, ";"))
+			r.b.WriteString(">")
+		})
+	})
+}
+
+func (r *htmlRenderer) imageSet(s *ImageSet) {
+	size := s.ImageSize
+	if size == "" {
+		size = r.hc.ImageSet.ImageSize
+	}
+	if size == "" || size == ImageSizeAuto || size == ImageSizeStretch {
+		size = ImageSizeMedium
+	}
+
+	r.open("div", "", []string{"display:flex", "flex-wrap:wrap", fmt.Sprintf("gap:%dpx", r.hc.Spacing.Small)}, false)
+	for n := range s.Images {
+		if !isHidden(&s.Images[n]) {
+			r.image(&s.Images[n], size)
+		}
+	}
+	r.b.WriteString("</div>")
+}
+
+func (r *htmlRenderer) media(m *Media) {
+	href := ""
+	if len(m.Sources) > 0 && htmlSafeURL.MatchString(m.Sources[0].Url) {
+		href = m.Sources[0].Url
+	}
+
+	css := []string{"position:relative", "display:flex", "align-items:center", "justify-content:center", "min-height:120px",
+		"background:" + r.hc.ContainerStyle(ContainerStyleEmphasis).BackgroundColor}
+	r.b.WriteString(`<a class="ac-select" target="_blank" rel="noopener noreferrer"`)
+	r.attr("href", href)
+	r.attr("title", m.AltText)
+	r.b.WriteString(">")
+	r.open("div", "", css, false)
+	if htmlSafeURL.MatchString(m.Poster) {
+		r.b.WriteString(`<img style="width:100%;display:block"`)
+		r.attr("src", m.Poster)
+		r.b.WriteString(` alt="` + html.EscapeString(m.AltText) + `">`)
+	}
+	r.b.WriteString(`<span style="position:absolute;font-size:32px;color:` + r.color(ColorDefault, false) + `">&#9654;</span>`)
+	r.b.WriteString("</div></a>")
+}
+
+func (r *htmlRenderer) codeBlock(c *CodeBlock) {
+	mono := r.hc.FontType(FontTypeMonospace)
+	css := []string{
+		"margin:0",
+		"padding:8px",
+		"overflow:auto",
+		"border-radius:4px",
+		"font-family:" + mono.FontFamily,
+		fmt.Sprintf("font-size:%dpx", mono.FontSizes.Small),
+		"background:" + r.hc.ContainerStyle(ContainerStyleEmphasis).BackgroundColor,
+	}
+	r.open("pre", "", css, false)
+	r.b.WriteString("<code")
+	if c.Language != "" {
+		r.attr("class", "language-"+strings.ToLower(string(c.Language)))
+	}
+	r.b.WriteString(">")
+	r.text(c.CodeSnippet)
+	r.b.WriteString("</code></pre>")
+}
+
+func (r *htmlRenderer) progressBar(p *ProgressBar) {
+	color := p.Color
+	if color == "" || color == ColorDefault {
+		color = ColorAccent
+	}
+
+	width := 100.0
+	opacity := "0.4"
+	if p.Value != nil {
+		max := p.Max
+		if max <= 0 {
+			max = 100
+		}
+		width = *p.Value / max * 100
+		if width < 0 {
+			width = 0
+		} else if width > 100 {
+			width = 100
+		}
+		opacity = "1"
+	}
+
+	r.open("div", "", []string{"height:4px", "border-radius:2px", "background:" + r.hc.Separator.LineColor}, false)
+	r.open("div", "", []string{fmt.Sprintf("width:%.4g%%", width), "height:100%", "border-radius:2px", "opacity:" + opacity, "background:" + r.color(color, false)}, false)
+	r.b.WriteString("</div></div>")
+}
+
+func (r *htmlRenderer) progressRing(p *ProgressRing) {
+	size := map[ProgressRingSize]int{ProgressRingSizeTiny: 12, ProgressRingSizeSmall: 16, ProgressRingSizeMedium: 24, ProgressRingSizeLarge: 32}[p.Size]
+	if size == 0 {
+		size = 24
+	}
+
+	direction := "row"
+	switch p.LabelPosition {
+	case LabelPositionBefore:
+		direction = "row-reverse"
+	case LabelPositionAbove:
+		direction = "column-reverse"
+	case LabelPositionBelow:
+		direction = "column"
+	}
+
+	r.open("div", "", []string{"display:inline-flex", "align-items:center", "gap:8px", "flex-direction:" + direction}, false)
+	r.open("span", "", []string{
+		"display:inline-block",
+		fmt.Sprintf("width:%dpx", size),
+		fmt.Sprintf("height:%dpx", size),
+		"border-radius:50%",
+		"border:3px solid " + r.hc.Separator.LineColor,
+		"border-top-color:" + r.color(ColorAccent, false),
+		"animation:ac-spin 1s linear infinite",
+	}, false)
+	r.b.WriteString("</span>")
+	if p.Label != "" {
+		r.b.WriteString("<span>")
+		r.text(p.Label)
+		r.b.WriteString("</span>")
+	}
+	r.b.WriteString("</div>")
+}
+
+func (r *htmlRenderer) badge(b *Badge) {
+	var color Colors
+	switch b.Style {
+	case BadgeStyleAccent, BadgeStyleInformative:
+		color = ColorAccent
+	case BadgeStyleGood:
+		color = ColorGood
+	case BadgeStyleAttention:
+		color = ColorAttention
+	case BadgeStyleWarning:
+		color = ColorWarning
+	default:
+		color = ColorDefault
+	}
+
+	fontSize := map[BadgeSize]int{BadgeSizeLarge: 14, BadgeSizeExtraLarge: 16}[b.Size]
+	if fontSize == 0 {
+		fontSize = 12
+	}
+	radius := "4px"
+	switch b.Shape {
+	case BadgeShapeCircular:
+		radius = "999px"
+	case BadgeShapeSquare:
+		radius = "0"
+	}
+
+	css := []string{"display:inline-flex", "align-items:center", "gap:4px", "padding:2px 8px", "border-radius:" + radius, fmt.Sprintf("font-size:%dpx", fontSize),
+		fmt.Sprintf("font-weight:%d", r.hc.FontWeightValue(FontTypeDefault, FontWeightBolder))}
+	if b.Appearance == BadgeAppearanceTint {
+		css = append(css, "background:"+r.hc.ContainerStyle(ContainerStyleEmphasis).BackgroundColor, "color:"+r.color(color, false), "border:1px solid "+r.color(color, true))
+	} else {
+		background := r.color(color, false)
+		if b.Style == BadgeStyleSubtle {
+			background = r.color(ColorDefault, true)
+		}
+		css = append(css, "background:"+background, "color:"+r.hc.ContainerStyle(ContainerStyleDefault).BackgroundColor)
+	}
+
+	r.alignment(b.HorizontalAlignment, func() {
+		r.b.WriteString("<span")
+		r.attr("title", b.Tooltip)
+		r.attr("style", strings.Join(css, ";"))
+		r.b.WriteString(">")
+		if b.Icon != "" && b.IconPosition != IconPositionAfter {
+			r.icon(b.Icon, IconSizeXSmall, "")
+		}
+		r.text(b.Text)
+		if b.Icon != "" && b.IconPosition == IconPositionAfter {
+			r.icon(b.Icon, IconSizeXSmall, "")
+		}
+		r.b.WriteString("</span>")
+	})
+}
+
+var iconSizes = map[IconSize]int{
+	IconSizeXxSmall:  12,
+	IconSizeXSmall:   16,
+	IconSizeSmall:    20,
+	IconSizeStandard: 24,
+	IconSizeMedium:   28,
+	IconSizeLarge:    32,
+	IconSizeXLarge:   40,
+	IconSizeXxLarge:  48,
+}
+
+// icon renders a placeholder for a Fluent icon, since the icons aren’t available offline
+func (r *htmlRenderer) icon(name string, size IconSize, color Colors) {
+	px := iconSizes[size]
+	if px == 0 {
+		px = iconSizes[IconSizeStandard]
+	}
+
+	r.b.WriteString("<span")
+	r.attr("title", name)
+	r.attr("style", strings.Join([]string{
+		"display:inline-block",
+		"vertical-align:middle",
+		fmt.Sprintf("width:%dpx", px),
+		fmt.Sprintf("height:%dpx", px),
+		"border-radius:25%",
+		"border:2px solid " + r.color(color, false),
+	}, ";"))
+	r.b.WriteString("></span>")
+}
+
+func (r *htmlRenderer) compoundButton(c *CompoundButton) {
+	r.selectAction(c.SelectAction, func() {
+		r.open("div", "", []string{"display:flex", "gap:12px", "padding:12px", "border-radius:4px", "border:1px solid " + r.hc.Separator.LineColor}, false)
+		if c.Icon != nil {
+			r.icon(c.Icon.Name, c.Icon.Size, c.Icon.Color)
+		}
+		r.b.WriteString("<div>")
+		r.open("div", "", []string{fmt.Sprintf("font-weight:%d", r.hc.FontWeightValue(FontTypeDefault, FontWeightBolder))}, false)
+		r.text(c.Title)
+		if c.Badge != "" {
+			r.b.WriteString(" ")
+			r.badge(&Badge{Text: c.Badge, Style: BadgeStyleAccent, Appearance: BadgeAppearanceTint})
+		}
+		r.b.WriteString("</div>")
+		if c.Description != "" {
+			r.open("div", "", []string{"color:" + r.color(ColorDefault, true)}, false)
+			r.text(c.Description)
+			r.b.WriteString("</div>")
+		}
+		r.b.WriteString("</div></div>")
+	})
+}
+
+func (r *htmlRenderer) factSet(f *FactSet) {
+	cfg := r.hc.FactSet
+	titleCSS := r.fontCSS(FontTypeDefault, cfg.Title.Size, cfg.Title.Weight, cfg.Title.Color, cfg.Title.IsSubtle)
+	titleCSS = append(titleCSS, fmt.Sprintf("padding-right:%dpx", cfg.Spacing))
+	if cfg.Title.MaxWidth > 0 {
+		titleCSS = append(titleCSS, fmt.Sprintf("max-width:%dpx", cfg.Title.MaxWidth))
+	}
+	if !cfg.Title.Wrap {
+		titleCSS = append(titleCSS, "white-space:nowrap")
+	}
+	valueCSS := r.fontCSS(FontTypeDefault, cfg.Value.Size, cfg.Value.Weight, cfg.Value.Color, cfg.Value.IsSubtle)
+
+	r.b.WriteString("<table>")
+	for _, fact := range f.Facts {
+		r.b.WriteString("<tr>")
+		r.open("td", "", titleCSS, false)
+		r.b.WriteString(markdownToHTML(expandTextFunctions(fact.Title, time.UTC), false))
+		r.b.WriteString("</td>")
+		r.open("td", "", valueCSS, false)
+		r.b.WriteString(markdownToHTML(expandTextFunctions(fact.Value, time.UTC), false))
+		r.b.WriteString("</td></tr>")
+	}
+	r.b.WriteString("</table>")
+}
+
+// container renders the items of a Container, a Column, a CarouselPage or a TableCell
+func (r *htmlRenderer) container(style ContainerStyle, selectAction ISelectAction, minHeight string, align VerticalContentAlignment, bg *BackgroundImage, bleed bool, rtl bool, items []Element) {
+	css := []string{"display:flex", "flex-direction:column"}
+	if style != "" && style != r.style() {
+		padding := r.hc.Spacing.Padding
+		css = append(css, "background:"+r.hc.ContainerStyle(style).BackgroundColor, "color:"+r.hc.ForegroundColor(style, ColorDefault, false), fmt.Sprintf("padding:%dpx", padding))
+		if bleed {
+			css = append(css, fmt.Sprintf("margin:0 -%dpx", padding))
+		}
+	}
+	css = append(css, pixelsCSS("min-height", minHeight)...)
+	switch align {
+	case VerticalContentAlignmentCenter:
+		css = append(css, "justify-content:center")
+	case VerticalContentAlignmentBottom:
+		css = append(css, "justify-content:flex-end")
+	}
+	css = append(css, backgroundImageCSS(bg)...)
+
+	if style == "" {
+		style = r.style()
+	}
+	r.styles = append(r.styles, style)
+	r.selectAction(selectAction, func() {
+		r.open("div", "", css, rtl)
+		r.elements(items)
+		r.b.WriteString("</div>")
+	})
+	r.styles = r.styles[:len(r.styles)-1]
+}
+
+func backgroundImageCSS(bg *BackgroundImage) []string {
+	if bg == nil || !htmlSafeURL.MatchString(bg.Url) {
+		return nil
+	}
+
+	css := []string{`background-image:url("` + strings.ReplaceAll(bg.Url, `"`, `%22`) + `")`}
+	switch bg.FillMode {
+	case ImageFillModeRepeat:
+		css = append(css, "background-repeat:repeat")
+	case ImageFillModeRepeatHorizontally:
+		css = append(css, "background-repeat:repeat-x")
+	case ImageFillModeRepeatVertically:
+		css = append(css, "background-repeat:repeat-y")
+	default:
+		css = append(css, "background-size:cover", "background-repeat:no-repeat")
+	}
+	x, y := "left", "top"
+	switch bg.HorizontalAlignment {
+	case HorizontalAlignmentCenter, HorizontalAlignmentRight:
+		x = string(bg.HorizontalAlignment)
+	}
+	switch bg.VerticalAlignment {
+	case VerticalAlignmentCenter, VerticalAlignmentBottom:
+		y = string(bg.VerticalAlignment)
+	}
+
+	return append(css, "background-position:"+x+" "+y)
+}
+
+func (r *htmlRenderer) columnSet(c *ColumnSet) {
+	css := []string{}
+	if c.Style != "" && c.Style != r.style() {
+		css = append(css, "background:"+r.hc.ContainerStyle(c.Style).BackgroundColor, "color:"+r.hc.ForegroundColor(c.Style, ColorDefault, false), fmt.Sprintf("padding:%dpx", r.hc.Spacing.Padding))
+	}
+	css = append(css, pixelsCSS("min-height", c.MinHeight)...)
+	switch c.HorizontalAlignment {
+	case HorizontalAlignmentCenter:
+		css = append(css, "justify-content:center")
+	case HorizontalAlignmentRight:
+		css = append(css, "justify-content:flex-end")
+	}
+
+	style := c.Style
+	if style == "" {
+		style = r.style()
+	}
+	r.styles = append(r.styles, style)
+	r.selectAction(c.SelectAction, func() {
+		r.open("div", "ac-columns", css, false)
+		first := true
+		for i := range c.Columns {
+			col := &c.Columns[i]
+			if isHidden(col) {
+				continue
+			}
+
+			css := []string{"flex:" + columnFlex(col.Width)}
+			if !first {
+				css = append(css, r.spacing(col.Spacing, col.Separator, false)...)
+			}
+			first = false
+
+			r.open("div", "ac-column", css, false)
+			r.container(col.Style, col.SelectAction, col.MinHeight, col.VerticalContentAlignment, col.BackgroundImage, col.Bleed, col.Rtl, col.Items)
+			r.b.WriteString("</div>")
+		}
+		r.b.WriteString("</div>")
+	})
+	r.styles = r.styles[:len(r.styles)-1]
+}
+
+// columnFlex returns the CSS flex of a column or table column of the given width: "auto", "stretch", a relative
+// weight or a pixel width like "50px"
+func columnFlex(width interface{}) string {
+	switch w := width.(type) {
+	case float64:
+		return strconv.FormatFloat(w, 'f', -1, 64) + " 1 0"
+	case int:
+		return strconv.Itoa(w) + " 1 0"
+	case string:
+		switch {
+		case w == "auto":
+			return "0 1 auto"
+		case cssPixels.MatchString(w):
+			return "0 0 " + w
+		}
+		if n, err := strconv.ParseFloat(w, 64); err == nil {
+			return strconv.FormatFloat(n, 'f', -1, 64) + " 1 0"
+		}
+	}
+
+	// stretch
+	return "1 1 0"
+}
+
+func (r *htmlRenderer) table(t *Table) {
+	gridLines := t.ShowGridLines == nil || *t.ShowGridLines
+	header := t.FirstRowAsHeader == nil || *t.FirstRowAsHeader
+	gridColor := r.hc.Separator.LineColor
+	if t.GridStyle != "" {
+		gridColor = r.hc.ContainerStyle(t.GridStyle).BackgroundColor
+	}
+
+	r.b.WriteString("<table>")
+	if len(t.Columns) > 0 {
+		total := 0.0
+		for _, col := range t.Columns {
+			total += columnWeight(col.Width)
+		}
+		r.b.WriteString("<colgroup>")
+		for _, col := range t.Columns {
+			if w, ok := col.Width.(string); ok && cssPixels.MatchString(w) {
+				r.open("col", "", []string{"width:" + w}, false)
+			} else if total > 0 && columnWeight(col.Width) > 0 {
+				r.open("col", "", []string{fmt.Sprintf("width:%.4g%%", columnWeight(col.Width)/total*100)}, false)
+			} else {
+				r.b.WriteString("<col>")
+			}
+		}
+		r.b.WriteString("</colgroup>")
+	}
+
+	for i := range t.Rows {
+		row := &t.Rows[i]
+		r.b.WriteString("<tr>")
+
+		tag := "td"
+		if i == 0 && header {
+			tag = "th"
+		}
+		for n := range row.Cells {
+			if len(t.Columns) > 0 && n >= len(t.Columns) {
+				// extra cells are ignored
+				break
+			}
+			cell := &row.Cells[n]
+
+			var css []string
+			if gridLines {
+				css = append(css, "border:1px solid "+gridColor)
+			}
+			align := t.HorizontalCellContentAlignment
+			valign := t.VerticalCellContentAlignment
+			if n < len(t.Columns) {
+				if a := t.Columns[n].HorizontalCellContentAlignment; a != "" {
+					align = a
+				}
+				if a := t.Columns[n].VerticalCellContentAlignment; a != "" {
+					valign = a
+				}
+			}
+			if row.HorizontalCellContentAlignment != "" {
+				align = row.HorizontalCellContentAlignment
+			}
+			if row.VerticalCellContentAlignment != "" {
+				valign = row.VerticalCellContentAlignment
+			}
+			if cell.VerticalContentAlignment != "" {
+				valign = VerticalAlignment(cell.VerticalContentAlignment)
+			}
+			css = append(css, textAlignCSS(align)...)
+			switch valign {
+			case VerticalAlignmentCenter:
+				css = append(css, "vertical-align:middle")
+			case VerticalAlignmentTop, VerticalAlignmentBottom:
+				css = append(css, "vertical-align:"+string(valign))
+			}
+			if tag == "th" {
+				header := r.hc.TextStyles.ColumnHeader
+				css = append(css, r.fontCSS(header.FontType, header.Size, header.Weight, header.Color, header.IsSubtle)...)
+			}
+
+			style := cell.Style
+			if style == "" {
+				style = row.Style
+			}
+			r.open(tag, "", css, cell.Rtl)
+			r.container(style, cell.SelectAction, cell.MinHeight, "", cell.BackgroundImage, false, false, cell.Items)
+			r.b.WriteString("</" + tag + ">")
+		}
+		r.b.WriteString("</tr>")
+	}
+	r.b.WriteString("</table>")
+}
+
+// columnWeight returns the relative weight of a table column, or 0 if it has a pixel width
+func columnWeight(width interface{}) float64 {
+	switch w := width.(type) {
+	case float64:
+		return w
+	case int:
+		return float64(w)
+	case string:
+		if n, err := strconv.ParseFloat(w, 64); err == nil {
+			return n
+		}
+		return 0
+	}
+
+	return 1
+}
+
+func (r *htmlRenderer) carousel(c *Carousel) {
+	css := []string{"display:flex", "overflow-x:auto", "scroll-snap-type:x mandatory", fmt.Sprintf("gap:%dpx", r.hc.Spacing.Default)}
+	css = append(css, pixelsCSS("height", c.HeightInPixels)...)
+
+	r.open("div", "", css, false)
+	for i := range c.Pages {
+		page := &c.Pages[i]
+		if isHidden(page) {
+			continue
+		}
+		r.open("div", "ac-carouselpage", []string{"flex:0 0 100%", "scroll-snap-align:start"}, false)
+		r.container(page.Style, page.SelectAction, page.MinHeight, page.VerticalContentAlignment, page.BackgroundImage, false, page.Rtl, page.Items)
+		r.b.WriteString("</div>")
+	}
+	r.b.WriteString("</div>")
+}
+
+// actions renders a row of buttons followed by the cards of the Action.ShowCard among them. The actions of a card
+// are limited to the maximum number of the HostConfig and separated from the body
+func (r *htmlRenderer) actions(actions []Action, card bool, spaced bool) {
+	cfg := r.hc.Actions
+	if card && cfg.MaxActions > 0 && len(actions) > cfg.MaxActions {
+		actions = actions[:cfg.MaxActions]
+	}
+	if len(actions) == 0 {
+		return
+	}
+
+	var css []string
+	if spaced {
+		css = append(css, fmt.Sprintf("margin-top:%dpx", r.hc.SpacingValue(cfg.Spacing)))
+	}
+	r.open("div", "", css, false)
+
+	// the checkboxes must precede the buttons and the cards of the Action.ShowCard to be selectable in CSS
+	ids := map[*ActionShowCard]string{}
+	for _, a := range actions {
+		if a, ok := a.(*ActionShowCard); ok {
+			r.showCards++
+			id := fmt.Sprintf("ac-showcard-%d", r.showCards)
+			ids[a] = id
+			r.b.WriteString(`<input type="checkbox" class="ac-toggle" id="` + id + `">`)
+			r.rules = append(r.rules, fmt.Sprintf("#%s:checked~#%s-card{display:block}", id, id),
+				fmt.Sprintf("#%s:checked~.ac-actions label[for=%s]{box-shadow:inset 0 -3px 0 %s}", id, id, r.color(ColorAccent, false)))
+		}
+	}
+
+	class := "ac-actions"
+	if cfg.ActionsOrientation == ActionsOrientationVertical {
+		class += " ac-vertical"
+	}
+	var rowCSS []string
+	switch cfg.ActionAlignment {
+	case ActionAlignmentStretch:
+		class += " ac-stretch"
+	case ActionAlignmentCenter:
+		rowCSS = append(rowCSS, "justify-content:center")
+	case ActionAlignmentRight:
+		rowCSS = append(rowCSS, "justify-content:flex-end")
+	}
+	r.open("div", class, rowCSS, false)
+	for _, a := range actions {
+		r.button(a, ids)
+	}
+	r.b.WriteString("</div>")
+
+	for _, a := range actions {
+		if a, ok := a.(*ActionShowCard); ok {
+			style := cfg.ShowCard.Style
+			if style == "" {
+				style = ContainerStyleEmphasis
+			}
+			css := []string{
+				fmt.Sprintf("margin-top:%dpx", cfg.ShowCard.InlineTopMargin),
+				fmt.Sprintf("padding:%dpx", r.hc.Spacing.Padding),
+				"background:" + r.hc.ContainerStyle(style).BackgroundColor,
+				"color:" + r.hc.ForegroundColor(style, ColorDefault, false),
+			}
+			r.b.WriteString(`<div class="ac-showcard" id="` + ids[a] + `-card"`)
+			r.attr("style", strings.Join(css, ";"))
+			r.b.WriteString(">")
+			r.styles = append(r.styles, style)
+			r.card(&a.Card, false)
+			r.styles = r.styles[:len(r.styles)-1]
+			r.b.WriteString("</div>")
+		}
+	}
+	r.b.WriteString("</div>")
+}
+
+func (r *htmlRenderer) button(a Action, showCards map[*ActionShowCard]string) {
+	var title, iconUrl, tooltip string
+	var style ActionStyle
+	switch a := a.(type) {
+	case *ActionOpenUrl:
+		title, iconUrl, tooltip, style = a.Title, a.IconUrl, a.Tooltip, a.Style
+	case *ActionSubmit:
+		title, iconUrl, tooltip, style = a.Title, a.IconUrl, a.Tooltip, a.Style
+	case *ActionShowCard:
+		title, iconUrl, tooltip, style = a.Title, a.IconUrl, a.Tooltip, a.Style
+	case *ActionToggleVisibility:
+		title, iconUrl, tooltip, style = a.Title, a.IconUrl, a.Tooltip, a.Style
+	case *ActionExecute:
+		title, iconUrl, tooltip, style = a.Title, a.IconUrl, a.Tooltip, a.Style
+	default:
+		return
+	}
+
+	class := "ac-button"
+	switch style {
+	case ActionStylePositive:
+		class += " ac-positive"
+	case ActionStyleDestructive:
+		class += " ac-destructive"
+	}
+	if iconUrl != "" && r.hc.Actions.IconPlacement == IconPlacementAboveTitle {
+		class += " ac-icon-above"
+	}
+
+	tag := "button"
+	switch a := a.(type) {
+	case *ActionOpenUrl:
+		tag = "a"
+		r.b.WriteString(`<a class="` + class + `" target="_blank" rel="noopener noreferrer"`)
+		if htmlSafeURL.MatchString(a.Url) {
+			r.attr("href", a.Url)
+		}
+	case *ActionShowCard:
+		tag = "label"
+		r.b.WriteString(`<label class="` + class + `" for="` + showCards[a] + `"`)
+	default:
+		r.b.WriteString(`<button type="button" class="` + class + `"`)
+	}
+	r.attr("title", tooltip)
+	r.b.WriteString(">")
+	if htmlSafeURL.MatchString(iconUrl) {
+		r.b.WriteString(`<img alt=""`)
+		r.attr("src", iconUrl)
+		r.b.WriteString(">")
+	}
+	r.text(title)
+	r.b.WriteString("</" + tag + ">")
+}
+
+func (r *htmlRenderer) label(id string, label string, required bool) {
+	if label == "" {
+		return
+	}
+
+	cfg := r.hc.Inputs.Label.OptionalInputs
+	if required {
+		cfg = r.hc.Inputs.Label.RequiredInputs
+	}
+	css := append(r.fontCSS(FontTypeDefault, cfg.Size, cfg.Weight, cfg.Color, cfg.IsSubtle), fmt.Sprintf("margin-bottom:%dpx", r.hc.SpacingValue(r.hc.Inputs.Label.InputSpacing)))
+
+	r.b.WriteString(`<label class="ac-label"`)
+	r.attr("for", "ac-input-"+id)
+	r.attr("style", strings.Join(css, ";"))
+	r.b.WriteString(">")
+	r.text(label)
+	if cfg.Suffix != "" {
+		r.b.WriteString(`<span class="ac-required">`)
+		r.text(cfg.Suffix)
+		r.b.WriteString("</span>")
+	}
+	r.b.WriteString("</label>")
+}
+
+func (r *htmlRenderer) input(id string, label string, required bool, inputType string, attrs map[string]string) {
+	r.label(id, label, required)
+	r.b.WriteString(`<input class="ac-input" type="` + inputType + `"`)
+	r.attr("id", "ac-input-"+id)
+	r.attr("name", id)
+	for _, name := range []string{"value", "placeholder", "min", "max", "maxlength", "pattern"} {
+		r.attr(name, attrs[name])
+	}
+	if required {
+		r.b.WriteString(" required")
+	}
+	r.b.WriteString(">")
+}
+
+func (r *htmlRenderer) inputText(t *InputText) {
+	if t.IsMultiline {
+		r.label(t.Id, t.Label, t.IsRequired)
+		r.b.WriteString(`<textarea class="ac-input" rows="3"`)
+		r.attr("id", "ac-input-"+t.Id)
+		r.attr("name", t.Id)
+		r.attr("placeholder", t.Placeholder)
+		r.b.WriteString(">")
+		r.text(t.Value)
+		r.b.WriteString("</textarea>")
+		return
+	}
+
+	inputType := "text"
+	switch t.Style {
+	case TextInputStyleTel, TextInputStyleUrl, TextInputStyleEmail, TextInputStylePassword:
+		inputType = string(t.Style)
+	}
+	attrs := map[string]string{"value": t.Value, "placeholder": t.Placeholder, "pattern": t.Regex}
+	if t.MaxLength > 0 {
+		attrs["maxlength"] = strconv.Itoa(t.MaxLength)
+	}
+
+	if t.InlineAction == nil {
+		r.input(t.Id, t.Label, t.IsRequired, inputType, attrs)
+		return
+	}
+	r.label(t.Id, t.Label, t.IsRequired)
+	r.open("div", "", []string{"display:flex", "gap:8px"}, false)
+	r.input(t.Id, "", t.IsRequired, inputType, attrs)
+	r.button(t.InlineAction, nil)
+	r.b.WriteString("</div>")
+}
+
+func (r *htmlRenderer) inputToggle(t *InputToggle) {
+	on := t.ValueOn
+	if on == "" {
+		on = "true"
+	}
+
+	r.label(t.Id, t.Label, t.IsRequired)
+	r.b.WriteString("<label>")
+	r.b.WriteString(`<input type="checkbox"`)
+	r.attr("name", t.Id)
+	r.attr("value", on)
+	if t.Label == "" {
+		r.attr("id", "ac-input-"+t.Id)
+	}
+	if t.Value == on {
+		r.b.WriteString(" checked")
+	}
+	r.b.WriteString("> ")
+	r.text(t.Title)
+	r.b.WriteString("</label>")
+}
+
+func (r *htmlRenderer) inputChoiceSet(c *InputChoiceSet) {
+	selected := map[string]bool{}
+	for _, v := range strings.Split(c.Value, ",") {
+		selected[strings.TrimSpace(v)] = true
+	}
+
+	r.label(c.Id, c.Label, c.IsRequired)
+
+	if c.Style == ChoiceInputStyleExpanded || c.IsMultiSelect {
+		inputType := "radio"
+		if c.IsMultiSelect {
+			inputType = "checkbox"
+		}
+		r.b.WriteString(`<div role="group"`)
+		r.attr("id", "ac-input-"+c.Id)
+		r.b.WriteString(">")
+		for _, choice := range c.Choices {
+			r.b.WriteString(`<label style="display:block"><input type="` + inputType + `"`)
+			r.attr("name", c.Id)
+			r.attr("value", choice.Value)
+			if selected[choice.Value] {
+				r.b.WriteString(" checked")
+			}
+			r.b.WriteString("> ")
+			r.text(choice.Title)
+			r.b.WriteString("</label>")
+		}
+		r.b.WriteString("</div>")
+		return
+	}
+
+	r.b.WriteString(`<select class="ac-input"`)
+	r.attr("id", "ac-input-"+c.Id)
+	r.attr("name", c.Id)
+	r.b.WriteString(">")
+	if c.Placeholder != "" {
+		r.b.WriteString(`<option value="" disabled`)
+		if c.Value == "" {
+			r.b.WriteString(" selected")
+		}
+		r.b.WriteString(">")
+		r.text(c.Placeholder)
+		r.b.WriteString("</option>")
+	}
+	for _, choice := range c.Choices {
+		r.b.WriteString("<option")
+		r.attr("value", choice.Value)
+		if selected[choice.Value] {
+			r.b.WriteString(" selected")
+		}
+		r.b.WriteString(">")
+		r.text(choice.Title)
+		r.b.WriteString("</option>")
+	}
+	r.b.WriteString("</select>")
+}
+
+func (r *htmlRenderer) inputRating(i *InputRating) {
+	max := int(i.Max)
+	if max <= 0 {
+		max = 5
+	}
+	color := r.color(ColorDefault, false)
+	if i.Color == RatingColorMarigold {
+		color = r.color(ColorWarning, false)
+	}
+	size := 20
+	if i.Size == RatingSizeLarge {
+		size = 28
+	}
+
+	r.label(i.Id, i.Label, i.IsRequired)
+	r.open("div", "", []string{"color:" + color, fmt.Sprintf("font-size:%dpx", size), "letter-spacing:2px"}, false)
+	for n := 1; n <= max; n++ {
+		switch {
+		case float64(n) <= i.Value:
+			r.b.WriteString("&#9733;")
+		case float64(n)-0.5 <= i.Value && i.AllowHalfSteps:
+			r.b.WriteString(`<span style="opacity:0.6">&#9733;</span>`)
+		default:
+			r.b.WriteString("&#9734;")
+		}
+	}
+	r.b.WriteString("</div>")
+}
+
+var (
+	markdownBullet   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	markdownNumbered = regexp.MustCompile(`^\s*\d+\.\s+(.*)$`)
+)
+
+// markdownToHTML converts the markdown supported by Teams to HTML. Text that isn’t wrapped is kept on a single line
+func markdownToHTML(text string, singleLine bool) string {
+	if singleLine {
+		return markdownInlineToHTML(strings.Join(strings.Fields(text), " "))
+	}
+
+	var b strings.Builder
+	list := ""
+	closeList := func() {
+		if list != "" {
+			b.WriteString("</" + list + ">")
+			list = ""
+		}
+	}
+
+	paragraph := false
+	for _, line := range strings.Split(text, "\n") {
+		var item, tag string
+		if m := markdownBullet.FindStringSubmatch(line); m != nil {
+			item, tag = m[1], "ul"
+		} else if m := markdownNumbered.FindStringSubmatch(line); m != nil {
+			item, tag = m[1], "ol"
+		}
+
+		switch {
+		case tag != "":
+			if paragraph {
+				b.WriteString("</p>")
+				paragraph = false
+			}
+			if list != tag {
+				closeList()
+				b.WriteString("<" + tag + ">")
+				list = tag
+			}
+			b.WriteString("<li>" + markdownInlineToHTML(item) + "</li>")
+		case strings.TrimSpace(line) == "":
+			closeList()
+			if paragraph {
+				b.WriteString("</p>")
+				paragraph = false
+			}
+		default:
+			closeList()
+			if paragraph {
+				b.WriteString("<br>")
+			} else {
+				b.WriteString("<p>")
+				paragraph = true
+			}
+			b.WriteString(markdownInlineToHTML(line))
+		}
+	}
+	closeList()
+	if paragraph {
+		b.WriteString("</p>")
+	}
+
+	return b.String()
+}
+
+func markdownInlineToHTML(line string) string {
+	line, escaped := hideEscapes(line)
+	line = html.EscapeString(line)
+
+	line = markdownLink.ReplaceAllStringFunc(line, func(m string) string {
+		parts := markdownLink.FindStringSubmatch(m)
+		url := html.UnescapeString(parts[2])
+		if !htmlSafeURL.MatchString(url) {
+			return parts[1]
+		}
+		title := parts[1]
+		if title == "" {
+			title = parts[2]
+		}
+		return `<a href="` + html.EscapeString(url) + `" target="_blank" rel="noopener noreferrer">` + title + "</a>"
+	})
+	line = markdownStrong.ReplaceAllString(line, "<strong>$1$2</strong>")
+	line = markdownEmph.ReplaceAllString(line, "<em>$1$2</em>")
+
+	return restoreEscapes(line, escaped, html.EscapeString)
+}
@@ -0,0 +1,50 @@
+package teams
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderHTMLDropsUnsafeCSS(t *testing.T) {
+	const evil = "1px;position:fixed;inset:0;background:url(https://evil.example/t.png)"
+	tests := []struct {
+		name string
+		card string
+	}{
+		{name: "card minHeight", card: `{"type":"AdaptiveCard","version":"1.6","minHeight":"` + evil + `","body":[]}`},
+		{name: "container minHeight", card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"Container","minHeight":"` + evil + `","items":[]}]}`},
+		{name: "column width", card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"ColumnSet","columns":[{"type":"Column","width":"` + evil + `","items":[]}]}]}`},
+		{name: "image width", card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"Image","url":"https://example.com/a.png","width":"` + evil + `"}]}`},
+		{name: "image height", card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"Image","url":"https://example.com/a.png","height":"` + evil + `"}]}`},
+		{name: "image backgroundColor", card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"Image","url":"https://example.com/a.png","backgroundColor":"` + evil + `"}]}`},
+		{name: "text alignment", card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"TextBlock","text":"x","horizontalAlignment":"` + evil + `"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var card AdaptiveCard
+			if err := json.Unmarshal([]byte(tt.card), &card); err != nil {
+				t.Fatal(err)
+			}
+			if out := RenderHTML(&card, nil); strings.Contains(out, "evil.example") || strings.Contains(out, "position:fixed") {
+				t.Fatalf("rendered HTML contains the injected CSS:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestRenderHTMLKeepsPixelLengths(t *testing.T) {
+	var card AdaptiveCard
+	err := json.Unmarshal([]byte(`{"type":"AdaptiveCard","version":"1.6","minHeight":"120px","body":[
+		{"type":"Image","url":"https://example.com/a.png","width":"64px","height":"32px","backgroundColor":"#FF112233"}]}`), &card)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := RenderHTML(&card, nil)
+	for _, want := range []string{"min-height:120px", "width:64px", "height:32px", "background-color:rgba(17,34,51,1)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered HTML doesn’t contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+// Code generated by schemagen from adaptive-card-1.6.json; DO NOT EDIT.
+
+package teams
+
+const (
+	TypeDataQuery      Type = "Data.Query"
+	TypeInputChoiceSet Type = "Input.ChoiceSet"
+	TypeInputDate      Type = "Input.Date"
+	TypeInputNumber    Type = "Input.Number"
+	TypeInputRating    Type = "Input.Rating"
+	TypeInputText      Type = "Input.Text"
+	TypeInputTime      Type = "Input.Time"
+	TypeInputToggle    Type = "Input.Toggle"
+)
+
+// The data populated in the event payload of the dynamic typeahead search of an Input.ChoiceSet
+//
+// Source: https://adaptivecards.io/explorer/Data.Query.html
+type DataQuery struct {
+	// Must be TypeDataQuery ("Data.Query")
+	Type Type `json:"type"`
+	// The dataset from which to fetch the data, as understood by the Bot
+	Dataset string `json:"dataset"`
+	// The number of choices the Bot should send at most
+	Count int `json:"count,omitempty"`
+	// The number of choices the Bot should skip, used for paging
+	Skip int `json:"skip,omitempty"`
+}
+
+func NewDataQuery(dataset string) *DataQuery {
+	return &DataQuery{
+		Type:    TypeDataQuery,
+		Dataset: dataset,
+	}
+}
+
+// Describes a choice for use in a ChoiceSet
+//
+// Source: https://adaptivecards.io/explorer/Input.Choice.html
+type InputChoice struct {
+	// Text to display
+	Title string `json:"title"`
+	// The raw value for the choice. NOTE: do not use a , in the value, since a ChoiceSet with isMultiSelect set to true returns a comma-delimited string of choice values
+	Value string `json:"value"`
+}
+
+// Allows a user to input a Choice
+//
+// Source: https://adaptivecards.io/explorer/Input.ChoiceSet.html
+type InputChoiceSet struct {
+	// Must be TypeInputChoiceSet ("Input.ChoiceSet")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// Choice options
+	Choices []InputChoice `json:"choices,omitempty"`
+	// Enables dynamic auto-complete of the choices from a data set the Bot provides. Requires style filtered
+	ChoicesData *DataQuery `json:"choices.data,omitempty"`
+	// Allow multiple choices to be selected
+	IsMultiSelect bool `json:"isMultiSelect,omitempty"`
+	// Description of the input desired. Only visible when no selection has been made, the style is compact and isMultiSelect is false
+	Style ChoiceInputStyle `json:"style,omitempty"`
+	// The initial choice (or set of choices) that should be selected. For multi-select, specify a comma-separated string of values
+	Value string `json:"value,omitempty"`
+	// Description of the input desired. Displayed when no text has been input
+	Placeholder string `json:"placeholder,omitempty"`
+	// If true, allow text to wrap. Otherwise, text is clipped
+	Wrap bool `json:"wrap,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputChoiceSet(id string) *InputChoiceSet {
+	return &InputChoiceSet{
+		Type: TypeInputChoiceSet,
+		Id:   id,
+	}
+}
+
+func (i *InputChoiceSet) IsElement() bool {
+	return true
+}
+
+// Lets a user choose a date
+//
+// Source: https://adaptivecards.io/explorer/Input.Date.html
+type InputDate struct {
+	// Must be TypeInputDate ("Input.Date")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// Hint of maximum value expressed in YYYY-MM-DD(may be ignored by some clients)
+	Max string `json:"max,omitempty"`
+	// Hint of minimum value expressed in YYYY-MM-DD(may be ignored by some clients)
+	Min string `json:"min,omitempty"`
+	// Description of the input desired. Displayed when no text has been input
+	Placeholder string `json:"placeholder,omitempty"`
+	// The initial value for this field expressed in YYYY-MM-DD
+	Value string `json:"value,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputDate(id string) *InputDate {
+	return &InputDate{
+		Type: TypeInputDate,
+		Id:   id,
+	}
+}
+
+func (i *InputDate) IsElement() bool {
+	return true
+}
+
+// Allows a user to enter a number
+//
+// Source: https://adaptivecards.io/explorer/Input.Number.html
+type InputNumber struct {
+	// Must be TypeInputNumber ("Input.Number")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// Hint of maximum value (may be ignored by some clients)
+	Max *float64 `json:"max,omitempty"`
+	// Hint of minimum value (may be ignored by some clients)
+	Min *float64 `json:"min,omitempty"`
+	// Description of the input desired. Displayed when no text has been input
+	Placeholder string `json:"placeholder,omitempty"`
+	// The initial value for this field
+	Value *float64 `json:"value,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputNumber(id string) *InputNumber {
+	return &InputNumber{
+		Type: TypeInputNumber,
+		Id:   id,
+	}
+}
+
+func (i *InputNumber) IsElement() bool {
+	return true
+}
+
+// Lets a user rate something using stars
+//
+// Source: https://adaptivecards.io/explorer/Input.Rating.html
+type InputRating struct {
+	// Must be TypeInputRating ("Input.Rating")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// The number of stars to display. Defaults to 5
+	Max float64 `json:"max,omitempty"`
+	// The initial rating
+	Value float64 `json:"value,omitempty"`
+	// If true, the user can rate in half star steps
+	AllowHalfSteps bool `json:"allowHalfSteps,omitempty"`
+	// The size of the stars
+	Size RatingSize `json:"size,omitempty"`
+	// The color of the stars
+	Color RatingColor `json:"color,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputRating(id string) *InputRating {
+	return &InputRating{
+		Type: TypeInputRating,
+		Id:   id,
+	}
+}
+
+func (i *InputRating) IsElement() bool {
+	return true
+}
+
+// Lets a user enter text
+//
+// Source: https://adaptivecards.io/explorer/Input.Text.html
+type InputText struct {
+	// Must be TypeInputText ("Input.Text")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// If true, allow multiple lines of input
+	IsMultiline bool `json:"isMultiline,omitempty"`
+	// Hint of maximum length characters to collect (may be ignored by some clients)
+	MaxLength int `json:"maxLength,omitempty"`
+	// Description of the input desired. Displayed when no text has been input
+	Placeholder string `json:"placeholder,omitempty"`
+	// Regular expression indicating the required format of this text input
+	Regex string `json:"regex,omitempty"`
+	// Style hint for text input
+	Style TextInputStyle `json:"style,omitempty"`
+	// The inline action for the input. Typically displayed to the right of the input. It is strongly recommended to provide an icon on the action (which will be displayed instead of the title of the action)
+	InlineAction ISelectAction `json:"inlineAction,omitempty"`
+	// The initial value for this field
+	Value string `json:"value,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputText(id string) *InputText {
+	return &InputText{
+		Type: TypeInputText,
+		Id:   id,
+	}
+}
+
+func (i *InputText) IsElement() bool {
+	return true
+}
+
+// Lets a user select a time
+//
+// Source: https://adaptivecards.io/explorer/Input.Time.html
+type InputTime struct {
+	// Must be TypeInputTime ("Input.Time")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// Hint of maximum value expressed in HH:MM (may be ignored by some clients)
+	Max string `json:"max,omitempty"`
+	// Hint of minimum value expressed in HH:MM (may be ignored by some clients)
+	Min string `json:"min,omitempty"`
+	// Description of the input desired. Displayed when no text has been input
+	Placeholder string `json:"placeholder,omitempty"`
+	// The initial value for this field expressed in HH:MM
+	Value string `json:"value,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputTime(id string) *InputTime {
+	return &InputTime{
+		Type: TypeInputTime,
+		Id:   id,
+	}
+}
+
+func (i *InputTime) IsElement() bool {
+	return true
+}
+
+// Lets a user choose between two options
+//
+// Source: https://adaptivecards.io/explorer/Input.Toggle.html
+type InputToggle struct {
+	// Must be TypeInputToggle ("Input.Toggle")
+	Type Type `json:"type"`
+	// Unique identifier for the value. Used to identify collected input when the Submit action is performed
+	Id string `json:"id"`
+	// Title for the toggle
+	Title string `json:"title"`
+	// The initial selected value. If you want the toggle to be initially on, set this to the value of valueOn‘s value
+	Value string `json:"value,omitempty"`
+	// The value when toggle is off
+	ValueOff string `json:"valueOff,omitempty"`
+	// The value when toggle is on
+	ValueOn string `json:"valueOn,omitempty"`
+	// If true, allow text to wrap. Otherwise, text is clipped
+	Wrap bool `json:"wrap,omitempty"`
+	// Error message to display when entered input is invalid
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Whether or not this input is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// Label for this input
+	Label string `json:"label,omitempty"`
+	// Describes what to do when an unknown element is encountered or the requires of this or any children can’t be met
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Specifies the height of the element
+	Height BlockElementHeight `json:"height,omitempty"`
+	// When `true`, draw a separating line at the top of the element
+	Separator bool `json:"separator,omitempty"`
+	// Controls the amount of spacing between this element and the preceding element
+	Spacing Spacing `json:"spacing,omitempty"`
+	// If `false`, this item will be removed from the visual tree
+	IsVisible *bool `json:"isVisible,omitempty"`
+	// A series of key/value pairs indicating features that the item requires with corresponding minimum version. When a feature is missing or of insufficient version, fallback is triggered
+	Requires map[string]Version `json:"requires,omitempty"`
+}
+
+func NewInputToggle(id, title string) *InputToggle {
+	return &InputToggle{
+		Type:  TypeInputToggle,
+		Id:    id,
+		Title: title,
+	}
+}
+
+func (i *InputToggle) IsElement() bool {
+	return true
+}
@@ -0,0 +1,98 @@
+package teams
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInputsRoundTrip(t *testing.T) {
+	card := roundTrip(t, `{"type":"AdaptiveCard","version":"1.6","body":[
+		{"type":"Input.Text","id":"text","label":"Name","isRequired":true,"errorMessage":"required","isMultiline":true,
+			"inlineAction":{"type":"Action.Submit","title":"Send"}},
+		{"type":"Input.Number","id":"zero","value":0,"min":-1.5,"max":2.5},
+		{"type":"Input.Number","id":"unset"},
+		{"type":"Input.Date","id":"date","label":"Date","isRequired":true},
+		{"type":"Input.Time","id":"time","errorMessage":"bad time"},
+		{"type":"Input.Toggle","id":"toggle","title":"Notify","isRequired":true},
+		{"type":"Input.ChoiceSet","id":"user","label":"User","isRequired":true,"style":"filtered",
+			"choices":[{"title":"Me","value":"me"}],
+			"choices.data":{"type":"Data.Query","dataset":"graph.microsoft.com/users","count":10,"skip":5}},
+		{"type":"Input.Rating","id":"rating","max":5,"value":3.5,"allowHalfSteps":true,"label":"Rating","isRequired":true}
+	]}`)
+
+	number := card.Body[1].(*InputNumber)
+	if number.Value == nil || *number.Value != 0 || *number.Min != -1.5 || *number.Max != 2.5 {
+		t.Errorf("Input.Number = %+v, want value 0, min -1.5 and max 2.5", number)
+	}
+	if unset := card.Body[2].(*InputNumber); unset.Value != nil || unset.Min != nil || unset.Max != nil {
+		t.Errorf("Input.Number without values = %+v, want nil values", unset)
+	}
+	choices := card.Body[6].(*InputChoiceSet)
+	if choices.ChoicesData == nil || choices.ChoicesData.Dataset != "graph.microsoft.com/users" {
+		t.Errorf("Input.ChoiceSet choices.data = %+v", choices.ChoicesData)
+	}
+	if rating := card.Body[7].(*InputRating); rating.Value != 3.5 || !rating.AllowHalfSteps {
+		t.Errorf("Input.Rating = %+v", rating)
+	}
+	if err := card.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+func TestInputIsRequiredTag(t *testing.T) {
+	inputs := []Element{
+		NewInputText("a"), NewInputNumber("a"), NewInputDate("a"), NewInputTime("a"), NewInputToggle("a", "t"),
+		NewInputChoiceSet("a"), NewInputRating("a"),
+	}
+	for _, input := range inputs {
+		if err := json.Unmarshal([]byte(`{"isRequired":true}`), input); err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), `"isRequired":true`) {
+			t.Errorf("%T marshals to %s, want isRequired", input, data)
+		}
+	}
+}
+
+func TestValidateInputs(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+	tests := []struct {
+		name  string
+		input Element
+		want  string
+	}{
+		{"text without id", NewInputText(""), "Id is required"},
+		{"invalid inline action", &InputText{Type: TypeInputText, Id: "a", InlineAction: &ActionSubmit{}}, "inlineAction: Type is required"},
+		{"number min above max", &InputNumber{Type: TypeInputNumber, Id: "a", Min: float(2), Max: float(1)}, "Min 2 is greater than Max 1"},
+		{"choice set data without filtered style", &InputChoiceSet{Type: TypeInputChoiceSet, Id: "a", ChoicesData: NewDataQuery("users")}, "Style must be filtered"},
+		{"choice set data without dataset", &InputChoiceSet{Type: TypeInputChoiceSet, Id: "a", Style: ChoiceInputStyleFiltered, ChoicesData: NewDataQuery("")}, "choices.data: Dataset is required"},
+		{"choice set data with negative count", &InputChoiceSet{Type: TypeInputChoiceSet, Id: "a", Style: ChoiceInputStyleFiltered, ChoicesData: &DataQuery{Type: TypeDataQuery, Dataset: "users", Count: -1}}, "Count and Skip must not be negative"},
+		{"rating above max", &InputRating{Type: TypeInputRating, Id: "a", Max: 5, Value: 6}, "Value 6 is greater than Max 5"},
+		{"negative rating", &InputRating{Type: TypeInputRating, Id: "a", Value: -1}, "must not be negative"},
+		{"toggle without id", NewInputToggle("", "t"), "Id is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := NewAdaptiveCard()
+			card.Version = Version16
+			card.Body = append(card.Body, tt.input)
+			if err := card.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputRatingRequiresVersion16(t *testing.T) {
+	card := NewAdaptiveCard()
+	card.Version = Version15
+	card.Body = append(card.Body, NewInputRating("a"))
+	if err := card.Validate(); err == nil || !strings.Contains(err.Error(), "Input.Rating requires version 1.6") {
+		t.Errorf("Validate() = %v, want a version error", err)
+	}
+}
@@ -0,0 +1,1040 @@
+// Command schemagen generates the Go types of package teams from the vendored Adaptive Card JSON schemas.
+//
+// It reads every schemas/adaptive-card-*.json file of the package directory, completes them with overrides.json,
+// takes the structs, enums, constants, constructors, IsElement/IsAction markers and polymorphic decoders from the
+// latest one, and the version each element and action was introduced in from the earliest one it appears in. Run it
+// through go generate from the package directory:
+//
+//	//go:generate go run ./internal/schemagen
+//
+// Constructors and UnmarshalJSON methods that are written by hand in a non-generated file of the package take
+// precedence over the generated ones, so special cases like the string shorthand of a TextRun stay possible.
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// files assigns the schema types to the generated files, named after the hand-written file that holds the
+// rest of their code. Element types that aren’t listed here end up in cardElements, types that are only
+// referenced by other types end up next to the first type that references them
+var files = map[string]string{
+	"AdaptiveCard": "adaptiveCard",
+	"ActionSet":    "containers",
+	"Container":    "containers",
+	"ColumnSet":    "containers",
+	"Column":       "containers",
+	"FactSet":      "containers",
+	"ImageSet":     "containers",
+	"Carousel":     "containers",
+	"CarouselPage": "containers",
+	"Table":        "containers",
+	"TableRow":     "containers",
+	"TableCell":    "containers",
+}
+
+// sources holds the documentation of the types that aren’t part of the Adaptive Cards explorer. An empty
+// source omits the Source line
+var sources = map[string]string{
+	"CodeBlock":      "https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format#codeblock-in-adaptive-cards",
+	"ProgressBar":    "https://adaptivecards.microsoft.com/?topic=ProgressBar",
+	"ProgressRing":   "https://adaptivecards.microsoft.com/?topic=ProgressRing",
+	"Badge":          "https://adaptivecards.microsoft.com/?topic=Badge",
+	"Icon":           "https://adaptivecards.microsoft.com/?topic=Icon",
+	"IconInfo":       "",
+	"CompoundButton": "https://adaptivecards.microsoft.com/?topic=CompoundButton",
+	"Carousel":       "https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format#carousel-layout",
+	"CarouselPage":   "https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format#carousel-layout",
+}
+
+// fieldTypes overrides the Go type of properties the schema can’t describe precisely enough, like numbers
+// that need to be told apart from 0 and tri-state booleans
+var fieldTypes = map[string]string{
+	"AdaptiveCard.version":    "Version",
+	"AdaptiveCard.$schema":    "Schema",
+	"Input.Number.max":        "*float64",
+	"Input.Number.min":        "*float64",
+	"Input.Number.value":      "*float64",
+	"ProgressBar.value":       "*float64",
+	"TargetElement.isVisible": "*bool",
+}
+
+// overridesJSON completes the definitions of the schemas, which are vendored unchanged so that cards are validated
+// against the official ones: properties that are required in practice, like the card of Action.ShowCard, and
+// booleans that default to true and so need to be told apart from false. Each override is applied to every schema
+// that has the definition or property
+//
+//go:embed overrides.json
+var overridesJSON []byte
+
+// override is the part of a definition that overrides.json can complete
+type override struct {
+	Required   []string `json:"required"`
+	Properties map[string]struct {
+		Default interface{} `json:"default"`
+	} `json:"properties"`
+}
+
+// enumPrefixes overrides the prefix of the constants of an enum, which defaults to the name of the enum
+var enumPrefixes = map[string]string{
+	"Colors":           "Color",
+	"AssociatedInputs": "AssociatedInput",
+}
+
+// interfaces maps the ImplementationsOf definitions to the Go interface their implementations satisfy and the
+// marker method of that interface
+var interfaces = []struct {
+	def, name, marker string
+}{
+	{"ImplementationsOf.Element", "Element", "IsElement"},
+	{"ImplementationsOf.Action", "Action", "IsAction"},
+	{"ImplementationsOf.ISelectAction", "ISelectAction", "IsISelectAction"},
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("schemagen: ")
+
+	dir := flag.String("dir", ".", "the directory of package teams")
+	flag.Parse()
+
+	paths, err := filepath.Glob(filepath.Join(*dir, "schemas", "adaptive-card-*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(paths) == 0 {
+		log.Fatalf("no schemas found in %s", filepath.Join(*dir, "schemas"))
+	}
+	sort.Strings(paths)
+
+	var overrides map[string]override
+	if err := json.Unmarshal(overridesJSON, &overrides); err != nil {
+		log.Fatalf("overrides.json: %v", err)
+	}
+
+	var schemas []*document
+	for _, path := range paths {
+		doc, err := readSchema(path)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		doc.apply(overrides)
+		schemas = append(schemas, doc)
+	}
+
+	handWritten, err := declaredFuncs(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := newGenerator(schemas, handWritten)
+	out, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stale, _ := filepath.Glob(filepath.Join(*dir, "*_gen.go"))
+	for _, path := range stale {
+		if _, ok := out[filepath.Base(path)]; !ok {
+			if err := os.Remove(path); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	for name, src := range out {
+		if err := os.WriteFile(filepath.Join(*dir, name), src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// document is a parsed schema file
+type document struct {
+	path    string
+	version string
+	defs    map[string]*schema
+	order   []string
+}
+
+// schema is the subset of a JSON schema the generator understands
+type schema struct {
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Enum        []string    `json:"enum"`
+	Default     interface{} `json:"default"`
+	Ref         string      `json:"$ref"`
+	AnyOf       []*schema   `json:"anyOf"`
+	AllOf       []*schema   `json:"allOf"`
+	Items       *schema     `json:"items"`
+	Properties  properties  `json:"properties"`
+	Required    []string    `json:"required"`
+}
+
+// properties keeps the properties of a schema in the order of the file, which is the order of the struct fields
+type properties struct {
+	names  []string
+	values map[string]*schema
+}
+
+func (p *properties) UnmarshalJSON(data []byte) error {
+	ordered, err := orderedObject(data)
+	if err != nil {
+		return err
+	}
+	p.values = map[string]*schema{}
+	for _, kv := range ordered {
+		var s schema
+		if err := json.Unmarshal(kv.value, &s); err != nil {
+			return fmt.Errorf("%s: %w", kv.key, err)
+		}
+		p.names = append(p.names, kv.key)
+		p.values[kv.key] = &s
+	}
+
+	return nil
+}
+
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// orderedObject returns the members of a JSON object in their original order
+func orderedObject(data []byte) ([]member, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object, got %v", t)
+	}
+
+	var members []member
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, member{key: t.(string), value: value})
+	}
+
+	return members, nil
+}
+
+func readSchema(path string) (*document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var head struct {
+		Definitions json.RawMessage `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	members, err := orderedObject(head.Definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &document{
+		path:    filepath.ToSlash(path),
+		version: strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "adaptive-card-"), ".json"),
+		defs:    map[string]*schema{},
+	}
+	for _, m := range members {
+		var s schema
+		if err := json.Unmarshal(m.value, &s); err != nil {
+			return nil, fmt.Errorf("%s: %w", m.key, err)
+		}
+		doc.defs[m.key] = &s
+		doc.order = append(doc.order, m.key)
+	}
+
+	return doc, nil
+}
+
+// apply adds the overrides to the definitions and properties of the schema that exist
+func (d *document) apply(overrides map[string]override) {
+	for name, o := range overrides {
+		s := d.defs[name]
+		if s == nil {
+			continue
+		}
+		for _, r := range o.Required {
+			if !contains(s.Required, r) {
+				s.Required = append(s.Required, r)
+			}
+		}
+		for prop, po := range o.Properties {
+			if ps, ok := s.Properties.values[prop]; ok && !isEmpty(ps) {
+				ps.Default = po.Default
+			}
+		}
+	}
+}
+
+// declaredFuncs returns the functions and methods declared in the non-generated files of the package, methods as
+// "Receiver.Method"
+func declaredFuncs(dir string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_gen.go") && !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				name := fn.Name.Name
+				if fn.Recv != nil && len(fn.Recv.List) == 1 {
+					recv := fn.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if ident, ok := recv.(*ast.Ident); ok {
+						name = ident.Name + "." + name
+					}
+				}
+				funcs[name] = true
+			}
+		}
+	}
+
+	return funcs, nil
+}
+
+type generator struct {
+	doc         *document
+	schemas     []*document
+	handWritten map[string]bool
+	// implements maps a type to the interfaces it implements
+	implements map[string][]string
+	// fileOf maps a type to the file it’s generated into
+	fileOf map[string]string
+}
+
+// field is a struct field generated from a property
+type field struct {
+	prop, name, typ, doc string
+	required             bool
+}
+
+func newGenerator(schemas []*document, handWritten map[string]bool) *generator {
+	g := &generator{
+		doc:         schemas[len(schemas)-1],
+		schemas:     schemas,
+		handWritten: handWritten,
+		implements:  map[string][]string{},
+		fileOf:      map[string]string{},
+	}
+	for _, iface := range interfaces {
+		for _, name := range g.implementations(iface.def) {
+			g.implements[name] = append(g.implements[name], iface.name)
+		}
+	}
+
+	return g
+}
+
+// generate returns the generated files by name
+func (g *generator) generate() (map[string][]byte, error) {
+	g.assignFiles()
+
+	bodies := map[string]*bytes.Buffer{}
+	buf := func(file string) *bytes.Buffer {
+		if bodies[file] == nil {
+			bodies[file] = &bytes.Buffer{}
+		}
+		return bodies[file]
+	}
+
+	// type constants first, grouped per file
+	typed := g.typedDefs()
+	for _, file := range g.sortedFiles() {
+		var consts []string
+		for _, name := range typed {
+			if g.fileOf[name] == file {
+				consts = append(consts, fmt.Sprintf("Type%s Type = %q", goName(name), name))
+			}
+		}
+		if len(consts) > 0 {
+			fmt.Fprintf(buf(file), "const (\n%s\n)\n\n", strings.Join(consts, "\n"))
+		}
+	}
+
+	for _, name := range g.classes() {
+		b := buf(g.fileOf[name])
+		if err := g.writeStruct(b, name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range g.enums() {
+		g.writeEnum(buf("enums"), name)
+	}
+
+	g.writeRegistry(buf("decode"))
+	if err := g.writeDecoders(buf("decode")); err != nil {
+		return nil, err
+	}
+	g.writeVersions(buf("version"))
+
+	out := map[string][]byte{}
+	for file, body := range bodies {
+		if body.Len() == 0 {
+			continue
+		}
+		var src bytes.Buffer
+		fmt.Fprintf(&src, "// Code generated by schemagen from %s; DO NOT EDIT.\n\npackage teams\n\n", filepath.Base(g.doc.path))
+		if strings.Contains(body.String(), "json.") {
+			src.WriteString("import (\n\"encoding/json\"\n\"fmt\"\n)\n\n")
+		}
+		src.Write(body.Bytes())
+		formatted, err := format.Source(src.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s_gen.go: %w\n%s", file, err, src.Bytes())
+		}
+		out[file+"_gen.go"] = formatted
+	}
+
+	return out, nil
+}
+
+func (g *generator) sortedFiles() []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, file := range g.fileOf {
+		if !seen[file] {
+			seen[file] = true
+			out = append(out, file)
+		}
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// classes returns the object definitions that become structs, sorted by name
+func (g *generator) classes() []string {
+	var out []string
+	for _, name := range g.doc.order {
+		s := g.doc.defs[name]
+		if s.Type == "object" && len(s.Properties.names) > 0 && !strings.HasPrefix(name, "Extendable.") {
+			out = append(out, name)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return goName(out[i]) < goName(out[j]) })
+
+	return out
+}
+
+// typedDefs returns the classes with a type discriminator, sorted by name
+func (g *generator) typedDefs() []string {
+	var out []string
+	for _, name := range g.classes() {
+		if g.discriminator(name) != "" {
+			out = append(out, name)
+		}
+	}
+
+	return out
+}
+
+// discriminator returns the value of the type property of a class, if it has one
+func (g *generator) discriminator(name string) string {
+	t := g.doc.defs[name].Properties.values["type"]
+	if t == nil || len(t.Enum) != 1 {
+		return ""
+	}
+
+	return t.Enum[0]
+}
+
+// enums returns the enum definitions, sorted by name
+func (g *generator) enums() []string {
+	var out []string
+	for _, name := range g.doc.order {
+		if len(enumValues(g.doc.defs[name])) > 0 {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+func enumValues(s *schema) []string {
+	for _, alt := range s.AnyOf {
+		if len(alt.Enum) > 0 {
+			return alt.Enum
+		}
+	}
+
+	return nil
+}
+
+// implementations returns the types listed by an ImplementationsOf definition
+func (g *generator) implementations(def string) []string {
+	s := g.doc.defs[def]
+	if s == nil {
+		return nil
+	}
+
+	var out []string
+	for _, alt := range s.AnyOf {
+		for _, base := range alt.AllOf {
+			out = append(out, refName(base.Ref))
+		}
+		if alt.Ref != "" {
+			out = append(out, refName(alt.Ref))
+		}
+	}
+
+	return out
+}
+
+// assignFiles decides which file each class is generated into
+func (g *generator) assignFiles() {
+	typed := g.typedDefs()
+	for _, name := range typed {
+		switch {
+		case files[name] != "":
+			g.fileOf[name] = files[name]
+		case strings.HasPrefix(name, "Action."):
+			g.fileOf[name] = "actions"
+		case strings.HasPrefix(name, "Input.") || strings.HasPrefix(name, "Data."):
+			g.fileOf[name] = "inputs"
+		default:
+			g.fileOf[name] = "cardElements"
+		}
+	}
+
+	// types without a discriminator follow the first type referencing them, the card itself coming first
+	order := append([]string{"AdaptiveCard"}, typed...)
+	for changed := true; changed; {
+		changed = false
+		for _, owner := range order {
+			for _, ref := range g.references(owner) {
+				if _, ok := g.fileOf[ref]; ok || !g.isClass(ref) {
+					continue
+				}
+				file := g.fileOf[owner]
+				if owner == "AdaptiveCard" {
+					file = "types"
+				}
+				g.fileOf[ref] = file
+				order = append(order, ref)
+				changed = true
+			}
+		}
+	}
+	for _, name := range g.classes() {
+		if _, ok := g.fileOf[name]; !ok {
+			g.fileOf[name] = "types"
+		}
+	}
+}
+
+func (g *generator) isClass(name string) bool {
+	for _, c := range g.classes() {
+		if c == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// references returns the definitions referenced by the properties of a class, in property order
+func (g *generator) references(name string) []string {
+	var out []string
+	var walk func(s *schema)
+	walk = func(s *schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			out = append(out, refName(s.Ref))
+		}
+		for _, alt := range s.AnyOf {
+			walk(alt)
+		}
+		walk(s.Items)
+	}
+	s := g.doc.defs[name]
+	for _, prop := range s.Properties.names {
+		walk(s.Properties.values[prop])
+	}
+
+	return out
+}
+
+// bases returns the Extendable definitions a class inherits from, the most specific first
+func (g *generator) bases(name string) []string {
+	var out []string
+	s := g.doc.defs[name]
+	for len(s.AllOf) > 0 && s.AllOf[0].Ref != "" {
+		base := refName(s.AllOf[0].Ref)
+		out = append(out, base)
+		s = g.doc.defs[base]
+	}
+
+	return out
+}
+
+// fields returns the struct fields of a class: the type, its own properties in schema order, then the inherited
+// properties, the most specific base first
+func (g *generator) fields(name string) ([]field, error) {
+	s := g.doc.defs[name]
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	for _, base := range g.bases(name) {
+		for _, r := range g.doc.defs[base].Required {
+			required[r] = true
+		}
+	}
+	if len(g.implements[name]) > 0 {
+		required["type"] = true
+	}
+
+	type prop struct {
+		name, owner string
+		schema      *schema
+	}
+	var props []prop
+	seen := map[string]bool{}
+	inherit := func(required bool) {
+		for _, base := range g.bases(name) {
+			bs := g.doc.defs[base]
+			for _, p := range bs.Properties.names {
+				if seen[p] || required && !contains(bs.Required, p) {
+					continue
+				}
+				if ps, ok := s.Properties.values[p]; !ok || !isEmpty(ps) {
+					// redeclared by the class, or added by a newer version of the base
+					continue
+				}
+				props = append(props, prop{p, base, bs.Properties.values[p]})
+				seen[p] = true
+			}
+		}
+	}
+	if t, ok := s.Properties.values["type"]; ok {
+		props = append(props, prop{"type", name, t})
+		seen["type"] = true
+	}
+	// required inherited properties identify the item, like the id of inputs, so they come right after the type
+	inherit(true)
+	for _, p := range s.Properties.names {
+		ps := s.Properties.values[p]
+		if seen[p] || isEmpty(ps) {
+			continue
+		}
+		props = append(props, prop{p, name, ps})
+		seen[p] = true
+	}
+	inherit(false)
+
+	var out []field
+	for _, p := range props {
+		f := field{prop: p.name, name: fieldName(p.name), required: required[p.name]}
+		if p.name == "type" && g.discriminator(name) != "" {
+			f.typ = "Type"
+			f.doc = fmt.Sprintf("Must be Type%s (%q)", goName(name), g.discriminator(name))
+		} else {
+			typ, err := g.goType(name+"."+p.name, p.schema, f.required)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", name, p.name, err)
+			}
+			f.typ = typ
+			f.doc = comment(p.schema.Description)
+		}
+		out = append(out, f)
+	}
+
+	return out, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmpty(s *schema) bool {
+	return s.Type == "" && s.Ref == "" && s.AnyOf == nil && s.AllOf == nil && s.Enum == nil && s.Items == nil
+}
+
+// goType returns the Go type of a property
+func (g *generator) goType(path string, s *schema, required bool) (string, error) {
+	if t, ok := fieldTypes[path]; ok {
+		return t, nil
+	}
+
+	switch {
+	case s.Ref != "":
+		return g.refType(refName(s.Ref), required)
+	case s.AnyOf != nil:
+		var refs []string
+		others := 0
+		for _, alt := range s.AnyOf {
+			switch {
+			case alt.Ref != "" && refName(alt.Ref) == "FallbackOption":
+				return "*Fallback", nil
+			case alt.Ref != "":
+				refs = append(refs, refName(alt.Ref))
+			case alt.Type == "string":
+				// the shorthand of an object or a value outside the enum
+			default:
+				others++
+			}
+		}
+		if len(refs) == 1 && others == 0 {
+			return g.refType(refs[0], required)
+		}
+
+		return "interface{}", nil
+	}
+
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "boolean":
+		if s.Default == true {
+			return "*bool", nil
+		}
+		return "bool", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}", nil
+		}
+		item, err := g.goType(path+"[]", s.Items, true)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		return "map[string]interface{}", nil
+	}
+
+	return "", fmt.Errorf("unsupported schema %+v", s)
+}
+
+func (g *generator) refType(name string, required bool) (string, error) {
+	for _, iface := range interfaces {
+		if name == iface.def {
+			return iface.name, nil
+		}
+	}
+	if name == "HostCapabilities" {
+		return "map[string]Version", nil
+	}
+
+	s := g.doc.defs[name]
+	switch {
+	case s == nil:
+		return "", fmt.Errorf("unknown definition %s", name)
+	case len(enumValues(s)) > 0:
+		return goName(name), nil
+	case s.Type == "object":
+		if required {
+			return goName(name), nil
+		}
+		return "*" + goName(name), nil
+	}
+
+	return "", fmt.Errorf("unsupported definition %s", name)
+}
+
+func (g *generator) writeStruct(b *bytes.Buffer, name string) error {
+	fields, err := g.fields(name)
+	if err != nil {
+		return err
+	}
+	typeName := goName(name)
+	recv := receiver(typeName)
+
+	writeDoc(b, g.doc.defs[name].Description)
+	source, ok := sources[name]
+	if !ok {
+		source = "https://adaptivecards.io/explorer/" + name + ".html"
+	}
+	if source != "" {
+		fmt.Fprintf(b, "//\n// Source: %s\n", source)
+	}
+	fmt.Fprintf(b, "type %s struct {\n", typeName)
+	for _, f := range fields {
+		tag := f.prop
+		if !f.required {
+			tag += ",omitempty"
+		}
+		if f.doc != "" {
+			fmt.Fprintf(b, "// %s\n", f.doc)
+		}
+		fmt.Fprintf(b, "%s %s `json:%q`\n", f.name, f.typ, tag)
+	}
+	b.WriteString("}\n\n")
+
+	if d := g.discriminator(name); d != "" && !g.handWritten["New"+typeName] {
+		g.writeConstructor(b, typeName, fields)
+	}
+
+	for _, iface := range interfaces {
+		for _, impl := range g.implements[name] {
+			if impl == iface.name && !g.handWritten[typeName+"."+iface.marker] {
+				fmt.Fprintf(b, "func (%s *%s) %s() bool {\nreturn true\n}\n\n", recv, typeName, iface.marker)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeConstructor writes a constructor taking the required properties of a class. A trailing slice becomes a
+// variadic parameter
+func (g *generator) writeConstructor(b *bytes.Buffer, typeName string, fields []field) {
+	var params []field
+	for _, f := range fields {
+		if f.required && f.prop != "type" {
+			params = append(params, f)
+		}
+	}
+
+	var sig []string
+	for i, p := range params {
+		typ := p.typ
+		if i == len(params)-1 && strings.HasPrefix(typ, "[]") {
+			typ = "..." + strings.TrimPrefix(typ, "[]")
+		}
+		if i+1 < len(params) && params[i+1].typ == p.typ {
+			sig = append(sig, paramName(p.prop))
+		} else {
+			sig = append(sig, paramName(p.prop)+" "+typ)
+		}
+	}
+
+	fmt.Fprintf(b, "func New%s(%s) *%s {\nreturn &%s{\nType: Type%s,\n", typeName, strings.Join(sig, ", "), typeName, typeName, typeName)
+	for _, p := range params {
+		fmt.Fprintf(b, "%s: %s,\n", p.name, paramName(p.prop))
+	}
+	b.WriteString("}\n}\n\n")
+}
+
+func (g *generator) writeEnum(b *bytes.Buffer, name string) {
+	s := g.doc.defs[name]
+	typeName := goName(name)
+	prefix := typeName
+	if p, ok := enumPrefixes[name]; ok {
+		prefix = p
+	}
+
+	writeDoc(b, s.Description)
+	fmt.Fprintf(b, "type %s string\n\nconst (\n", typeName)
+	for _, v := range enumValues(s) {
+		fmt.Fprintf(b, "%s%s %s = %q\n", prefix, exported(v), typeName, v)
+	}
+	b.WriteString(")\n\n")
+}
+
+// writeRegistry writes the maps the polymorphic decoders look element and action types up in
+func (g *generator) writeRegistry(b *bytes.Buffer) {
+	for _, r := range []struct{ def, iface, name, what string }{
+		{"ImplementationsOf.Element", "Element", "elementTypes", "element"},
+		{"ImplementationsOf.Action", "Action", "actionTypes", "action"},
+	} {
+		impls := g.implementations(r.def)
+		sort.Slice(impls, func(i, j int) bool { return goName(impls[i]) < goName(impls[j]) })
+
+		fmt.Fprintf(b, "// %s maps the type discriminator of every known %s to a constructor of its Go type\n", r.name, r.what)
+		fmt.Fprintf(b, "var %s = map[Type]func() %s{\n", r.name, r.iface)
+		for _, name := range impls {
+			fmt.Fprintf(b, "Type%s: func() %s { return &%s{} },\n", goName(name), r.iface, goName(name))
+		}
+		b.WriteString("}\n\n")
+	}
+}
+
+// writeDecoders writes an UnmarshalJSON method for every class with element or action properties, which
+// encoding/json can’t decode into interfaces on its own
+func (g *generator) writeDecoders(b *bytes.Buffer) error {
+	decoders := map[string]string{
+		"[]Element":     "decodeElements",
+		"[]Action":      "decodeActions",
+		"ISelectAction": "decodeSelectAction",
+	}
+
+	for _, name := range g.classes() {
+		typeName := goName(name)
+		if g.handWritten[typeName+".UnmarshalJSON"] {
+			continue
+		}
+		fields, err := g.fields(name)
+		if err != nil {
+			return err
+		}
+
+		var poly []field
+		for _, f := range fields {
+			if _, ok := decoders[f.typ]; ok {
+				poly = append(poly, f)
+			} else if f.typ == "Element" || f.typ == "Action" || f.typ == "[]ISelectAction" {
+				return fmt.Errorf("%s.%s: no decoder for %s", name, f.prop, f.typ)
+			}
+		}
+		if len(poly) == 0 {
+			continue
+		}
+
+		recv := receiver(typeName)
+		fmt.Fprintf(b, "func (%s *%s) UnmarshalJSON(data []byte) error {\ntype alias %s\naux := struct {\n*alias\n", recv, typeName, typeName)
+		for _, f := range poly {
+			raw := "json.RawMessage"
+			if strings.HasPrefix(f.typ, "[]") {
+				raw = "[]json.RawMessage"
+			}
+			tag := f.prop
+			if !f.required {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(b, "%s %s `json:%q`\n", f.name, raw, tag)
+		}
+		fmt.Fprintf(b, "}{alias: (*alias)(%s)}\nif err := json.Unmarshal(data, &aux); err != nil {\nreturn err\n}\n\nvar err error\n", recv)
+		for _, f := range poly {
+			format := f.prop + ": %w"
+			if strings.HasPrefix(f.typ, "[]") {
+				// the slice decoders prefix their errors with the index
+				format = f.prop + "%w"
+			}
+			fmt.Fprintf(b, "if %s.%s, err = %s(aux.%s); err != nil {\nreturn fmt.Errorf(%q, err)\n}\n", recv, f.name, decoders[f.typ], f.name, format)
+		}
+		b.WriteString("\nreturn nil\n}\n\n")
+	}
+
+	return nil
+}
+
+// writeVersions writes the version every type with a discriminator was introduced in, which is the version of the
+// first schema that defines it
+func (g *generator) writeVersions(b *bytes.Buffer) {
+	type entry struct{ name, version string }
+	var entries []entry
+	for _, name := range g.typedDefs() {
+		if name == "AdaptiveCard" {
+			continue
+		}
+		for _, doc := range g.schemas {
+			if _, ok := doc.defs[name]; ok {
+				entries = append(entries, entry{name, doc.version})
+				break
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].version < entries[j].version })
+
+	b.WriteString("// typeVersions holds the schema version every element and action type was introduced in\n")
+	b.WriteString("var typeVersions = map[Type]Version{\n")
+	for _, e := range entries {
+		fmt.Fprintf(b, "Type%s: Version%s,\n", goName(e.name), strings.ReplaceAll(e.version, ".", ""))
+	}
+	b.WriteString("}\n")
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// goName returns the Go name of a definition, e.g. InputText for Input.Text
+func goName(def string) string {
+	return strings.ReplaceAll(def, ".", "")
+}
+
+// fieldName returns the Go name of a property, e.g. ChoicesData for choices.data and Schema for $schema
+func fieldName(prop string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range prop {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+func paramName(prop string) string {
+	name := fieldName(prop)
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// exported returns an enum value as the suffix of its constant, e.g. ExtraLarge for extraLarge
+func exported(value string) string {
+	name := fieldName(value)
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "V" + name
+	}
+
+	return name
+}
+
+func receiver(typeName string) string {
+	return strings.ToLower(typeName[:1])
+}
+
+// comment turns a schema description into a single line comment in the style of the package, which leaves off the
+// final period
+func comment(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if strings.HasSuffix(s, ".") && !strings.HasSuffix(s, "etc.") && !strings.HasSuffix(s, "..") {
+		s = strings.TrimSuffix(s, ".")
+	}
+
+	return s
+}
+
+func writeDoc(b *bytes.Buffer, description string) {
+	if c := comment(description); c != "" {
+		fmt.Fprintf(b, "// %s\n", c)
+	}
+}
@@ -0,0 +1,111 @@
+package teams
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A LintIssue is a part of a card that is valid but likely to be displayed or to behave differently than
+// intended in Teams
+type LintIssue struct {
+	// The location of the issue in the JSON representation of the card as a JSON pointer, e.g. "/body/0/text"
+	Path string
+	// What is wrong at the location
+	Message string
+}
+
+func (i LintIssue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// Lint reports the parts of a valid card that are likely mistakes: markdown Teams doesn’t render, images without
+// alternative text, inputs without a label, actions without a title, ids used more than once and actions that need
+// a bot, which do nothing in cards sent to an incoming webhook. The issues are sorted by path
+func Lint(card *AdaptiveCard) []LintIssue {
+	var issues []LintIssue
+	add := func(path string, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	markdown := func(path string, text string) {
+		for _, issue := range ValidateMarkdown(text) {
+			add(path, "%s", issue)
+		}
+	}
+
+	Inspect(card, func(node interface{}, path string) bool {
+		switch n := node.(type) {
+		case *TextBlock:
+			markdown(path+"/text", n.Text)
+		case *FactSet:
+			for i, f := range n.Facts {
+				markdown(fmt.Sprintf("%s/facts/%d/value", path, i), f.Value)
+			}
+		case *Image:
+			if n.AltText == "" {
+				add(path, "Image has no altText for screen readers")
+			}
+		case *ActionSubmit, *ActionExecute:
+			add(path, "%s needs a bot to handle it and does nothing in cards sent to an incoming webhook", itemType(n))
+		}
+
+		if _, ok := node.(Action); ok && !strings.HasSuffix(path, "/selectAction") && actionTitle(node) == "" {
+			add(path, "%s has no title", itemType(node))
+		}
+		if t := itemType(node); strings.HasPrefix(string(t), "Input.") && inputLabel(node) == "" {
+			add(path, "%s has no label for screen readers", t)
+		}
+
+		return true
+	})
+
+	paths := map[string][]string{}
+	walkIDs(card, func(c *Cursor) {
+		if id := itemId(c.Node()); id != "" {
+			paths[id] = append(paths[id], c.Path())
+		}
+	})
+	for id, p := range paths {
+		if len(p) > 1 {
+			add(p[1], "%s", &DuplicateIDError{ID: id, Paths: p})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+
+	return issues
+}
+
+// actionTitle returns the Title of an action, or its IconUrl if it only shows an icon
+func actionTitle(v interface{}) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, name := range []string{"Title", "IconUrl"} {
+		if f := rv.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+
+	return ""
+}
+
+// inputLabel returns the Label of an input
+func inputLabel(v interface{}) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := rv.FieldByName("Label"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
+
+	return ""
+}
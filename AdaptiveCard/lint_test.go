@@ -0,0 +1,82 @@
+package teams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		card string
+		want []string
+	}{
+		{
+			name: "clean",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[
+				{"type":"TextBlock","text":"**bold** and [link](https://example.com)"},
+				{"type":"Image","url":"https://example.com/a.png","altText":"a"},
+				{"type":"Input.Text","id":"a","label":"A"}
+			],"actions":[{"type":"Action.OpenUrl","title":"Open","url":"https://example.com"}]}`,
+		},
+		{
+			name: "unsupported markdown",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[
+				{"type":"TextBlock","text":"# Title\n~~old~~"},
+				{"type":"FactSet","facts":[{"title":"a","value":"ok"},{"title":"b","value":"<b>x</b>"}]}
+			]}`,
+			want: []string{
+				"/body/0/text: line 1: heading is not supported: # Title",
+				"/body/0/text: line 2: strikethrough is not supported: ~~old~~",
+				"/body/1/facts/1/value: line 1: html is not supported: <b>",
+				"/body/1/facts/1/value: line 1: html is not supported: </b>",
+			},
+		},
+		{
+			name: "accessibility",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[
+				{"type":"ImageSet","images":[{"type":"Image","url":"https://example.com/a.png"}]},
+				{"type":"Input.Toggle","id":"t","title":"Notify"}
+			]}`,
+			want: []string{
+				"/body/0/images/0: Image has no altText for screen readers",
+				"/body/1: Input.Toggle has no label for screen readers",
+			},
+		},
+		{
+			name: "actions",
+			card: `{"type":"AdaptiveCard","version":"1.5","selectAction":{"type":"Action.OpenUrl","url":"https://example.com"},"actions":[
+				{"type":"Action.OpenUrl","url":"https://example.com"},
+				{"type":"Action.OpenUrl","iconUrl":"https://example.com/icon.png","url":"https://example.com"},
+				{"type":"Action.Submit","title":"Send"},
+				{"type":"Action.ShowCard","title":"More","card":{"type":"AdaptiveCard","actions":[{"type":"Action.Execute","verb":"go"}]}}
+			]}`,
+			want: []string{
+				"/actions/0: Action.OpenUrl has no title",
+				"/actions/2: Action.Submit needs a bot to handle it and does nothing in cards sent to an incoming webhook",
+				"/actions/3/card/actions/0: Action.Execute needs a bot to handle it and does nothing in cards sent to an incoming webhook",
+				"/actions/3/card/actions/0: Action.Execute has no title",
+			},
+		},
+		{
+			// fallbacks commonly reuse the id of the item they replace
+			name: "duplicate ids",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[
+				{"type":"TextBlock","id":"a","text":"x","fallback":{"type":"TextBlock","id":"a","text":"old"}},
+				{"type":"Container","items":[{"type":"TextBlock","id":"a","text":"y"}]}
+			]}`,
+			want: []string{`/body/1/items/0: id "a" is used by 2 nodes: /body/0, /body/1/items/0`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range Lint(decodeCard(t, tt.card)) {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
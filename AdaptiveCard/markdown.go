@@ -0,0 +1,288 @@
+package teams
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Teams renders TextBlock.Text and Fact.Value with a subset of markdown: **bold**, _italic_, bulleted and numbered
+// lists and [links](https://example.com). Everything else, like headings, tables, images or HTML, is either shown
+// verbatim or rendered inconsistently across clients.
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-format
+
+// markdownEscaper escapes the characters the markdown renderer of Teams interprets anywhere in a line
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`_`, `\_`,
+	"`", "\\`",
+	`[`, `\[`,
+	`]`, `\]`,
+	`(`, `\(`,
+	`)`, `\)`,
+	`~`, `\~`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`|`, `\|`,
+	`!`, `\!`,
+)
+
+var (
+	// line prefixes which start a block: headings, quotes, bullets and numbered lists
+	markdownBlockPrefix = regexp.MustCompile(`^(\s*)([#+-]|\d+\.)(\s|$)`)
+
+	markdownHeading     = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownTableRow    = regexp.MustCompile(`^\s*\|.*\|\s*$`)
+	markdownTablePipe   = regexp.MustCompile(`(^|[^\\])\|`)
+	markdownTableRule   = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	markdownImage       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]*)(?:\s+"[^"]*")?\)`)
+	markdownHTML        = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9-]*(\s[^<>]*)?/?>`)
+	markdownFence       = regexp.MustCompile("^\\s*(```|~~~)")
+	markdownQuote       = regexp.MustCompile(`^\s*>\s?`)
+	markdownRule        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	markdownStrike      = regexp.MustCompile(`~~([^~]+)~~`)
+	markdownInlineCode  = regexp.MustCompile("`([^`]+)`")
+	markdownUnderscores = regexp.MustCompile(`__([^_]+)__`)
+	markdownEscaped     = regexp.MustCompile(`\\.`)
+)
+
+// EscapeMarkdown escapes s so that it is displayed verbatim when used in TextBlock.Text or Fact.Value, e.g. metric
+// names containing _ and * or user input containing [links](...)
+func EscapeMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = markdownEscaper.Replace(line)
+		// block markers are only interpreted at the start of a line; escape their last character
+		if m := markdownBlockPrefix.FindStringSubmatchIndex(line); m != nil {
+			at := m[5] - 1
+			line = line[:at] + `\` + line[at:]
+		}
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type MarkdownIssueKind string
+
+const (
+	MarkdownIssueHeading    MarkdownIssueKind = "heading"
+	MarkdownIssueTable      MarkdownIssueKind = "table"
+	MarkdownIssueImage      MarkdownIssueKind = "image"
+	MarkdownIssueHTML       MarkdownIssueKind = "html"
+	MarkdownIssueCodeBlock  MarkdownIssueKind = "codeBlock"
+	MarkdownIssueInlineCode MarkdownIssueKind = "inlineCode"
+	MarkdownIssueQuote      MarkdownIssueKind = "blockquote"
+	MarkdownIssueRule       MarkdownIssueKind = "horizontalRule"
+	MarkdownIssueStrike     MarkdownIssueKind = "strikethrough"
+)
+
+// A MarkdownIssue is a markdown construct Teams doesn’t support in TextBlock.Text and Fact.Value
+type MarkdownIssue struct {
+	// The 1-based line the construct was found on
+	Line int
+	// The kind of construct
+	Kind MarkdownIssueKind
+	// The offending text
+	Text string
+}
+
+func (i MarkdownIssue) String() string {
+	return fmt.Sprintf("line %d: %s is not supported: %s", i.Line, i.Kind, i.Text)
+}
+
+// ValidateMarkdown reports the markdown constructs of text that Teams doesn’t support, such as tables, headings,
+// images and HTML
+func ValidateMarkdown(text string) []MarkdownIssue {
+	var issues []MarkdownIssue
+	add := func(line int, kind MarkdownIssueKind, text string) {
+		issues = append(issues, MarkdownIssue{Line: line + 1, Kind: kind, Text: strings.TrimSpace(text)})
+	}
+
+	lines := strings.Split(text, "\n")
+	tables := markdownTables(lines)
+	inFence := false
+	for n, line := range lines {
+		if markdownFence.MatchString(line) {
+			if !inFence {
+				add(n, MarkdownIssueCodeBlock, line)
+			}
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		switch {
+		case markdownHeading.MatchString(line):
+			add(n, MarkdownIssueHeading, line)
+		case tables[n] || markdownTableRow.MatchString(line):
+			add(n, MarkdownIssueTable, line)
+		case markdownQuote.MatchString(line):
+			add(n, MarkdownIssueQuote, line)
+		case markdownRule.MatchString(line):
+			add(n, MarkdownIssueRule, line)
+		}
+
+		// inline constructs are searched in the line without escaped characters
+		unescaped := markdownEscaped.ReplaceAllString(line, "")
+		for _, m := range markdownImage.FindAllString(unescaped, -1) {
+			add(n, MarkdownIssueImage, m)
+		}
+		for _, m := range markdownHTML.FindAllString(unescaped, -1) {
+			add(n, MarkdownIssueHTML, m)
+		}
+		for _, m := range markdownStrike.FindAllString(unescaped, -1) {
+			add(n, MarkdownIssueStrike, m)
+		}
+		for _, m := range markdownInlineCode.FindAllString(unescaped, -1) {
+			add(n, MarkdownIssueInlineCode, m)
+		}
+	}
+
+	return issues
+}
+
+// ConvertMarkdown converts CommonMark to the subset supported by Teams: headings become bold lines, tables become
+// bulleted lists of their rows, images become links, code is kept as escaped plain text and HTML tags, quotes, rules
+// and strikethrough are removed
+func ConvertMarkdown(commonmark string) string {
+	var out []string
+	var header []string
+
+	lines := strings.Split(commonmark, "\n")
+	tables := markdownTables(lines)
+	inFence := false
+	for n, line := range lines {
+		if markdownFence.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, EscapeMarkdown(line))
+			continue
+		}
+
+		if tables[n] {
+			cells := splitTableRow(line)
+			switch {
+			case markdownTableRule.MatchString(line):
+				// the rule separating the header from the body
+			case header == nil:
+				header = cells
+			default:
+				out = append(out, "- "+tableRowText(header, cells))
+			}
+			continue
+		}
+		header = nil
+
+		if m := markdownHeading.FindStringSubmatch(line); m != nil {
+			out = append(out, "**"+convertInline(m[2])+"**")
+			continue
+		}
+		if markdownRule.MatchString(line) {
+			out = append(out, "")
+			continue
+		}
+		line = markdownQuote.ReplaceAllString(line, "")
+
+		out = append(out, convertInline(line))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// markdownTables reports which lines belong to a GFM table: a header row followed by a delimiter row with the same
+// number of cells, then the rows up to the first line without a |. The pipes at the start and end of the rows are
+// optional
+func markdownTables(lines []string) []bool {
+	tables := make([]bool, len(lines))
+
+	inFence := false
+	for n := 0; n < len(lines); n++ {
+		if markdownFence.MatchString(lines[n]) {
+			inFence = !inFence
+			continue
+		}
+		if inFence || n+1 >= len(lines) || !isTableRow(lines[n]) {
+			continue
+		}
+		rule := lines[n+1]
+		if !markdownTableRule.MatchString(rule) || len(splitTableRow(rule)) != len(splitTableRow(lines[n])) {
+			continue
+		}
+
+		tables[n], tables[n+1] = true, true
+		for n += 2; n < len(lines) && isTableRow(lines[n]) && !markdownFence.MatchString(lines[n]); n++ {
+			tables[n] = true
+		}
+		n--
+	}
+
+	return tables
+}
+
+// isTableRow reports whether the line contains an unescaped | outside of code spans
+func isTableRow(line string) bool {
+	return markdownTablePipe.MatchString(markdownInlineCode.ReplaceAllString(line, ""))
+}
+
+func convertInline(line string) string {
+	// the content of code spans is kept verbatim, the rest of the line is converted
+	var b strings.Builder
+	last := 0
+	for _, m := range markdownInlineCode.FindAllStringSubmatchIndex(line, -1) {
+		b.WriteString(convertText(line[last:m[0]]))
+		b.WriteString(EscapeMarkdown(line[m[2]:m[3]]))
+		last = m[1]
+	}
+	b.WriteString(convertText(line[last:]))
+
+	return b.String()
+}
+
+func convertText(text string) string {
+	text = markdownImage.ReplaceAllStringFunc(text, func(m string) string {
+		parts := markdownImage.FindStringSubmatch(m)
+		alt := parts[1]
+		if alt == "" {
+			alt = parts[2]
+		}
+		return "[" + alt + "](" + parts[2] + ")"
+	})
+	text = markdownHTML.ReplaceAllString(text, "")
+	text = markdownStrike.ReplaceAllString(text, "$1")
+	text = markdownUnderscores.ReplaceAllString(text, "**$1**")
+
+	return text
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = convertInline(strings.TrimSpace(cell))
+	}
+
+	return cells
+}
+
+func tableRowText(header []string, cells []string) string {
+	parts := make([]string, 0, len(cells))
+	for i, cell := range cells {
+		if i < len(header) && header[i] != "" {
+			parts = append(parts, "**"+header[i]+":** "+cell)
+		} else {
+			parts = append(parts, cell)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
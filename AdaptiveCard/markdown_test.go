@@ -0,0 +1,119 @@
+package teams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "CPU usage", "CPU usage"},
+		{"emphasis", "cpu_usage_total * 2", `cpu\_usage\_total \* 2`},
+		{"link", "[docs](https://example.com)", `\[docs\]\(https://example.com\)`},
+		{"code and html", "`x` <b>", "\\`x\\` \\<b\\>"},
+		{"backslash", `C:\temp`, `C:\\temp`},
+		{"table and strike", "a | ~~b~~ !", `a \| \~\~b\~\~ \!`},
+		{"bullet", "- item", `\- item`},
+		{"indented bullet", "  + item", `  \+ item`},
+		{"numbered list", "1. first", `1\. first`},
+		{"heading", "# title", `\# title`},
+		{"marker in the middle", "a - b # c 1. d", "a - b # c 1. d"},
+		{"lines", "- a\n2. b", "\\- a\n2\\. b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMarkdown(tt.in); got != tt.want {
+				t.Errorf("EscapeMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []MarkdownIssue
+	}{
+		{name: "supported", in: "**bold** _italic_\n- item\n1. item\n[link](https://example.com)"},
+		{name: "heading", in: "## Title", want: []MarkdownIssue{{1, MarkdownIssueHeading, "## Title"}}},
+		{
+			name: "table",
+			in:   "| a | b |\n|---|---|\n| 1 | 2 |",
+			want: []MarkdownIssue{{1, MarkdownIssueTable, "| a | b |"}, {2, MarkdownIssueTable, "|---|---|"}, {3, MarkdownIssueTable, "| 1 | 2 |"}},
+		},
+		{
+			name: "table without leading pipes",
+			in:   "a | b\n--- | ---\n1 | 2\n\nafter",
+			want: []MarkdownIssue{{1, MarkdownIssueTable, "a | b"}, {2, MarkdownIssueTable, "--- | ---"}, {3, MarkdownIssueTable, "1 | 2"}},
+		},
+		{name: "pipe without delimiter row", in: "a | b\nc"},
+		{name: "image", in: "see ![graph](https://example.com/a.png)", want: []MarkdownIssue{{1, MarkdownIssueImage, "![graph](https://example.com/a.png)"}}},
+		{name: "html", in: "a<br/>b", want: []MarkdownIssue{{1, MarkdownIssueHTML, "<br/>"}}},
+		{name: "strike", in: "~~old~~", want: []MarkdownIssue{{1, MarkdownIssueStrike, "~~old~~"}}},
+		{name: "inline code", in: "run `make`", want: []MarkdownIssue{{1, MarkdownIssueInlineCode, "`make`"}}},
+		{name: "quote", in: "> quoted", want: []MarkdownIssue{{1, MarkdownIssueQuote, "> quoted"}}},
+		{name: "rule", in: "a\n***", want: []MarkdownIssue{{2, MarkdownIssueRule, "***"}}},
+		{
+			name: "code block content is not checked",
+			in:   "```go\n# not a heading\n| a | b |\n```",
+			want: []MarkdownIssue{{1, MarkdownIssueCodeBlock, "```go"}},
+		},
+		{name: "escaped", in: `\~~a\~~ \<b\> \![x](y)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateMarkdown(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateMarkdown(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"supported is kept", "**bold** _italic_\n- item\n1. item", "**bold** _italic_\n- item\n1. item"},
+		{"link", "[docs](https://example.com)", "[docs](https://example.com)"},
+		{"heading", "## Title ##", "**Title**"},
+		{"image", "![graph](https://example.com/a.png) ![](https://example.com/b.png)", "[graph](https://example.com/a.png) [https://example.com/b.png](https://example.com/b.png)"},
+		{"html", "a<br/>b <span class=\"x\">c</span>", "ab c"},
+		{"strike and underscores", "~~old~~ __strong__", "old **strong**"},
+		{"quote", "> quoted", "quoted"},
+		{"rule", "a\n---\nb", "a\n\nb"},
+		{"inline code", "run `make_all *x*`", `run make\_all \*x\*`},
+		{"inline code is not converted", "`<b>` and `~~x~~`", `\<b\> and \~\~x\~\~`},
+		{"fenced code", "```\n# not a heading\n- [x](y)\n```\nafter", "\\# not a heading\n\\- \\[x\\]\\(y\\)\nafter"},
+		{
+			name: "table",
+			in:   "| Name | Value |\n|:-----|------:|\n| cpu | `9_0` |\n| mem | 10 |",
+			want: "- **Name:** cpu, **Value:** 9\\_0\n- **Name:** mem, **Value:** 10",
+		},
+		{
+			name: "table without leading pipes",
+			in:   "Name | Value\n--- | ---\ncpu | 90\n\nafter",
+			want: "- **Name:** cpu, **Value:** 90\n\nafter",
+		},
+		{
+			name: "table ended by a line without pipes",
+			in:   "a | b\n--- | ---\n1 | 2\ntext",
+			want: "- **a:** 1, **b:** 2\ntext",
+		},
+		{"pipe without delimiter row", "a | b", "a | b"},
+		{"delimiter row with another cell count", "a | b\n---", "a | b\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertMarkdown(tt.in); got != tt.want {
+				t.Errorf("ConvertMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,22 @@
 package teams
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
 const (
 	TypeMessage Type = "Message"
 )
 
+// ContentTypeAdaptiveCard is the content type of attachments holding an AdaptiveCard
+const ContentTypeAdaptiveCard = "application/vnd.microsoft.card.adaptive"
+
+// the longest summary shown in notifications and the activity feed
+const maxSummaryLength = 80
+
 type Card interface {
 	IsCard() bool
 }
@@ -13,12 +26,100 @@ func (a *AdaptiveCard) IsCard() bool {
 }
 
 type Message struct {
-	Type        Type         `json:"type"`
-	Attachments []Attachment `json:"attachments"`
+	Type Type `json:"type"`
+	// Text of a message without attachments, e.g. the reply to an outgoing webhook. May contain markdown
+	Text string `json:"text,omitempty"`
+	// Text shown in notifications and the activity feed instead of the card
+	Summary     string       `json:"summary,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
 type Attachment struct {
 	ContentType string `json:"contentType"`
 	ContentUrl  string `json:"contentUrl"`
 	Content     Card   `json:"content"`
+	// Name of the attachment, e.g. the file name of a file
+	Name string `json:"name,omitempty"`
+}
+
+// UnmarshalJSON decodes the content of attachments of type ContentTypeAdaptiveCard as *AdaptiveCard and the content
+// of other types, like hero cards or files, as RawCard
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type alias Attachment
+	aux := struct {
+		*alias
+		Content json.RawMessage `json:"content"`
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	a.Content = nil
+	switch {
+	case len(aux.Content) == 0 || string(aux.Content) == "null":
+	case a.ContentType == ContentTypeAdaptiveCard:
+		card := &AdaptiveCard{}
+		if err := json.Unmarshal(aux.Content, card); err != nil {
+			return fmt.Errorf("content: %w", err)
+		}
+		a.Content = card
+	default:
+		a.Content = RawCard(aux.Content)
+	}
+
+	return nil
+}
+
+// RawCard is the JSON of the content of an attachment that isn’t an AdaptiveCard
+type RawCard json.RawMessage
+
+func (r RawCard) IsCard() bool {
+	return true
+}
+
+func (r RawCard) MarshalJSON() ([]byte, error) {
+	if len(r) == 0 {
+		return []byte("null"), nil
+	}
+	return r, nil
+}
+
+// NewMessage wraps the card in a message. The summary of the message is the first line of the plain-text rendering of
+// the card, and the card’s FallbackText defaults to the whole rendering. The given card is not modified
+func NewMessage(card *AdaptiveCard) *Message {
+	text := RenderText(card)
+
+	if card.FallbackText == "" && text != "" {
+		c := *card
+		c.FallbackText = text
+		card = &c
+	}
+
+	return &Message{
+		Type:    "message",
+		Summary: summarize(text),
+		Attachments: []Attachment{
+			{
+				ContentType: ContentTypeAdaptiveCard,
+				ContentUrl:  "",
+				Content:     card,
+			},
+		},
+	}
+}
+
+// summarize returns the first non-empty line of text, shortened to maxSummaryLength characters
+func summarize(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if utf8.RuneCountInString(line) > maxSummaryLength {
+			line = string([]rune(line)[:maxSummaryLength-1]) + "…"
+		}
+		return line
+	}
+
+	return ""
 }
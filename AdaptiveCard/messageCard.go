@@ -0,0 +1,389 @@
+package teams
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The MessageCard is the legacy card format of Office 365 connectors, still accepted by incoming webhooks. It can be
+// sent as it is with Webhook.Send, or converted to an AdaptiveCard with ToAdaptiveCard.
+
+const (
+	TypeMessageCard Type = "MessageCard"
+
+	MessageCardContext = "http://schema.org/extensions"
+)
+
+// The types of the potential actions of a MessageCard
+const (
+	MessageCardActionOpenUri            Type = "OpenUri"
+	MessageCardActionHttpPOST           Type = "HttpPOST"
+	MessageCardActionActionCard         Type = "ActionCard"
+	MessageCardActionInvokeAddInCommand Type = "InvokeAddInCommand"
+)
+
+// The types of the inputs of an ActionCard action
+const (
+	MessageCardInputText        Type = "TextInput"
+	MessageCardInputDate        Type = "DateInput"
+	MessageCardInputMultichoice Type = "MultichoiceInput"
+)
+
+// A legacy actionable message card
+//
+// Source: https://learn.microsoft.com/en-us/outlook/actionable-messages/message-card-reference
+type MessageCard struct {
+	// Must be TypeMessageCard ("MessageCard")
+	Type Type `json:"@type"`
+	// Must be MessageCardContext
+	Context string `json:"@context,omitempty"`
+	// Text shown in notifications instead of the card
+	Summary string `json:"summary,omitempty"`
+	// Accent color of the card as a hex value, e.g. "0076D7"
+	ThemeColor string `json:"themeColor,omitempty"`
+	// Title of the card
+	Title string `json:"title,omitempty"`
+	// Text of the card, may contain markdown
+	Text string `json:"text,omitempty"`
+	// Sections of the card
+	Sections []MessageCardSection `json:"sections,omitempty"`
+	// Actions of the card
+	PotentialAction []MessageCardAction `json:"potentialAction,omitempty"`
+}
+
+func (m *MessageCard) IsCard() bool {
+	return true
+}
+
+// A section of a MessageCard
+type MessageCardSection struct {
+	// Title of the section
+	Title string `json:"title,omitempty"`
+	// If true, the section is separated from the previous one
+	StartGroup bool `json:"startGroup,omitempty"`
+	// Image shown next to the activity, usually the avatar of a person
+	ActivityImage string `json:"activityImage,omitempty"`
+	// Title of the activity, usually the name of a person
+	ActivityTitle string `json:"activityTitle,omitempty"`
+	// Subtitle of the activity, usually a date
+	ActivitySubtitle string `json:"activitySubtitle,omitempty"`
+	// Text of the activity
+	ActivityText string `json:"activityText,omitempty"`
+	// Large image shown in the section
+	HeroImage *MessageCardImage `json:"heroImage,omitempty"`
+	// Text of the section
+	Text string `json:"text,omitempty"`
+	// Facts of the section
+	Facts []MessageCardFact `json:"facts,omitempty"`
+	// Images of the section, shown as a gallery
+	Images []MessageCardImage `json:"images,omitempty"`
+	// Actions of the section
+	PotentialAction []MessageCardAction `json:"potentialAction,omitempty"`
+	// If false, markdown in the texts of the section is not rendered. Defaults to true
+	Markdown *bool `json:"markdown,omitempty"`
+}
+
+// A fact of a MessageCardSection
+type MessageCardFact struct {
+	// Name of the fact
+	Name string `json:"name"`
+	// Value of the fact, may contain markdown
+	Value string `json:"value"`
+}
+
+// An image of a MessageCardSection
+type MessageCardImage struct {
+	// URL of the image
+	Image string `json:"image"`
+	// Alternative text of the image
+	Title string `json:"title,omitempty"`
+}
+
+// A potential action of a MessageCard or MessageCardSection. Which fields apply depends on the type
+type MessageCardAction struct {
+	// Type of the action, e.g. MessageCardActionOpenUri
+	Type Type `json:"@type"`
+	// Title of the button of the action
+	Name string `json:"name"`
+	// The URLs to open per operating system, for OpenUri
+	Targets []MessageCardTarget `json:"targets,omitempty"`
+	// The URL to post to, for HttpPOST
+	Target string `json:"target,omitempty"`
+	// The body to post, for HttpPOST
+	Body string `json:"body,omitempty"`
+	// The content type of the body, for HttpPOST
+	BodyContentType string `json:"bodyContentType,omitempty"`
+	// The inputs of the card shown by an ActionCard
+	Inputs []MessageCardInput `json:"inputs,omitempty"`
+	// The actions of the card shown by an ActionCard
+	Actions []MessageCardAction `json:"actions,omitempty"`
+}
+
+// A target of an OpenUri action
+type MessageCardTarget struct {
+	// The operating system the URI is for: default, iOS, android or windows
+	OS string `json:"os"`
+	// The URI to open
+	Uri string `json:"uri"`
+}
+
+// An input of an ActionCard action. Which fields apply depends on the type
+type MessageCardInput struct {
+	// Type of the input, e.g. MessageCardInputText
+	Type Type `json:"@type"`
+	// Id of the input, referenced by the actions as {{id.value}}
+	Id string `json:"id"`
+	// Title of the input, shown as its placeholder
+	Title string `json:"title,omitempty"`
+	// Whether a value is required
+	IsRequired bool `json:"isRequired,omitempty"`
+	// The initial value
+	Value string `json:"value,omitempty"`
+	// If true, a TextInput allows multiple lines
+	IsMultiline bool `json:"isMultiline,omitempty"`
+	// The maximum length of the value of a TextInput
+	MaxLength int `json:"maxLength,omitempty"`
+	// If true, a DateInput also asks for the time
+	IncludeTime bool `json:"includeTime,omitempty"`
+	// The choices of a MultichoiceInput
+	Choices []MessageCardChoice `json:"choices,omitempty"`
+	// If true, several choices of a MultichoiceInput can be selected
+	IsMultiSelect bool `json:"isMultiSelect,omitempty"`
+	// Style of a MultichoiceInput: normal or expanded
+	Style string `json:"style,omitempty"`
+}
+
+// A choice of a MultichoiceInput
+type MessageCardChoice struct {
+	// The text shown for the choice
+	Display string `json:"display"`
+	// The value of the choice
+	Value string `json:"value"`
+}
+
+// ToAdaptiveCard converts the MessageCard to an AdaptiveCard with the same content: sections become containers,
+// activities become columns next to their image, OpenUri becomes Action.OpenUrl and ActionCard becomes
+// Action.ShowCard. The returned notes describe what couldn’t be converted, like HttpPOST actions, which need a bot
+// to be replaced by Action.Execute
+func (m *MessageCard) ToAdaptiveCard() (*AdaptiveCard, []string) {
+	c := &messageCardConverter{}
+	card := NewAdaptiveCard()
+
+	if m.ThemeColor != "" {
+		c.note("themeColor %q has no equivalent", m.ThemeColor)
+	}
+	if m.Title != "" {
+		title := NewTextBlock(m.Title)
+		title.Size = FontSizeLarge
+		title.Weight = FontWeightBolder
+		title.Wrap = true
+		card.Body = append(card.Body, title)
+	}
+	if m.Text != "" {
+		text := NewTextBlock(m.Text)
+		text.Wrap = true
+		card.Body = append(card.Body, text)
+	}
+	for i, s := range m.Sections {
+		card.Body = append(card.Body, c.section(fmt.Sprintf("sections[%d]", i), s))
+	}
+	card.Actions = c.actions("potentialAction", m.PotentialAction)
+
+	return card, c.notes
+}
+
+type messageCardConverter struct {
+	notes []string
+}
+
+func (c *messageCardConverter) note(format string, args ...interface{}) {
+	c.notes = append(c.notes, fmt.Sprintf(format, args...))
+}
+
+func (c *messageCardConverter) section(path string, s MessageCardSection) *Container {
+	container := NewContainer()
+	container.Items = []Element{}
+	container.Separator = s.StartGroup
+	text := func(s string) string {
+		return s
+	}
+	if s.Markdown != nil && !*s.Markdown {
+		// TextBlocks always render markdown
+		text = EscapeMarkdown
+	}
+
+	if s.Title != "" {
+		title := NewTextBlock(text(s.Title))
+		title.Size = FontSizeMedium
+		title.Weight = FontWeightBolder
+		title.Wrap = true
+		container.Items = append(container.Items, title)
+	}
+	if s.ActivityTitle != "" || s.ActivitySubtitle != "" || s.ActivityText != "" || s.ActivityImage != "" {
+		container.Items = append(container.Items, c.activity(s, text))
+	}
+	if s.HeroImage != nil && s.HeroImage.Image != "" {
+		hero := NewImage(s.HeroImage.Image)
+		hero.AltText = s.HeroImage.Title
+		hero.Size = ImageSizeStretch
+		container.Items = append(container.Items, hero)
+	}
+	if s.Text != "" {
+		tb := NewTextBlock(text(s.Text))
+		tb.Wrap = true
+		container.Items = append(container.Items, tb)
+	}
+	if len(s.Facts) > 0 {
+		facts := NewFactSet()
+		for _, f := range s.Facts {
+			facts.Facts = append(facts.Facts, Fact{Title: f.Name, Value: text(f.Value)})
+		}
+		container.Items = append(container.Items, facts)
+	}
+	if len(s.Images) > 0 {
+		images := NewImageSet()
+		for _, img := range s.Images {
+			image := NewImage(img.Image)
+			image.AltText = img.Title
+			images.Images = append(images.Images, *image)
+		}
+		container.Items = append(container.Items, images)
+	}
+	if actions := c.actions(path+".potentialAction", s.PotentialAction); len(actions) > 0 {
+		container.Items = append(container.Items, NewActionSet(actions...))
+	}
+
+	return container
+}
+
+// activity lays out the activity of a section like Teams does: the image in a narrow column next to the title,
+// subtitle and text
+func (c *messageCardConverter) activity(s MessageCardSection, text func(string) string) Element {
+	var items []Element
+	if s.ActivityTitle != "" {
+		title := NewTextBlock(text(s.ActivityTitle))
+		title.Weight = FontWeightBolder
+		title.Wrap = true
+		items = append(items, title)
+	}
+	if s.ActivitySubtitle != "" {
+		subtitle := NewTextBlock(text(s.ActivitySubtitle))
+		subtitle.IsSubtle = true
+		subtitle.Spacing = SpacingNone
+		subtitle.Wrap = true
+		items = append(items, subtitle)
+	}
+	if s.ActivityText != "" {
+		tb := NewTextBlock(text(s.ActivityText))
+		tb.Wrap = true
+		items = append(items, tb)
+	}
+	if s.ActivityImage == "" {
+		return NewContainer(items...)
+	}
+
+	image := NewImage(s.ActivityImage)
+	image.Style = ImageStylePerson
+	image.Size = ImageSizeSmall
+	imageColumn := NewColumn()
+	imageColumn.Width = "auto"
+	imageColumn.Items = []Element{image}
+	textColumn := NewColumn()
+	textColumn.Width = "stretch"
+	textColumn.Items = items
+	textColumn.VerticalContentAlignment = VerticalContentAlignmentCenter
+
+	columns := NewColumnSet()
+	columns.Columns = []Column{*imageColumn, *textColumn}
+
+	return columns
+}
+
+func (c *messageCardConverter) actions(path string, actions []MessageCardAction) []Action {
+	var out []Action
+	for i, a := range actions {
+		if converted := c.action(fmt.Sprintf("%s[%d]", path, i), a); converted != nil {
+			out = append(out, converted)
+		}
+	}
+
+	return out
+}
+
+func (c *messageCardConverter) action(path string, a MessageCardAction) Action {
+	switch a.Type {
+	case MessageCardActionOpenUri:
+		uri := ""
+		for _, t := range a.Targets {
+			if uri == "" || strings.EqualFold(t.OS, "default") {
+				uri = t.Uri
+			}
+		}
+		if uri == "" {
+			c.note("%s: OpenUri %q has no target and was dropped", path, a.Name)
+			return nil
+		}
+		if len(a.Targets) > 1 {
+			c.note("%s: OpenUri %q has targets per operating system, only %s is kept", path, a.Name, uri)
+		}
+		open := NewActionOpenUrl()
+		open.Title = a.Name
+		open.Url = uri
+		return open
+	case MessageCardActionActionCard:
+		show := NewActionShowCard()
+		show.Title = a.Name
+		show.Card = AdaptiveCard{Type: TypeAdaptiveCard}
+		for j, input := range a.Inputs {
+			if el := c.input(fmt.Sprintf("%s.inputs[%d]", path, j), input); el != nil {
+				show.Card.Body = append(show.Card.Body, el)
+			}
+		}
+		show.Card.Actions = c.actions(path+".actions", a.Actions)
+		return show
+	case MessageCardActionHttpPOST:
+		c.note("%s: HttpPOST %q was dropped; Adaptive Cards need a bot and Action.Execute to post data", path, a.Name)
+	default:
+		c.note("%s: %s %q was dropped, it has no equivalent", path, a.Type, a.Name)
+	}
+
+	return nil
+}
+
+func (c *messageCardConverter) input(path string, in MessageCardInput) Element {
+	switch in.Type {
+	case MessageCardInputText:
+		text := NewInputText(in.Id)
+		text.Placeholder = in.Title
+		text.IsRequired = in.IsRequired
+		text.Value = in.Value
+		text.IsMultiline = in.IsMultiline
+		text.MaxLength = in.MaxLength
+		return text
+	case MessageCardInputDate:
+		date := NewInputDate(in.Id)
+		date.Placeholder = in.Title
+		date.IsRequired = in.IsRequired
+		date.Value = in.Value
+		if in.IncludeTime {
+			c.note("%s: the time of DateInput %q was dropped, Input.Date only asks for the date", path, in.Id)
+		}
+		return date
+	case MessageCardInputMultichoice:
+		choices := NewInputChoiceSet(in.Id)
+		choices.Placeholder = in.Title
+		choices.IsRequired = in.IsRequired
+		choices.Value = in.Value
+		choices.IsMultiSelect = in.IsMultiSelect
+		if in.Style == "expanded" {
+			choices.Style = ChoiceInputStyleExpanded
+		}
+		for _, choice := range in.Choices {
+			choices.Choices = append(choices.Choices, InputChoice{Title: choice.Display, Value: choice.Value})
+		}
+		return choices
+	}
+
+	c.note("%s: input type %s of %q was dropped, it has no equivalent", path, in.Type, in.Id)
+	return nil
+}
@@ -0,0 +1,148 @@
+package teams
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// jsonEqual reports whether the JSON encoding of v is equal to want, ignoring the order of the properties
+func jsonEqual(t *testing.T, v interface{}, want string) bool {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, wanted interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wanted); err != nil {
+		t.Fatal(err)
+	}
+
+	return reflect.DeepEqual(got, wanted)
+}
+
+func TestMessageCardToAdaptiveCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    string
+		body    string
+		actions string
+		notes   []string
+	}{
+		{
+			name:  "title and text",
+			card:  `{"@type":"MessageCard","themeColor":"0076D7","title":"Build failed","text":"See **logs**"}`,
+			body:  `[{"type":"TextBlock","text":"Build failed","size":"large","weight":"bolder","wrap":true},{"type":"TextBlock","text":"See **logs**","wrap":true}]`,
+			notes: []string{`themeColor "0076D7" has no equivalent`},
+		},
+		{
+			name: "section title, text and facts",
+			card: `{"@type":"MessageCard","sections":[{"title":"Details","startGroup":true,"text":"a_b","facts":[{"name":"Env","value":"prod"}]}]}`,
+			body: `[{"type":"Container","separator":true,"items":[
+				{"type":"TextBlock","text":"Details","size":"medium","weight":"bolder","wrap":true},
+				{"type":"TextBlock","text":"a_b","wrap":true},
+				{"type":"FactSet","facts":[{"title":"Env","value":"prod"}]}]}]`,
+		},
+		{
+			name: "section without markdown",
+			card: `{"@type":"MessageCard","sections":[{"title":"*t*","text":"a_b","facts":[{"name":"n_1","value":"[x]"}],"markdown":false}]}`,
+			body: `[{"type":"Container","items":[
+				{"type":"TextBlock","text":"\\*t\\*","size":"medium","weight":"bolder","wrap":true},
+				{"type":"TextBlock","text":"a\\_b","wrap":true},
+				{"type":"FactSet","facts":[{"title":"n_1","value":"\\[x\\]"}]}]}]`,
+		},
+		{
+			name: "activity with image",
+			card: `{"@type":"MessageCard","sections":[{"activityImage":"https://example.com/ada.png","activityTitle":"Ada","activitySubtitle":"today","activityText":"deployed"}]}`,
+			body: `[{"type":"Container","items":[{"type":"ColumnSet","columns":[
+				{"type":"Column","width":"auto","items":[{"type":"Image","url":"https://example.com/ada.png","style":"person","size":"small"}]},
+				{"type":"Column","width":"stretch","verticalContentAlignment":"center","items":[
+					{"type":"TextBlock","text":"Ada","weight":"bolder","wrap":true},
+					{"type":"TextBlock","text":"today","isSubtle":true,"spacing":"none","wrap":true},
+					{"type":"TextBlock","text":"deployed","wrap":true}]}]}]}]`,
+		},
+		{
+			name: "activity without image",
+			card: `{"@type":"MessageCard","sections":[{"activityTitle":"Ada"}]}`,
+			body: `[{"type":"Container","items":[{"type":"Container","items":[{"type":"TextBlock","text":"Ada","weight":"bolder","wrap":true}]}]}]`,
+		},
+		{
+			name: "hero image and images",
+			card: `{"@type":"MessageCard","sections":[{"heroImage":{"image":"https://example.com/hero.png","title":"hero"},"images":[{"image":"https://example.com/a.png","title":"a"}]}]}`,
+			body: `[{"type":"Container","items":[
+				{"type":"Image","url":"https://example.com/hero.png","altText":"hero","size":"stretch"},
+				{"type":"ImageSet","images":[{"type":"Image","url":"https://example.com/a.png","altText":"a"}]}]}]`,
+		},
+		{
+			name: "section actions",
+			card: `{"@type":"MessageCard","sections":[{"potentialAction":[{"@type":"OpenUri","name":"Open","targets":[{"os":"default","uri":"https://example.com"}]}]}]}`,
+			body: `[{"type":"Container","items":[{"type":"ActionSet","actions":[{"type":"Action.OpenUrl","title":"Open","url":"https://example.com"}]}]}]`,
+		},
+		{
+			name:    "OpenUri with targets per operating system",
+			card:    `{"@type":"MessageCard","potentialAction":[{"@type":"OpenUri","name":"Open","targets":[{"os":"iOS","uri":"https://example.com/ios"},{"os":"default","uri":"https://example.com"}]}]}`,
+			actions: `[{"type":"Action.OpenUrl","title":"Open","url":"https://example.com"}]`,
+			notes:   []string{`potentialAction[0]: OpenUri "Open" has targets per operating system, only https://example.com is kept`},
+		},
+		{
+			name:  "OpenUri without target",
+			card:  `{"@type":"MessageCard","potentialAction":[{"@type":"OpenUri","name":"Open"}]}`,
+			notes: []string{`potentialAction[0]: OpenUri "Open" has no target and was dropped`},
+		},
+		{
+			name: "ActionCard",
+			card: `{"@type":"MessageCard","potentialAction":[{"@type":"ActionCard","name":"Comment","inputs":[
+				{"@type":"TextInput","id":"comment","title":"Comment","isRequired":true,"isMultiline":true,"maxLength":100},
+				{"@type":"DateInput","id":"due","title":"Due","value":"2024-01-02","includeTime":true},
+				{"@type":"MultichoiceInput","id":"list","title":"List","isMultiSelect":true,"style":"expanded","choices":[{"display":"One","value":"1"}]},
+				{"@type":"ToggleInput","id":"x"}
+			],"actions":[{"@type":"HttpPOST","name":"Save","target":"https://example.com/save"}]}]}`,
+			actions: `[{"type":"Action.ShowCard","title":"Comment","card":{"type":"AdaptiveCard","body":[
+				{"type":"Input.Text","id":"comment","placeholder":"Comment","isRequired":true,"isMultiline":true,"maxLength":100},
+				{"type":"Input.Date","id":"due","placeholder":"Due","value":"2024-01-02"},
+				{"type":"Input.ChoiceSet","id":"list","placeholder":"List","isMultiSelect":true,"style":"expanded","choices":[{"title":"One","value":"1"}]}]}}]`,
+			notes: []string{
+				`potentialAction[0].inputs[1]: the time of DateInput "due" was dropped, Input.Date only asks for the date`,
+				`potentialAction[0].inputs[3]: input type ToggleInput of "x" was dropped, it has no equivalent`,
+				`potentialAction[0].actions[0]: HttpPOST "Save" was dropped; Adaptive Cards need a bot and Action.Execute to post data`,
+			},
+		},
+		{
+			name:  "unknown action",
+			card:  `{"@type":"MessageCard","sections":[{},{"potentialAction":[{"@type":"InvokeAddInCommand","name":"Add-in"}]}]}`,
+			body:  `[{"type":"Container","items":[]},{"type":"Container","items":[]}]`,
+			notes: []string{`sections[1].potentialAction[0]: InvokeAddInCommand "Add-in" was dropped, it has no equivalent`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m MessageCard
+			if err := json.Unmarshal([]byte(tt.card), &m); err != nil {
+				t.Fatal(err)
+			}
+			card, notes := m.ToAdaptiveCard()
+
+			if tt.body == "" {
+				tt.body = "null"
+			}
+			if !jsonEqual(t, card.Body, tt.body) {
+				data, _ := json.Marshal(card.Body)
+				t.Errorf("body = %s, want %s", data, tt.body)
+			}
+			if tt.actions == "" {
+				tt.actions = "null"
+			}
+			if !jsonEqual(t, card.Actions, tt.actions) {
+				data, _ := json.Marshal(card.Actions)
+				t.Errorf("actions = %s, want %s", data, tt.actions)
+			}
+			if !reflect.DeepEqual(notes, tt.notes) {
+				t.Errorf("notes = %q, want %q", notes, tt.notes)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+)
+
+// With the refresh of Universal Actions, Teams replaces the card with the one returned by the bot for the
+// Action.Execute of the refresh whenever one of the listed users views it, so every user can see a personal view,
+// e.g. approve buttons only for approvers. Other users can refresh the card manually.
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/universal-actions-for-adaptive-cards/user-specific-views
+
+// MaxRefreshUserIds is the most users Teams refreshes a card automatically for
+const MaxRefreshUserIds = 60
+
+// NewRefresh returns a Refresh running the action automatically for the given users, identified by their Teams
+// user id ("29:…") or Azure Active Directory object id
+func NewRefresh(action *ActionExecute, userIds ...string) *Refresh {
+	return &Refresh{
+		Action:  action,
+		UserIds: userIds,
+	}
+}
+
+func (r *Refresh) validate() error {
+	if r.Action == nil {
+		return errors.New("action is required")
+	}
+	if err := validateItem(r.Action); err != nil {
+		return fmt.Errorf("action: %w", err)
+	}
+	if r.Action.Verb == "" {
+		return errors.New("action: Verb is required to tell the refresh apart from other actions")
+	}
+	if len(r.UserIds) > MaxRefreshUserIds {
+		return fmt.Errorf("userIds has %d users; Teams refreshes cards automatically for at most %d users", len(r.UserIds), MaxRefreshUserIds)
+	}
+	for i, id := range r.UserIds {
+		if id == "" {
+			return fmt.Errorf("userIds[%d] is empty", i)
+		}
+	}
+
+	return nil
+}
+
+// validateRefresh checks the refresh of the card and that the card’s version supports it
+func (a *AdaptiveCard) validateRefresh() error {
+	if a.Refresh == nil {
+		return nil
+	}
+	if err := a.Refresh.validate(); err != nil {
+		return fmt.Errorf("refresh: %w", err)
+	}
+	if a.Version != "" && a.Version.Compare(Version14) < 0 {
+		return fmt.Errorf("refresh: Universal Actions require version %s but the card declares %s", Version14, a.Version)
+	}
+
+	return nil
+}
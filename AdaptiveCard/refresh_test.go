@@ -0,0 +1,54 @@
+package teams
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateRefresh(t *testing.T) {
+	execute := func(verb string) *ActionExecute {
+		action := NewActionExecute()
+		action.Verb = verb
+		return action
+	}
+	users := func(n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("29:user%d", i)
+		}
+		return ids
+	}
+	tests := []struct {
+		name    string
+		version Version
+		refresh *Refresh
+		want    string
+	}{
+		{"no users", Version14, NewRefresh(execute("refresh")), ""},
+		{"most users", Version14, NewRefresh(execute("refresh"), users(MaxRefreshUserIds)...), ""},
+		{"too many users", Version14, NewRefresh(execute("refresh"), users(MaxRefreshUserIds+1)...),
+			"refresh: userIds has 61 users; Teams refreshes cards automatically for at most 60 users"},
+		{"empty user", Version14, NewRefresh(execute("refresh"), "29:a", ""), "refresh: userIds[1] is empty"},
+		{"no action", Version14, &Refresh{UserIds: []string{"29:a"}}, "refresh: action is required"},
+		{"no verb", Version14, NewRefresh(execute("")), "refresh: action: Verb is required"},
+		{"version 1.3", Version13, NewRefresh(execute("refresh")),
+			"refresh: Universal Actions require version 1.4 but the card declares 1.3"},
+		{"version 1.6", Version16, NewRefresh(execute("refresh")), ""},
+		{"no version", "", NewRefresh(execute("refresh")), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := NewAdaptiveCard()
+			card.Version = tt.version
+			card.Refresh = tt.refresh
+			err := card.Validate()
+			if tt.want == "" && err != nil {
+				t.Errorf("Validate() = %v", err)
+			}
+			if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+				t.Errorf("Validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package teams
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// renderCard has hidden elements and an Action.ShowCard
+const renderCard = `{"type":"AdaptiveCard","version":"1.5","body":[
+	{"type":"TextBlock","text":"Deployment **finished**","size":"large","weight":"bolder","wrap":true},
+	{"type":"TextBlock","text":"hidden text","isVisible":false},
+	{"type":"FactSet","facts":[{"title":"Service","value":"api_gateway"},{"title":"Status","value":"healthy"}]},
+	{"type":"Container","isVisible":false,"items":[{"type":"TextBlock","text":"hidden container"}]},
+	{"type":"ColumnSet","columns":[
+		{"type":"Column","items":[{"type":"TextBlock","text":"left"}]},
+		{"type":"Column","isVisible":false,"items":[{"type":"TextBlock","text":"hidden column"}]},
+		{"type":"Column","items":[{"type":"TextBlock","text":"right"}]}
+	]}
+],"actions":[
+	{"type":"Action.OpenUrl","title":"Logs","url":"https://example.com/logs"},
+	{"type":"Action.ShowCard","title":"Details","card":{"type":"AdaptiveCard","body":[
+		{"type":"TextBlock","text":"shown card"},
+		{"type":"TextBlock","text":"hidden in shown card","isVisible":false}
+	]}},
+	{"type":"Action.Submit","title":"Approve"}
+]}`
+
+func TestRenderGolden(t *testing.T) {
+	card := decodeCard(t, renderCard)
+	tests := []struct {
+		name string
+		out  string
+	}{
+		{"card.txt", RenderText(card)},
+		{"card.md", RenderMarkdown(card)},
+		{"card_facts_table.md", (&MarkdownRenderer{FactsAsTable: true}).Render(card)},
+		{"card.html", (&HTMLRenderer{Fragment: true}).Render(card)},
+		{"card.term", (&TerminalRenderer{Width: 48, NoColor: true}).Render(card)},
+		{"card_color.term", RenderTerminal(card, 48)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			golden := filepath.Join("testdata", "render", tt.name)
+			if *update {
+				if err := os.WriteFile(golden, []byte(tt.out), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.out != string(want) {
+				t.Errorf("output differs from %s, run go test -update to update it:\n%s", golden, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,563 @@
+package teams
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The Adaptive Card JSON schemas of every version are vendored in the schemas directory, so cards can be checked
+// against them without network access. They are validated by a minimal JSON Schema validator supporting the subset
+// of draft-06 the schemas use: $ref to local definitions, type, enum, const, properties, required,
+// additionalProperties, patternProperties, items, allOf, anyOf, oneOf, not, pattern, format (uri and
+// uri-reference) and the numeric, length and item count limits. Other keywords are ignored
+//
+// The schemas up to 1.5 are the official ones. The 1.6 schema additionally defines the elements only Teams supports,
+// like CodeBlock, Badge and Carousel
+//
+// The structs, enums, constructors and decoders of the card elements and actions are generated from the latest
+// schema by internal/schemagen. Run go generate after updating the schemas
+
+//go:generate go run ./internal/schemagen
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// schemaVersions holds the versions there is a vendored schema for
+var schemaVersions = []Version{Version10, Version11, Version12, Version13, Version14, Version15, Version16}
+
+var (
+	schemasMu sync.Mutex
+	schemas   = map[Version]*jsonSchema{}
+)
+
+// SchemaError is a violation of the Adaptive Card JSON schema
+type SchemaError struct {
+	// The location of the violation in the JSON representation of the card as a JSON pointer (RFC 6901), e.g.
+	// "/body/0/size". The root card has the empty path
+	Path string
+	// What is wrong at the location
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// SchemaErrors holds all the violations found in a card
+type SchemaErrors []*SchemaError
+
+func (e SchemaErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// JSONSchema returns the vendored Adaptive Card JSON schema of the given version
+func JSONSchema(version Version) ([]byte, error) {
+	for _, v := range schemaVersions {
+		if v == version {
+			return schemaFiles.ReadFile("schemas/adaptive-card-" + string(v) + ".json")
+		}
+	}
+
+	return nil, fmt.Errorf("Version is invalid; expected one of: %s, got %s", joinVersions(), version)
+}
+
+func joinVersions() string {
+	versions := make([]string, len(schemaVersions))
+	for i, v := range schemaVersions {
+		versions[i] = string(v)
+	}
+
+	return strings.Join(versions, ", ")
+}
+
+// ValidateAgainstSchema checks the JSON representation of the card against the vendored JSON schema of the card’s
+// version. Unlike Validate, it reports every violation, as SchemaErrors annotated with their location
+func ValidateAgainstSchema(card *AdaptiveCard) error {
+	data, err := json.Marshal(card)
+	if err != nil {
+		return err
+	}
+
+	return ValidateJSONAgainstSchema(data)
+}
+
+// ValidateJSONAgainstSchema checks the JSON of a card against the vendored JSON schema of the card’s version. Cards
+// without a version are checked against the latest schema, which reports the version as missing
+func ValidateJSONAgainstSchema(data []byte) error {
+	var card interface{}
+	if err := json.Unmarshal(data, &card); err != nil {
+		return err
+	}
+
+	version := schemaVersions[len(schemaVersions)-1]
+	if obj, ok := card.(map[string]interface{}); ok {
+		if v, ok := obj["version"].(string); ok {
+			version = Version(v)
+		}
+	}
+
+	schema, err := loadSchema(version)
+	if err != nil {
+		return SchemaErrors{{Path: "/version", Message: err.Error()}}
+	}
+	if errs := schema.validate(schema.root, card, ""); len(errs) > 0 {
+		return SchemaErrors(errs)
+	}
+
+	return nil
+}
+
+// loadSchema returns the parsed schema of the version, parsing it on first use
+func loadSchema(version Version) (*jsonSchema, error) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if s, ok := schemas[version]; ok {
+		return s, nil
+	}
+
+	data, err := JSONSchema(version)
+	if err != nil {
+		return nil, err
+	}
+	s := &jsonSchema{patterns: map[string]*regexp.Regexp{}}
+	if err := json.Unmarshal(data, &s.root); err != nil {
+		// the vendored schemas are known to be valid JSON
+		panic(fmt.Sprintf("teams: schema %s: %v", version, err))
+	}
+	schemas[version] = s
+
+	return s, nil
+}
+
+// jsonSchema validates JSON values decoded into interface{} against a draft-06 JSON schema
+type jsonSchema struct {
+	root interface{}
+
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+// validate returns the violations of schema by value, which is located at path
+func (s *jsonSchema) validate(schema interface{}, value interface{}, path string) []*SchemaError {
+	switch schema := schema.(type) {
+	case bool:
+		if !schema {
+			return []*SchemaError{{path, "no value is allowed"}}
+		}
+		return nil
+	case map[string]interface{}:
+		if ref, ok := schema["$ref"].(string); ok {
+			// other keywords next to $ref are ignored
+			return s.validate(s.resolve(ref), value, path)
+		}
+		return s.validateKeywords(schema, value, path)
+	}
+
+	return nil
+}
+
+// resolve returns the schema referenced by a local JSON pointer like "#/definitions/TextBlock"
+func (s *jsonSchema) resolve(ref string) interface{} {
+	if !strings.HasPrefix(ref, "#") {
+		panic("teams: only local schema references are supported, got " + ref)
+	}
+
+	schema := s.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		obj, _ := schema.(map[string]interface{})
+		if schema = obj[token]; schema == nil {
+			panic("teams: unresolvable schema reference " + ref)
+		}
+	}
+
+	return schema
+}
+
+func (s *jsonSchema) validateKeywords(schema map[string]interface{}, value interface{}, path string) []*SchemaError {
+	var errs []*SchemaError
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, &SchemaError{path, fmt.Sprintf(format, args...)})
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		fail("expected %s, got %s", joinTypes(types), jsonType(value))
+		// the other keywords would only repeat the mismatch
+		return errs
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		values := make([]string, len(enum))
+		for i, v := range enum {
+			values[i] = formatValue(v)
+		}
+		fail("value is invalid; expected one of: %s, got %s", strings.Join(values, ", "), formatValue(value))
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		fail("value is invalid; expected: %s, got %s", formatValue(c), formatValue(value))
+	}
+
+	switch value := value.(type) {
+	case string:
+		errs = append(errs, s.validateString(schema, value, path)...)
+	case float64:
+		errs = append(errs, validateNumber(schema, value, path)...)
+	case []interface{}:
+		errs = append(errs, s.validateArray(schema, value, path)...)
+	case map[string]interface{}:
+		errs = append(errs, s.validateObject(schema, value, path)...)
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			errs = append(errs, s.validate(sub, value, path)...)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		errs = append(errs, s.validateAnyOf(anyOf, value, path)...)
+	}
+	if one, ok := schema["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, sub := range one {
+			if len(s.validate(sub, value, path)) == 0 {
+				matches++
+			}
+		}
+		if matches == 0 {
+			errs = append(errs, s.validateAnyOf(one, value, path)...)
+		} else if matches > 1 {
+			fail("value is invalid; expected it to match exactly one schema, matched %d", matches)
+		}
+	}
+	if not, ok := schema["not"]; ok && len(s.validate(not, value, path)) == 0 {
+		fail("value is invalid; expected it not to match the schema")
+	}
+
+	return errs
+}
+
+// validateAnyOf returns nil if value matches any of the schemas. Otherwise it returns the violations of the schema
+// value most likely was meant to match: of the schemas accepting the type of an object, the violations they all
+// share, like a missing property, else those of the schema whose violations are the deepest inside value, and the
+// fewest
+func (s *jsonSchema) validateAnyOf(schemas []interface{}, value interface{}, path string) []*SchemaError {
+	candidates := make([][]*SchemaError, 0, len(schemas))
+	for _, sub := range schemas {
+		errs := s.validate(sub, value, path)
+		if len(errs) == 0 {
+			return nil
+		}
+		candidates = append(candidates, errs)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if obj, ok := value.(map[string]interface{}); ok {
+		if t, ok := obj["type"].(string); ok {
+			var typed [][]*SchemaError
+			for _, errs := range candidates {
+				if !hasErrorAt(errs, path+"/type") {
+					typed = append(typed, errs)
+				}
+			}
+			if len(typed) == 0 {
+				return []*SchemaError{{path + "/type", fmt.Sprintf("%s is not allowed here", t)}}
+			}
+			candidates = typed
+		}
+	}
+
+	// violations common to all schemas, like a missing type, are the ones to fix
+	if common := commonErrors(candidates); len(common) > 0 {
+		return common
+	}
+
+	best := candidates[0]
+	for _, errs := range candidates[1:] {
+		if d, bestD := errorDepth(errs), errorDepth(best); d > bestD || d == bestD && len(errs) < len(best) {
+			best = errs
+		}
+	}
+
+	return best
+}
+
+func commonErrors(candidates [][]*SchemaError) []*SchemaError {
+	var common []*SchemaError
+	for _, err := range candidates[0] {
+		shared := true
+		for _, errs := range candidates[1:] {
+			if !containsError(errs, err) {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			common = append(common, err)
+		}
+	}
+
+	return common
+}
+
+func containsError(errs []*SchemaError, err *SchemaError) bool {
+	for _, e := range errs {
+		if *e == *err {
+			return true
+		}
+	}
+	return false
+}
+
+func hasErrorAt(errs []*SchemaError, path string) bool {
+	for _, err := range errs {
+		if err.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func errorDepth(errs []*SchemaError) int {
+	depth := 0
+	for _, err := range errs {
+		if d := strings.Count(err.Path, "/"); d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+func (s *jsonSchema) validateString(schema map[string]interface{}, value string, path string) []*SchemaError {
+	var errs []*SchemaError
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, &SchemaError{path, fmt.Sprintf(format, args...)})
+	}
+
+	length := float64(len([]rune(value)))
+	if min, ok := schema["minLength"].(float64); ok && length < min {
+		fail("value is too short; expected at least %v characters, got %v", min, length)
+	}
+	if max, ok := schema["maxLength"].(float64); ok && length > max {
+		fail("value is too long; expected at most %v characters, got %v", max, length)
+	}
+	if pattern, ok := schema["pattern"].(string); ok && !s.pattern(pattern).MatchString(value) {
+		fail("value is invalid; expected it to match %s, got %s", pattern, formatValue(value))
+	}
+
+	switch schema["format"] {
+	case "uri":
+		if u, err := url.Parse(value); err != nil || !u.IsAbs() {
+			fail("value is invalid; expected an absolute URI, got %s", formatValue(value))
+		}
+	case "uri-reference":
+		if _, err := url.Parse(value); err != nil {
+			fail("value is invalid; expected a URI reference, got %s", formatValue(value))
+		}
+	}
+
+	return errs
+}
+
+// pattern returns the compiled regular expression, compiling it on first use
+func (s *jsonSchema) pattern(pattern string) *regexp.Regexp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	re, ok := s.patterns[pattern]
+	if !ok {
+		re = regexp.MustCompile(pattern)
+		s.patterns[pattern] = re
+	}
+
+	return re
+}
+
+func validateNumber(schema map[string]interface{}, value float64, path string) []*SchemaError {
+	var errs []*SchemaError
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, &SchemaError{path, fmt.Sprintf(format, args...)})
+	}
+
+	if min, ok := schema["minimum"].(float64); ok && value < min {
+		fail("value is too small; expected at least %v, got %v", min, value)
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && value <= min {
+		fail("value is too small; expected more than %v, got %v", min, value)
+	}
+	if max, ok := schema["maximum"].(float64); ok && value > max {
+		fail("value is too large; expected at most %v, got %v", max, value)
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && value >= max {
+		fail("value is too large; expected less than %v, got %v", max, value)
+	}
+	if m, ok := schema["multipleOf"].(float64); ok && m > 0 && math.Mod(value, m) != 0 {
+		fail("value is invalid; expected a multiple of %v, got %v", m, value)
+	}
+
+	return errs
+}
+
+func (s *jsonSchema) validateArray(schema map[string]interface{}, value []interface{}, path string) []*SchemaError {
+	var errs []*SchemaError
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, &SchemaError{path, fmt.Sprintf(format, args...)})
+	}
+
+	if min, ok := schema["minItems"].(float64); ok && float64(len(value)) < min {
+		fail("too few items; expected at least %v, got %d", min, len(value))
+	}
+	if max, ok := schema["maxItems"].(float64); ok && float64(len(value)) > max {
+		fail("too many items; expected at most %v, got %d", max, len(value))
+	}
+
+	switch items := schema["items"].(type) {
+	case []interface{}:
+		for i, item := range value {
+			if i < len(items) {
+				errs = append(errs, s.validate(items[i], item, fmt.Sprintf("%s/%d", path, i))...)
+			}
+		}
+	case nil:
+	default:
+		for i, item := range value {
+			errs = append(errs, s.validate(items, item, fmt.Sprintf("%s/%d", path, i))...)
+		}
+	}
+
+	return errs
+}
+
+func (s *jsonSchema) validateObject(schema map[string]interface{}, value map[string]interface{}, path string) []*SchemaError {
+	var errs []*SchemaError
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, ok := value[name.(string)]; !ok {
+				errs = append(errs, &SchemaError{path, fmt.Sprintf("%s is required", name)})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	patternProperties, _ := schema["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+	for _, name := range names {
+		propertyPath := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+		matched := false
+		if sub, ok := properties[name]; ok {
+			matched = true
+			errs = append(errs, s.validate(sub, value[name], propertyPath)...)
+		}
+		for pattern, sub := range patternProperties {
+			if s.pattern(pattern).MatchString(name) {
+				matched = true
+				errs = append(errs, s.validate(sub, value[name], propertyPath)...)
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			errs = append(errs, &SchemaError{path, fmt.Sprintf("%s is not allowed", name)})
+			continue
+		}
+		errs = append(errs, s.validate(additional, value[name], propertyPath)...)
+	}
+
+	return errs
+}
+
+// jsonType returns the JSON schema type of a decoded JSON value
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func matchesType(types interface{}, value interface{}) bool {
+	t := jsonType(value)
+	matches := func(expected interface{}) bool {
+		return expected == t || expected == "number" && t == "integer"
+	}
+
+	if list, ok := types.([]interface{}); ok {
+		for _, expected := range list {
+			if matches(expected) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return matches(types)
+}
+
+func joinTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, t := range list {
+			names[i] = fmt.Sprint(t)
+		}
+		return strings.Join(names, " or ")
+	}
+
+	return fmt.Sprint(types)
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValue formats a decoded JSON value for error messages
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,171 @@
+package teams
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// The vendored schemas must not be edited for the generator: its hints are kept in its overrides.json
+func TestValidateJSONAgainstOfficialSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    string
+		wantErr bool
+	}{
+		{
+			name: "Action.ShowCard without card",
+			card: `{"type":"AdaptiveCard","version":"1.0","body":[],"actions":[{"type":"Action.ShowCard","title":"t"}]}`,
+		},
+		{
+			name: "Table without grid lines",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Table","showGridLines":false,"columns":[],"rows":[]}]}`,
+		},
+		{
+			name:    "wrong property type",
+			card:    `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"x","wrap":"yes"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSONAgainstSchema([]byte(tt.card))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateJSONAgainstSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateJSONAgainstSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		card string
+		want string
+	}{
+		{
+			name: "additional property",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"x","isRequireds":true}]}`,
+			want: "/body/0: isRequireds is not allowed",
+		},
+		{
+			name: "enum",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"x","size":"huge"}]}`,
+			want: `/body/0/size: value is invalid; expected one of: "default", "small", "medium", "large", "extraLarge", got "huge"`,
+		},
+		{
+			name: "enum of a Teams element",
+			card: `{"type":"AdaptiveCard","version":"1.6","body":[{"type":"Badge","text":"x","style":"loud"}]}`,
+			want: `/body/0/style: value is invalid; expected one of: "default", "subtle", "informative", "accent", "good", "attention", "warning", got "loud"`,
+		},
+		{
+			name: "required property of a nested element",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Container","items":[{"type":"Image"}]}]}`,
+			want: "/body/0/items/0: url is required",
+		},
+		{
+			name: "type of a deeply nested property",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"ColumnSet","columns":[{"type":"Column","items":[{"type":"TextBlock","text":"x","wrap":"yes"}]}]}]}`,
+			want: "/body/0/columns/0/items/0/wrap: expected boolean, got string",
+		},
+		{
+			name: "unknown type in anyOf",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Nope"}]}`,
+			want: "/body/0/type: Nope is not allowed here",
+		},
+		{
+			name: "Teams element in an older version",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Badge","text":"x"}]}`,
+			want: "/body/0/type: Badge is not allowed here",
+		},
+		{
+			name: "action in anyOf",
+			card: `{"type":"AdaptiveCard","version":"1.5","actions":[{"type":"Action.OpenUrl"}]}`,
+			want: "/actions/0: url is required",
+		},
+		{
+			name: "escaped pointer",
+			card: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"x","requires":{"a/b~c":1}}]}`,
+			want: "/body/0/requires/a~1b~0c: expected string, got integer",
+		},
+		{
+			name: "missing version",
+			card: `{"type":"AdaptiveCard"}`,
+			want: "version is required",
+		},
+		{
+			name: "unknown version",
+			card: `{"type":"AdaptiveCard","version":"2.0"}`,
+			want: "/version: Version is invalid; expected one of: 1.0, 1.1, 1.2, 1.3, 1.4, 1.5, 1.6, got 2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSONAgainstSchema([]byte(tt.card))
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("ValidateJSONAgainstSchema() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaKeywords(t *testing.T) {
+	s := &jsonSchema{patterns: map[string]*regexp.Regexp{}}
+	err := json.Unmarshal([]byte(`{
+		"definitions": {
+			"Color": {"enum": ["red", "green"]},
+			"Named": {"type": "object", "properties": {"name": {"type": "string", "minLength": 1}}, "required": ["name"]},
+			"a/b": {"type": "integer"}
+		},
+		"type": "object",
+		"properties": {
+			"color": {"$ref": "#/definitions/Color"},
+			"escaped": {"$ref": "#/definitions/a~1b"},
+			"item": {"anyOf": [{"type": "string"}, {"$ref": "#/definitions/Named"}]},
+			"list": {"type": "array", "items": {"$ref": "#/definitions/Named"}, "maxItems": 2},
+			"closed": {"type": "object", "properties": {"a": {}}, "additionalProperties": false},
+			"typed": {"type": "object", "additionalProperties": {"type": "number"}},
+			"open": {"type": "object"}
+		}
+	}`), &s.root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  []SchemaError
+	}{
+		{name: "valid", value: `{"color":"red","escaped":1,"item":{"name":"x"},"list":[{"name":"y"}],"closed":{"a":1},"typed":{"x":1.5},"open":{"x":"y"}}`},
+		{name: "$ref to enum", value: `{"color":"blue"}`, want: []SchemaError{{"/color", `value is invalid; expected one of: "red", "green", got "blue"`}}},
+		{name: "$ref with escaped token", value: `{"escaped":1.5}`, want: []SchemaError{{"/escaped", "expected integer, got number"}}},
+		{name: "anyOf first schema", value: `{"item":"x"}`},
+		{name: "anyOf with the errors of the closest schema", value: `{"item":{"name":""}}`, want: []SchemaError{{"/item/name", "value is too short; expected at least 1 characters, got 0"}}},
+		{name: "anyOf matching no type", value: `{"item":1}`, want: []SchemaError{{"/item", "expected string, got integer"}}},
+		{name: "array items", value: `{"list":[{"name":"x"},{}]}`, want: []SchemaError{{"/list/1", "name is required"}}},
+		{name: "array limit", value: `{"list":[{"name":"x"},{"name":"y"},{"name":"z"}]}`, want: []SchemaError{{"/list", "too many items; expected at most 2, got 3"}}},
+		{name: "additionalProperties false", value: `{"closed":{"a":1,"b":2,"c":3}}`, want: []SchemaError{{"/closed", "b is not allowed"}, {"/closed", "c is not allowed"}}},
+		{name: "additionalProperties schema", value: `{"typed":{"x":"y"}}`, want: []SchemaError{{"/typed/x", "expected number, got string"}}},
+		{name: "several errors", value: `{"color":"blue","typed":{"x":"y"}}`, want: []SchemaError{
+			{"/color", `value is invalid; expected one of: "red", "green", got "blue"`},
+			{"/typed/x", "expected number, got string"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatal(err)
+			}
+			var got []SchemaError
+			for _, err := range s.validate(s.root, value, "") {
+				got = append(got, *err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,10 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
-//
+// the longest part of a response body kept in a WebhookError
+const maxErrorBodyLength = 4096
+
+// connectorErrorPrefix starts the body of the responses of Office 365 connectors that failed to deliver a message,
+// which are sent with status 200
+const connectorErrorPrefix = "Microsoft Teams endpoint returned HTTP error "
+
+// Webhook posts messages to an incoming webhook of a Teams channel
 type Webhook struct {
 	// Webhook URL where the message is sent to
 	Url string
@@ -17,45 +27,63 @@ type Webhook struct {
 }
 
 func NewWebhook(url string) (*Webhook, error) {
-	webhook := &Webhook{}
+	return NewWebhookWithClient(url, &http.Client{})
+}
 
-	if ok := isValidUri(url); ok != true {
+// NewWebhookWithClient returns a Webhook that sends its requests with the given client, e.g. one with a timeout
+func NewWebhookWithClient(url string, client *http.Client) (*Webhook, error) {
+	if !isValidUri(url) {
 		return nil, errors.New("url is not valid")
-	} else {
-		webhook.Url = url
 	}
 
-	client := &http.Client{}
+	return &Webhook{Url: url, client: client}, nil
+}
+
+// WebhookError is returned by Send when the webhook doesn’t accept the message
+type WebhookError struct {
+	// HTTP status code of the response, or of the error reported by the connector
+	StatusCode int
+	// Body of the response, which usually explains the error
+	Body string
+}
+
+func (e *WebhookError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("webhook returned status %d", e.StatusCode)
+	}
 
-	webhook.client = client
+	return fmt.Sprintf("webhook returned status %d: %s", e.StatusCode, e.Body)
+}
 
-	return webhook, nil
+// Temporary reports whether sending the message again later may succeed, which is the case when the webhook is
+// throttled or has a server error
+func (e *WebhookError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
 }
 
-func (w *Webhook) Send(card Card) error {
+// WebhookPayload returns the JSON body Send posts for the card. An AdaptiveCard is wrapped in a Message, other
+// cards are sent as they are
+func WebhookPayload(card Card) ([]byte, error) {
 	var msg interface{}
-	switch card.(type) {
+	switch card := card.(type) {
 	case *AdaptiveCard:
-		msg = &Message{
-			Type: "message",
-			Attachments: []Attachment{
-				{
-					ContentType: "application/vnd.microsoft.card.adaptive",
-					ContentUrl:  "",
-					Content:     card,
-				},
-			},
-		}
+		msg = NewMessage(card)
 	default:
 		msg = card
 	}
 
-	json, err := json.Marshal(msg)
+	return json.Marshal(msg)
+}
+
+// Send posts the card to the webhook. It returns a *WebhookError if the webhook responds with an error status, or
+// reports a failed delivery in the body of a successful response like Office 365 connectors do
+func (w *Webhook) Send(card Card) error {
+	payload, err := WebhookPayload(card)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, w.Url, bytes.NewBuffer(json))
+	req, err := http.NewRequest(http.MethodPost, w.Url, bytes.NewBuffer(payload))
 	if err != nil {
 		return err
 	}
@@ -65,12 +93,29 @@ func (w *Webhook) Send(card Card) error {
 	res, err := w.client.Do(req)
 	if err != nil {
 		return err
-	} else {
-		if res.StatusCode != http.StatusOK {
-			return errors.New(fmt.Sprintf("Expected StatusCode:%d, but got %d", http.StatusOK, res.StatusCode))
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodyLength))
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSpace(string(body))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &WebhookError{StatusCode: res.StatusCode, Body: text}
+	}
+	if strings.HasPrefix(text, connectorErrorPrefix) {
+		status := strings.TrimPrefix(text, connectorErrorPrefix)
+		if i := strings.IndexFunc(status, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			status = status[:i]
 		}
+		code, err := strconv.Atoi(status)
+		if err != nil {
+			code = http.StatusBadGateway
+		}
+		return &WebhookError{StatusCode: code, Body: text}
 	}
 
 	return nil
-
 }
@@ -0,0 +1,973 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Templates separate the layout of a card from its data, like the Adaptive Cards templating SDK does. A template is
+// the JSON of a card with bindings:
+//
+//   - "${expression}" in a string is replaced by the value of the expression. A string that is a single binding
+//     takes the type of the value, e.g. a number or an object
+//   - "$data" sets the data the bindings of an object refer to. If it is an array, the object is repeated for every
+//     item of the array
+//   - "$when" drops an object when its expression is false
+//
+// Expressions are property paths like "user.name" or "items[0].title", starting at the current data, "$root", the
+// data the template was expanded with, "$data" or "$index", the index of the repeated object. They support the
+// literals true, false, null, numbers and quoted strings, the operators ! * / % + - < <= > >= == != && || and the
+// functions if, equals, not, and, or, exists, empty, count, length, concat, join, contains, toUpper, toLower,
+// string, json and formatNumber. Bindings whose value is undefined, like missing properties, are kept unchanged.
+
+// ExpandTemplate expands the template with the given data, which can be any value encoding/json can marshal, and
+// returns the resulting JSON
+func ExpandTemplate(template []byte, data interface{}) ([]byte, error) {
+	root, err := parseTemplateJSON(template)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("data: %w", err)
+	}
+	dataValue, err := parseTemplateJSON(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("data: %w", err)
+	}
+
+	out, keep, err := expandNode(root, templateScope{data: dataValue, root: dataValue, index: undefined}, "")
+	if err != nil {
+		return nil, err
+	}
+	if !keep {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(out)
+}
+
+// ExpandTemplateCard expands the template with the given data and decodes the result as an AdaptiveCard
+func ExpandTemplateCard(template []byte, data interface{}) (*AdaptiveCard, error) {
+	expanded, err := ExpandTemplate(template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var card AdaptiveCard
+	if err := json.Unmarshal(expanded, &card); err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+// undefined is the value of expressions referring to something that doesn’t exist
+var undefined = &struct{ undefined bool }{true}
+
+type templateScope struct {
+	data  interface{}
+	root  interface{}
+	index interface{}
+}
+
+// templateObject is a JSON object that keeps the order of its members, so expanded cards read like their template
+type templateObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *templateObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+func (o *templateObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// parseTemplateJSON decodes JSON into nil, bool, float64, string, []interface{} and *templateObject values
+func parseTemplateJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeTemplateValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+
+	return v, nil
+}
+
+func decodeTemplateValue(dec *json.Decoder) (interface{}, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := t.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			o := &templateObject{values: map[string]interface{}{}}
+			for dec.More() {
+				k, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				v, err := decodeTemplateValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				o.set(k.(string), v)
+			}
+			_, err := dec.Token()
+			return o, err
+		case '[':
+			a := []interface{}{}
+			for dec.More() {
+				v, err := decodeTemplateValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, v)
+			}
+			_, err := dec.Token()
+			return a, err
+		}
+	case json.Number:
+		return t.Float64()
+	}
+
+	return t, nil
+}
+
+// expandNode expands a node of the template. It reports false if the node is dropped by its $when
+func expandNode(node interface{}, s templateScope, path string) (interface{}, bool, error) {
+	switch n := node.(type) {
+	case string:
+		v, err := interpolate(n, s)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", pointerOrRoot(path), err)
+		}
+		return v, true, nil
+	case []interface{}:
+		out := []interface{}{}
+		for i, item := range n {
+			itemPath := fmt.Sprintf("%s/%d", path, i)
+			items, err := expandArrayItem(item, s, itemPath)
+			if err != nil {
+				return nil, false, err
+			}
+			out = append(out, items...)
+		}
+		return out, true, nil
+	case *templateObject:
+		if dataExpr, ok := n.values["$data"]; ok {
+			data, err := evaluateData(dataExpr, s)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s/$data: %w", path, err)
+			}
+			s = templateScope{data: data, root: s.root, index: s.index}
+		}
+		return expandObject(n, s, path)
+	}
+
+	return node, true, nil
+}
+
+// expandArrayItem expands an item of an array, repeating it for every item of its $data if that is an array
+func expandArrayItem(item interface{}, s templateScope, path string) ([]interface{}, error) {
+	o, ok := item.(*templateObject)
+	if !ok {
+		v, keep, err := expandNode(item, s, path)
+		if err != nil || !keep {
+			return nil, err
+		}
+		return []interface{}{v}, nil
+	}
+
+	dataExpr, ok := o.values["$data"]
+	if !ok {
+		v, keep, err := expandObject(o, s, path)
+		if err != nil || !keep {
+			return nil, err
+		}
+		return []interface{}{v}, nil
+	}
+
+	data, err := evaluateData(dataExpr, s)
+	if err != nil {
+		return nil, fmt.Errorf("%s/$data: %w", path, err)
+	}
+	items, repeat := data.([]interface{})
+	if !repeat {
+		v, keep, err := expandObject(o, templateScope{data: data, root: s.root, index: s.index}, path)
+		if err != nil || !keep {
+			return nil, err
+		}
+		return []interface{}{v}, nil
+	}
+
+	var out []interface{}
+	for i, d := range items {
+		v, keep, err := expandObject(o, templateScope{data: d, root: s.root, index: float64(i)}, path)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+// expandObject expands the members of an object whose $data has been applied already
+func expandObject(o *templateObject, s templateScope, path string) (interface{}, bool, error) {
+	if when, ok := o.values["$when"]; ok {
+		v, err := evaluateData(when, s)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s/$when: %w", path, err)
+		}
+		if !truthy(v) {
+			return nil, false, nil
+		}
+	}
+
+	out := &templateObject{values: map[string]interface{}{}}
+	for _, k := range o.keys {
+		if k == "$data" || k == "$when" {
+			continue
+		}
+		v, keep, err := expandNode(o.values[k], s, path+"/"+escapePointer(k))
+		if err != nil {
+			return nil, false, err
+		}
+		if keep {
+			out.set(k, v)
+		}
+	}
+
+	return out, true, nil
+}
+
+// evaluateData evaluates the value of $data or $when, which is either a binding or a literal value
+func evaluateData(v interface{}, s templateScope) (interface{}, error) {
+	str, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	expr, whole := singleBinding(str)
+	if !whole {
+		return interpolate(str, s)
+	}
+	value, err := evaluate(expr, s)
+	if err != nil {
+		return nil, err
+	}
+	if value == undefined {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+func pointerOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
+
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// binding is a ${...} binding found in a string
+type binding struct {
+	start, end int
+	expr       string
+}
+
+// findBindings returns the bindings of s, skipping braces and quotes inside the expressions
+func findBindings(s string) ([]binding, error) {
+	var out []binding
+	for i := 0; i < len(s); i++ {
+		if !strings.HasPrefix(s[i:], "${") {
+			continue
+		}
+		depth := 0
+		var quote byte
+		end := -1
+		for j := i + 2; j < len(s) && end < 0; j++ {
+			c := s[j]
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '\'' || c == '"':
+				quote = c
+			case c == '{':
+				depth++
+			case c == '}':
+				if depth == 0 {
+					end = j
+				}
+				depth--
+			}
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated binding %s", s[i:])
+		}
+		out = append(out, binding{start: i, end: end + 1, expr: s[i+2 : end]})
+		i = end
+	}
+
+	return out, nil
+}
+
+// singleBinding returns the expression of s if s consists of a single binding
+func singleBinding(s string) (string, bool) {
+	bindings, err := findBindings(s)
+	if err != nil || len(bindings) != 1 || bindings[0].start != 0 || bindings[0].end != len(s) {
+		return "", false
+	}
+
+	return bindings[0].expr, true
+}
+
+// interpolate replaces the bindings of s. A string that is a single binding is replaced by the value of the
+// expression
+func interpolate(s string, scope templateScope) (interface{}, error) {
+	bindings, err := findBindings(s)
+	if err != nil || len(bindings) == 0 {
+		return s, err
+	}
+
+	if len(bindings) == 1 && bindings[0].start == 0 && bindings[0].end == len(s) {
+		v, err := evaluate(bindings[0].expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		if v == undefined {
+			return s, nil
+		}
+		return v, nil
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, b := range bindings {
+		sb.WriteString(s[last:b.start])
+		v, err := evaluate(b.expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		if v == undefined {
+			sb.WriteString(s[b.start:b.end])
+		} else {
+			sb.WriteString(templateString(v))
+		}
+		last = b.end
+	}
+	sb.WriteString(s[last:])
+
+	return sb.String(), nil
+}
+
+// templateString formats a value for a binding inside a string
+func templateString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+
+	return v != undefined
+}
+
+// evaluate parses and evaluates an expression
+func evaluate(expr string, s templateScope) (interface{}, error) {
+	tokens, err := tokenizeExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("${%s}: %w", expr, err)
+	}
+	p := &expressionParser{tokens: tokens, scope: s}
+	v, err := p.or()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("${%s}: %w", expr, err)
+	}
+
+	return v, nil
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type expressionToken struct {
+	kind tokenKind
+	text string
+}
+
+var expressionOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "!", "<", ">", "+", "-", "*", "/", "%", ".", "[", "]", "(", ")", ","}
+
+func tokenizeExpression(expr string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expr[i+1:], expr[i])
+			if end < 0 {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, expressionToken{tokenString, expr[i+1 : i+1+end]})
+			i += end + 2
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(expr) && (unicode.IsDigit(rune(expr[j])) || expr[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, expressionToken{tokenNumber, expr[i:j]})
+			i = j
+		case unicode.IsLetter(c) || c == '_' || c == '$' || c == '@':
+			j := i
+			for j < len(expr) && (unicode.IsLetter(rune(expr[j])) || unicode.IsDigit(rune(expr[j])) || expr[j] == '_' || expr[j] == '$' || expr[j] == '@') {
+				j++
+			}
+			tokens = append(tokens, expressionToken{tokenIdent, expr[i:j]})
+			i = j
+		default:
+			matched := false
+			for _, op := range expressionOperators {
+				if strings.HasPrefix(expr[i:], op) {
+					tokens = append(tokens, expressionToken{tokenOperator, op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected %q", c)
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// expressionParser evaluates an expression while parsing it with recursive descent
+type expressionParser struct {
+	tokens []expressionToken
+	pos    int
+	scope  templateScope
+}
+
+func (p *expressionParser) accept(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			p.pos++
+			return op, true
+		}
+	}
+
+	return "", false
+}
+
+func (p *expressionParser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		if p.pos >= len(p.tokens) {
+			return fmt.Errorf("expected %q", op)
+		}
+		return fmt.Errorf("expected %q, got %q", op, p.tokens[p.pos].text)
+	}
+
+	return nil
+}
+
+func (p *expressionParser) or() (interface{}, error) {
+	left, err := p.and()
+	for err == nil {
+		if _, ok := p.accept("||"); !ok {
+			break
+		}
+		var right interface{}
+		if right, err = p.and(); err == nil {
+			left = truthy(left) || truthy(right)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) and() (interface{}, error) {
+	left, err := p.equality()
+	for err == nil {
+		if _, ok := p.accept("&&"); !ok {
+			break
+		}
+		var right interface{}
+		if right, err = p.equality(); err == nil {
+			left = truthy(left) && truthy(right)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) equality() (interface{}, error) {
+	left, err := p.comparison()
+	for err == nil {
+		op, ok := p.accept("==", "!=")
+		if !ok {
+			break
+		}
+		var right interface{}
+		if right, err = p.comparison(); err == nil {
+			equal := templateEqual(left, right)
+			left = equal == (op == "==")
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) comparison() (interface{}, error) {
+	left, err := p.additive()
+	for err == nil {
+		op, ok := p.accept("<", "<=", ">", ">=")
+		if !ok {
+			break
+		}
+		var right interface{}
+		if right, err = p.additive(); err != nil {
+			break
+		}
+		c, comparable := templateCompare(left, right)
+		if !comparable {
+			left = false
+			continue
+		}
+		switch op {
+		case "<":
+			left = c < 0
+		case "<=":
+			left = c <= 0
+		case ">":
+			left = c > 0
+		case ">=":
+			left = c >= 0
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) additive() (interface{}, error) {
+	left, err := p.multiplicative()
+	for err == nil {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			break
+		}
+		var right interface{}
+		if right, err = p.multiplicative(); err != nil {
+			break
+		}
+		l, lok := left.(float64)
+		r, rok := right.(float64)
+		switch {
+		case lok && rok && op == "+":
+			left = l + r
+		case lok && rok:
+			left = l - r
+		case op == "+" && left != undefined && right != undefined:
+			left = templateString(left) + templateString(right)
+		default:
+			left = undefined
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) multiplicative() (interface{}, error) {
+	left, err := p.unary()
+	for err == nil {
+		op, ok := p.accept("*", "/", "%")
+		if !ok {
+			break
+		}
+		var right interface{}
+		if right, err = p.unary(); err != nil {
+			break
+		}
+		l, lok := left.(float64)
+		r, rok := right.(float64)
+		switch {
+		case !lok || !rok:
+			left = undefined
+		case op == "*":
+			left = l * r
+		case r == 0:
+			return nil, errors.New("division by zero")
+		case op == "/":
+			left = l / r
+		default:
+			left = math.Mod(l, r)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) unary() (interface{}, error) {
+	if op, ok := p.accept("!", "-"); ok {
+		v, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "!" {
+			return !truthy(v), nil
+		}
+		if n, ok := v.(float64); ok {
+			return -n, nil
+		}
+		return undefined, nil
+	}
+
+	return p.postfix()
+}
+
+func (p *expressionParser) postfix() (interface{}, error) {
+	v, err := p.primary()
+	for err == nil {
+		if _, ok := p.accept("."); ok {
+			if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenIdent {
+				return nil, errors.New("expected a property name after \".\"")
+			}
+			v = member(v, p.tokens[p.pos].text)
+			p.pos++
+		} else if _, ok := p.accept("["); ok {
+			var index interface{}
+			if index, err = p.or(); err == nil {
+				err = p.expect("]")
+				v = member(v, index)
+			}
+		} else {
+			break
+		}
+	}
+
+	return v, err
+}
+
+func (p *expressionParser) primary() (interface{}, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+	switch t.kind {
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", t.text)
+		}
+		return n, nil
+	case tokenString:
+		return t.text, nil
+	case tokenIdent:
+		if _, ok := p.accept("("); ok {
+			return p.call(t.text)
+		}
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		case "$root":
+			return p.scope.root, nil
+		case "$data":
+			return p.scope.data, nil
+		case "$index":
+			return p.scope.index, nil
+		}
+		return member(p.scope.data, t.text), nil
+	}
+
+	if t.text == "(" {
+		v, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return v, p.expect(")")
+	}
+
+	return nil, fmt.Errorf("unexpected %q", t.text)
+}
+
+func (p *expressionParser) call(name string) (interface{}, error) {
+	var args []interface{}
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); ok {
+				continue
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	f, ok := templateFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+	if len(args) < f.minArgs || f.maxArgs >= 0 && len(args) > f.maxArgs {
+		return nil, fmt.Errorf("wrong number of arguments for %s: %d", name, len(args))
+	}
+
+	return f.call(args), nil
+}
+
+// member returns the property or item key of v, or undefined
+func member(v interface{}, key interface{}) interface{} {
+	switch v := v.(type) {
+	case *templateObject:
+		k, ok := key.(string)
+		if !ok {
+			return undefined
+		}
+		if m, ok := v.values[k]; ok {
+			return m
+		}
+	case []interface{}:
+		i, ok := key.(float64)
+		if ok && i >= 0 && int(i) < len(v) && i == math.Trunc(i) {
+			return v[int(i)]
+		}
+		if key == "length" {
+			return float64(len(v))
+		}
+	case string:
+		if key == "length" {
+			return float64(len([]rune(v)))
+		}
+	}
+
+	return undefined
+}
+
+func templateEqual(a, b interface{}) bool {
+	if a == undefined {
+		a = nil
+	}
+	if b == undefined {
+		b = nil
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// templateCompare compares two numbers or two strings
+func templateCompare(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			}
+			return 0, true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	}
+
+	return 0, false
+}
+
+type templateFunction struct {
+	minArgs, maxArgs int
+	call             func(args []interface{}) interface{}
+}
+
+var templateFunctions map[string]templateFunction
+
+func init() {
+	count := func(args []interface{}) interface{} {
+		switch v := args[0].(type) {
+		case []interface{}:
+			return float64(len(v))
+		case string:
+			return float64(len([]rune(v)))
+		case *templateObject:
+			return float64(len(v.keys))
+		}
+		return float64(0)
+	}
+
+	templateFunctions = map[string]templateFunction{
+		"if": {3, 3, func(args []interface{}) interface{} {
+			if truthy(args[0]) {
+				return args[1]
+			}
+			return args[2]
+		}},
+		"equals": {2, 2, func(args []interface{}) interface{} { return templateEqual(args[0], args[1]) }},
+		"not":    {1, 1, func(args []interface{}) interface{} { return !truthy(args[0]) }},
+		"and": {1, -1, func(args []interface{}) interface{} {
+			for _, a := range args {
+				if !truthy(a) {
+					return false
+				}
+			}
+			return true
+		}},
+		"or": {1, -1, func(args []interface{}) interface{} {
+			for _, a := range args {
+				if truthy(a) {
+					return true
+				}
+			}
+			return false
+		}},
+		"exists": {1, 1, func(args []interface{}) interface{} { return args[0] != undefined && args[0] != nil }},
+		"empty": {1, 1, func(args []interface{}) interface{} {
+			return args[0] == undefined || args[0] == nil || count(args) == float64(0)
+		}},
+		"count":  {1, 1, count},
+		"length": {1, 1, count},
+		"concat": {1, -1, func(args []interface{}) interface{} {
+			var sb strings.Builder
+			for _, a := range args {
+				if a != undefined {
+					sb.WriteString(templateString(a))
+				}
+			}
+			return sb.String()
+		}},
+		"join": {2, 2, func(args []interface{}) interface{} {
+			items, _ := args[0].([]interface{})
+			parts := make([]string, 0, len(items))
+			for _, item := range items {
+				parts = append(parts, templateString(item))
+			}
+			return strings.Join(parts, templateString(args[1]))
+		}},
+		"contains": {2, 2, func(args []interface{}) interface{} {
+			switch v := args[0].(type) {
+			case string:
+				s, ok := args[1].(string)
+				return ok && strings.Contains(v, s)
+			case []interface{}:
+				for _, item := range v {
+					if templateEqual(item, args[1]) {
+						return true
+					}
+				}
+			case *templateObject:
+				k, ok := args[1].(string)
+				_, has := v.values[k]
+				return ok && has
+			}
+			return false
+		}},
+		"toUpper": {1, 1, func(args []interface{}) interface{} { return strings.ToUpper(templateString(args[0])) }},
+		"toLower": {1, 1, func(args []interface{}) interface{} { return strings.ToLower(templateString(args[0])) }},
+		"string":  {1, 1, func(args []interface{}) interface{} { return templateString(args[0]) }},
+		"json": {1, 1, func(args []interface{}) interface{} {
+			data, _ := json.Marshal(args[0])
+			return string(data)
+		}},
+		"formatNumber": {2, 2, func(args []interface{}) interface{} {
+			n, ok := args[0].(float64)
+			decimals, dok := args[1].(float64)
+			if !ok || !dok || decimals < 0 {
+				return undefined
+			}
+			return strconv.FormatFloat(n, 'f', int(decimals), 64)
+		}},
+	}
+}
@@ -0,0 +1,116 @@
+package teams
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	data := map[string]interface{}{
+		"title": "Alert",
+		"count": 3,
+		"zero":  0,
+		"user":  map[string]interface{}{"name": "Ada", "tags": []string{"a", "b"}},
+		"items": []map[string]interface{}{{"name": "one", "done": true}, {"name": "two", "done": false}},
+		"empty": []string{},
+	}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"property", `{"text":"${title}"}`, `{"text":"Alert"}`},
+		{"nested path", `{"text":"${user.name}"}`, `{"text":"Ada"}`},
+		{"index path", `{"text":"${items[1].name} ${user.tags[0]}"}`, `{"text":"two a"}`},
+		{"interpolation", `{"text":"${title}: ${count} items"}`, `{"text":"Alert: 3 items"}`},
+		{"single binding keeps the type", `{"n":"${count}","o":"${user}","b":"${items[0].done}"}`, `{"n":3,"o":{"name":"Ada","tags":["a","b"]},"b":true}`},
+		{"missing path is kept", `{"text":"${missing.name}","n":"${user.age}"}`, `{"text":"${missing.name}","n":"${user.age}"}`},
+		{"missing path in interpolation", `{"text":"hi ${nobody}"}`, `{"text":"hi ${nobody}"}`},
+		{"multiplication before addition", `{"n":"${1 + 2 * 3}"}`, `{"n":7}`},
+		{"parentheses", `{"n":"${(1 + 2) * 3}"}`, `{"n":9}`},
+		{"left associative", `{"n":"${10 - 4 - 3}","m":"${12 / 3 / 2}","r":"${7 % 4 * 2}"}`, `{"n":3,"m":2,"r":6}`},
+		{"unary minus", `{"n":"${-count + 5}"}`, `{"n":2}`},
+		{"comparison before equality", `{"b":"${1 < 2 == true}"}`, `{"b":true}`},
+		{"and before or", `{"b":"${true || false && false}","c":"${(true || false) && false}"}`, `{"b":true,"c":false}`},
+		{"not", `{"b":"${!items[1].done && count > 2}"}`, `{"b":true}`},
+		{"string concatenation", `{"s":"${'#' + count}"}`, `{"s":"#3"}`},
+		{"functions", `{"s":"${toUpper(user.name)}","n":"${count(items)}","e":"${empty(empty)}","j":"${join(user.tags, ', ')}","i":"${if(zero > 0, 'some', 'none')}"}`,
+			`{"s":"ADA","n":2,"e":true,"j":"a, b","i":"none"}`},
+		{"$data object", `{"$data":"${user}","type":"TextBlock","text":"${name}"}`, `{"type":"TextBlock","text":"Ada"}`},
+		{"$data array repeats", `{"body":[{"$data":"${items}","type":"TextBlock","text":"${$index}: ${name}"}]}`,
+			`{"body":[{"type":"TextBlock","text":"0: one"},{"type":"TextBlock","text":"1: two"}]}`},
+		{"$data empty array", `{"body":[{"$data":"${empty}","type":"TextBlock"},{"type":"TextBlock","text":"after"}]}`,
+			`{"body":[{"type":"TextBlock","text":"after"}]}`},
+		{"$data literal array", `{"body":[{"$data":[1,2],"text":"${$data}"}]}`, `{"body":[{"text":1},{"text":2}]}`},
+		{"$root", `{"body":[{"$data":"${items}","text":"${name} of ${$root.title}"}]}`,
+			`{"body":[{"text":"one of Alert"},{"text":"two of Alert"}]}`},
+		{"nested $data keeps $root", `{"$data":"${user}","items":[{"$data":"${tags}","text":"${$data}/${$root.count}"}]}`,
+			`{"items":[{"text":"a/3"},{"text":"b/3"}]}`},
+		{"$when false removes the item", `{"body":[{"$when":"${count > 5}","text":"many"},{"$when":"${count > 1}","text":"some"}]}`,
+			`{"body":[{"text":"some"}]}`},
+		{"$when with $data", `{"body":[{"$data":"${items}","$when":"${done}","text":"${name}"}]}`, `{"body":[{"text":"one"}]}`},
+		{"$when false on a property", `{"a":{"$when":"${false}","x":1},"b":2}`, `{"b":2}`},
+		{"$when false on the root", `{"$when":"${zero}","text":"x"}`, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := ExpandTemplate([]byte(tt.template), data)
+			if err != nil {
+				t.Fatalf("ExpandTemplate() = %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("ExpandTemplate() = %s, want %s", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"invalid JSON", `{"text":`, "template: "},
+		{"unclosed binding", `{"text":"${title"}`, "/text"},
+		{"syntax error", `{"text":"${1 +}"}`, "/text"},
+		{"unknown function", `{"text":"${nope(1)}"}`, "nope"},
+		{"error location", `{"body":[{},{"text":"${(1"}]}`, "/body/1/text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExpandTemplate([]byte(tt.template), map[string]interface{}{"title": "x"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ExpandTemplate() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTemplateKeepsKeyOrder(t *testing.T) {
+	out, err := ExpandTemplate([]byte(`{"z":1,"type":"AdaptiveCard","a":"${x}"}`), map[string]int{"x": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"z":1,"type":"AdaptiveCard","a":2}`; string(out) != want {
+		t.Errorf("ExpandTemplate() = %s, want %s", out, want)
+	}
+}
+
+func TestExpandTemplateCard(t *testing.T) {
+	card, err := ExpandTemplateCard([]byte(`{"type":"AdaptiveCard","version":"1.5","body":[
+		{"$data":"${alerts}","type":"TextBlock","text":"${name}","color":"${if(firing, 'attention', 'good')}"}]}`),
+		map[string]interface{}{"alerts": []map[string]interface{}{{"name": "cpu", "firing": true}, {"name": "disk", "firing": false}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(card.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"type":"TextBlock","text":"cpu","color":"attention"},{"type":"TextBlock","text":"disk","color":"good"}]`; string(data) != want {
+		t.Errorf("body = %s, want %s", data, want)
+	}
+}
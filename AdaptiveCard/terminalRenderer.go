@@ -0,0 +1,1042 @@
+package teams
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The terminal renderer draws a card as box-drawing text with ANSI colours, e.g. to preview cards while developing
+// them. Columns are laid out side by side as long as they fit into the width, actions are numbered and the URLs of
+// Action.OpenUrl and the cards of Action.ShowCard are listed below them. Widths are counted in runes, so wide
+// characters like emoji may misalign borders.
+
+// TerminalRenderer renders cards for terminals
+type TerminalRenderer struct {
+	// The width of the card in columns, including its border. 80 if 0
+	Width int
+	// If true, no ANSI escape codes are written, e.g. when the output isn’t a terminal
+	NoColor bool
+}
+
+// RenderTerminal renders the card with ANSI colours, width columns wide
+func RenderTerminal(card *AdaptiveCard, width int) string {
+	return (&TerminalRenderer{Width: width}).Render(card)
+}
+
+// Render renders the card for a terminal
+func (t *TerminalRenderer) Render(card *AdaptiveCard) string {
+	width := t.Width
+	if width <= 0 {
+		width = 80
+	}
+	if width < 12 {
+		width = 12
+	}
+
+	r := &terminalRenderer{color: !t.NoColor, styles: []ContainerStyle{ContainerStyleDefault}}
+	if card == nil {
+		card = &AdaptiveCard{}
+	}
+	card = card.Clone()
+	stripControlChars(reflect.ValueOf(card))
+
+	return strings.Join(r.box(r.card(card, width-4), width-4, ""), "\n") + "\n"
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+const ansiReset = "\x1b[0m"
+
+// SGR parameters of the foreground colours
+var terminalColors = map[Colors]string{
+	ColorDark:      "90",
+	ColorLight:     "97",
+	ColorAccent:    "34",
+	ColorGood:      "32",
+	ColorWarning:   "33",
+	ColorAttention: "31",
+}
+
+// SGR parameters of the borders of containers with a style
+var terminalContainerColors = map[ContainerStyle]string{
+	ContainerStyleEmphasis:  "90",
+	ContainerStyleAccent:    "34",
+	ContainerStyleGood:      "32",
+	ContainerStyleWarning:   "33",
+	ContainerStyleAttention: "31",
+}
+
+type terminalRenderer struct {
+	color bool
+	// the styles of the enclosing containers
+	styles []ContainerStyle
+	// the number of actions rendered so far, to number them across the whole card
+	actions int
+}
+
+// A span is a run of text with the same SGR parameters
+type span struct {
+	text string
+	sgr  string
+}
+
+// sgr wraps s in the given SGR parameters
+func (r *terminalRenderer) sgr(s string, params ...string) string {
+	var p []string
+	seen := map[string]bool{}
+	for _, param := range params {
+		for _, code := range strings.Split(param, ";") {
+			if code != "" && !seen[code] {
+				seen[code] = true
+				p = append(p, code)
+			}
+		}
+	}
+	if !r.color || len(p) == 0 || s == "" {
+		return s
+	}
+
+	return "\x1b[" + strings.Join(p, ";") + "m" + s + ansiReset
+}
+
+func textSGR(c Colors, weight FontWeight, subtle bool) string {
+	var p []string
+	if weight == FontWeightBolder {
+		p = append(p, "1")
+	}
+	if subtle {
+		p = append(p, "2")
+	}
+	if code, ok := terminalColors[c]; ok {
+		p = append(p, code)
+	}
+
+	return strings.Join(p, ";")
+}
+
+// stripControlChars removes the control characters except newlines and tabs from the strings of a card, since
+// terminals would run the escape sequences they start, like changing the window title or clearing the screen
+func stripControlChars(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			stripControlChars(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		// the string of an interface can’t be changed in place, e.g. the width of a column
+		if e := v.Elem(); e.Kind() == reflect.String {
+			if v.CanSet() {
+				v.Set(reflect.ValueOf(withoutControlChars(e.String())).Convert(e.Type()))
+			}
+		} else {
+			stripControlChars(e)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				stripControlChars(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			stripControlChars(v.Index(i))
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(withoutControlChars(v.String()))
+		}
+	}
+}
+
+// withoutControlChars returns s without C0 and C1 control characters except newlines and tabs. Invalid UTF-8, like
+// a lone 8-bit CSI, is replaced by U+FFFD
+func withoutControlChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// visibleWidth returns the number of runes of s without escape codes
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// pad pads s with spaces to w visible runes
+func pad(s string, w int) string {
+	if n := visibleWidth(s); n < w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	return s
+}
+
+// align pads s to w visible runes, positioning it as given
+func align(s string, w int, a HorizontalAlignment) string {
+	n := visibleWidth(s)
+	if n >= w {
+		return s
+	}
+
+	switch a {
+	case HorizontalAlignmentCenter:
+		left := (w - n) / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", w-n-left)
+	case HorizontalAlignmentRight:
+		return strings.Repeat(" ", w-n) + s
+	}
+
+	return s + strings.Repeat(" ", w-n)
+}
+
+// truncate shortens plain text to w runes, ending with an ellipsis
+func truncate(s string, w int) string {
+	if utf8.RuneCountInString(s) <= w {
+		return s
+	}
+	if w <= 1 {
+		return string([]rune(s)[:w])
+	}
+
+	return string([]rune(s)[:w-1]) + "…"
+}
+
+// wrap breaks the spans into lines of at most w visible runes at spaces, breaking words longer than a line. Line
+// breaks in the text start a new line
+func (r *terminalRenderer) wrap(spans []span, w int) []string {
+	var lines []string
+	var line, run strings.Builder
+	runSGR := ""
+	n := 0
+	pending := "" // the space before the next word, written only if the word fits
+
+	// write adds text to the line, collecting text with the same SGR parameters into a single escape sequence
+	write := func(text string, sgr string) {
+		if sgr != runSGR {
+			line.WriteString(r.sgr(run.String(), runSGR))
+			run.Reset()
+			runSGR = sgr
+		}
+		run.WriteString(text)
+		n += utf8.RuneCountInString(text)
+	}
+	flush := func() {
+		line.WriteString(r.sgr(run.String(), runSGR))
+		lines = append(lines, line.String())
+		line.Reset()
+		run.Reset()
+		n = 0
+		pending = ""
+	}
+
+	for _, s := range spans {
+		for i, paragraph := range strings.Split(s.text, "\n") {
+			if i > 0 {
+				flush()
+			}
+			for j, word := range strings.Split(paragraph, " ") {
+				if j > 0 {
+					pending += " "
+				}
+				if word == "" {
+					continue
+				}
+
+				for word != "" {
+					runes := utf8.RuneCountInString(word)
+					if n > 0 && n+len(pending)+runes > w {
+						flush()
+					}
+					if n == 0 {
+						pending = ""
+					}
+					part := word
+					if runes > w-n-len(pending) {
+						part = string([]rune(word)[:w-n-len(pending)])
+					}
+					write(pending, runSGR)
+					write(part, s.sgr)
+					pending = ""
+					word = word[len(part):]
+					if word != "" {
+						flush()
+					}
+				}
+			}
+		}
+	}
+	if n > 0 || len(lines) == 0 {
+		flush()
+	}
+
+	return lines
+}
+
+var terminalMarkdown = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__|\[([^\]]*)\]\(([^)\s]*)\)`)
+
+// markdownSpans converts markdown to spans, with bold text in bold and links followed by their URL
+func markdownSpans(text string, sgr string) []span {
+	text, escaped := hideEscapes(text)
+	restore := func(s string) string {
+		s = markdownEmph.ReplaceAllString(s, "$1$2")
+		var consumed int
+		s = restoreEscapes(s, escaped, func(c string) string { consumed++; return c })
+		escaped = escaped[consumed:]
+		return s
+	}
+
+	var spans []span
+	last := 0
+	for _, m := range terminalMarkdown.FindAllStringSubmatchIndex(text, -1) {
+		spans = append(spans, span{restore(text[last:m[0]]), sgr})
+		switch {
+		case m[2] >= 0:
+			spans = append(spans, span{restore(text[m[2]:m[3]]), joinSGR(sgr, "1")})
+		case m[4] >= 0:
+			spans = append(spans, span{restore(text[m[4]:m[5]]), joinSGR(sgr, "1")})
+		default:
+			title, url := restore(text[m[6]:m[7]]), text[m[8]:m[9]]
+			if title != "" && title != url {
+				spans = append(spans, span{title + " ", joinSGR(sgr, "4")})
+				url = "(" + url + ")"
+			}
+			spans = append(spans, span{url, joinSGR(sgr, "2")})
+		}
+		last = m[1]
+	}
+
+	return append(spans, span{restore(text[last:]), sgr})
+}
+
+func joinSGR(a string, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + ";" + b
+}
+
+// box draws a border around lines of width w, in the colour of the container style
+func (r *terminalRenderer) box(lines []string, w int, style ContainerStyle) []string {
+	color := terminalContainerColors[style]
+	out := make([]string, 0, len(lines)+2)
+	out = append(out, r.sgr("┌"+strings.Repeat("─", w+2)+"┐", color))
+	for _, line := range lines {
+		out = append(out, r.sgr("│", color)+" "+pad(line, w)+" "+r.sgr("│", color))
+	}
+
+	return append(out, r.sgr("└"+strings.Repeat("─", w+2)+"┘", color))
+}
+
+func (r *terminalRenderer) card(card *AdaptiveCard, w int) []string {
+	lines := r.elements(card.Body, w)
+	if actions := r.actionLines(card.Actions, w); len(actions) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, actions...)
+	}
+
+	return lines
+}
+
+func (r *terminalRenderer) elements(elements []Element, w int) []string {
+	var lines []string
+	first := true
+	for _, el := range elements {
+		if el == nil || isHidden(el) {
+			continue
+		}
+
+		block := r.element(el, w)
+		if len(block) == 0 {
+			continue
+		}
+
+		if !first {
+			switch spacing, separator := itemSpacing(el); {
+			case separator:
+				lines = append(lines, r.sgr(strings.Repeat("─", w), "2"))
+			case spacing == SpacingMedium || spacing == SpacingLarge || spacing == SpacingExtraLarge || spacing == SpacingPadding:
+				lines = append(lines, "")
+			}
+		}
+		first = false
+
+		lines = append(lines, block...)
+	}
+
+	return lines
+}
+
+func (r *terminalRenderer) element(el Element, w int) []string {
+	switch el := el.(type) {
+	case *TextBlock:
+		return r.textBlock(el, w)
+	case *RichTextBlock:
+		var spans []span
+		for _, run := range el.Inlines {
+			sgr := textSGR(run.Color, run.Weight, run.IsSubtle)
+			if run.Italic {
+				sgr = joinSGR(sgr, "3")
+			}
+			if run.Underline {
+				sgr = joinSGR(sgr, "4")
+			}
+			if run.Strikethrough {
+				sgr = joinSGR(sgr, "9")
+			}
+			spans = append(spans, span{expandTextFunctions(run.Text, time.UTC), sgr})
+		}
+		return r.aligned(r.wrap(spans, w), w, el.HorizontalAlignment)
+	case *Image:
+		return r.image(el, w)
+	case *ImageSet:
+		var lines []string
+		for i := range el.Images {
+			if !isHidden(&el.Images[i]) {
+				lines = append(lines, r.image(&el.Images[i], w)...)
+			}
+		}
+		return lines
+	case *Media:
+		title := el.AltText
+		if title == "" {
+			title = "Media"
+		}
+		if len(el.Sources) > 0 {
+			title += " (" + el.Sources[0].Url + ")"
+		}
+		return r.wrap([]span{{"▶ " + title, "2"}}, w)
+	case *CodeBlock:
+		return r.codeBlock(el, w)
+	case *ProgressBar:
+		return []string{r.progressBar(el, w)}
+	case *ProgressRing:
+		return r.wrap([]span{{"◌ ", terminalColors[ColorAccent]}, {el.Label, ""}}, w)
+	case *Badge:
+		return r.aligned([]string{r.badge(el.Text, el.Style, w)}, w, el.HorizontalAlignment)
+	case *CompoundButton:
+		return r.compoundButton(el, w)
+	case *FactSet:
+		return r.factSet(el, w)
+	case *Container:
+		return r.container(el.Style, el.Items, w)
+	case *ColumnSet:
+		return r.columnSet(el, w)
+	case *Table:
+		return r.table(el, w)
+	case *Carousel:
+		return r.carousel(el, w)
+	case *ActionSet:
+		return r.actionLines(el.Actions, w)
+	case *InputText:
+		return r.input(el.Label, el.IsRequired, el.Value, el.Placeholder, w)
+	case *InputNumber:
+		value := ""
+		if el.Value != nil {
+			value = strconv.FormatFloat(*el.Value, 'f', -1, 64)
+		}
+		return r.input(el.Label, el.IsRequired, value, el.Placeholder, w)
+	case *InputDate:
+		return r.input(el.Label, el.IsRequired, el.Value, el.Placeholder, w)
+	case *InputTime:
+		return r.input(el.Label, el.IsRequired, el.Value, el.Placeholder, w)
+	case *InputToggle:
+		on := el.ValueOn
+		if on == "" {
+			on = "true"
+		}
+		check := "[ ] "
+		if el.Value == on {
+			check = "[x] "
+		}
+		return append(r.label(el.Label, el.IsRequired, w), r.wrap([]span{{check + el.Title, ""}}, w)...)
+	case *InputChoiceSet:
+		return r.inputChoiceSet(el, w)
+	case *InputRating:
+		max := int(el.Max)
+		if max <= 0 {
+			max = 5
+		}
+		stars := strings.Repeat("★", int(el.Value)) + strings.Repeat("☆", max-int(el.Value))
+		return append(r.label(el.Label, el.IsRequired, w), r.sgr(truncate(stars, w), terminalColors[ColorWarning]))
+	}
+
+	return nil
+}
+
+func (r *terminalRenderer) aligned(lines []string, w int, a HorizontalAlignment) []string {
+	for i, line := range lines {
+		lines[i] = align(line, w, a)
+	}
+	return lines
+}
+
+func (r *terminalRenderer) textBlock(t *TextBlock, w int) []string {
+	text := strings.TrimSpace(expandTextFunctions(t.Text, time.UTC))
+	if text == "" {
+		return nil
+	}
+
+	weight := t.Weight
+	sgr := textSGR(t.Color, weight, t.IsSubtle)
+	level := headingLevel(t)
+	if level > 0 {
+		sgr = joinSGR(sgr, "1")
+	}
+	if level == 1 {
+		sgr = joinSGR(sgr, "4")
+	}
+
+	if !t.Wrap {
+		text = truncate(stripMarkdown(strings.Join(strings.Fields(text), " ")), w)
+		return r.aligned([]string{r.sgr(text, sgr)}, w, t.HorizontalAlignment)
+	}
+
+	lines := r.wrap(markdownSpans(text, sgr), w)
+	if t.MaxLines > 0 && len(lines) > t.MaxLines {
+		lines = lines[:t.MaxLines]
+		lines[len(lines)-1] = strings.TrimRight(lines[len(lines)-1], " ")
+		if visibleWidth(lines[len(lines)-1]) < w {
+			lines[len(lines)-1] += "…"
+		}
+	}
+
+	return r.aligned(lines, w, t.HorizontalAlignment)
+}
+
+func (r *terminalRenderer) image(i *Image, w int) []string {
+	text := "[image"
+	if i.AltText != "" {
+		text += ": " + i.AltText
+	}
+	text += "]"
+
+	return r.aligned(r.wrap([]span{{text, "2"}}, w), w, i.HorizontalAlignment)
+}
+
+func (r *terminalRenderer) codeBlock(c *CodeBlock, w int) []string {
+	var lines []string
+	if c.Language != "" && c.Language != CodeLanguagePlainText {
+		lines = append(lines, r.sgr(truncate(string(c.Language), w), "2"))
+	}
+
+	number := c.StartLineNumber
+	if number <= 0 {
+		number = 1
+	}
+	code := strings.Split(strings.TrimRight(c.CodeSnippet, "\n"), "\n")
+	digits := len(strconv.Itoa(number + len(code) - 1))
+	for _, line := range code {
+		prefix := fmt.Sprintf("%*d │ ", digits, number)
+		number++
+		line = strings.ReplaceAll(line, "\t", "    ")
+		lines = append(lines, r.sgr(prefix, "2")+r.sgr(truncate(line, w-len([]rune(prefix))), "36"))
+	}
+
+	return lines
+}
+
+func (r *terminalRenderer) progressBar(p *ProgressBar, w int) string {
+	color := terminalColors[p.Color]
+	if color == "" {
+		color = terminalColors[ColorAccent]
+	}
+
+	if p.Value == nil {
+		return r.sgr(strings.Repeat("░", w), color)
+	}
+
+	max := p.Max
+	if max <= 0 {
+		max = 100
+	}
+	ratio := *p.Value / max
+	if ratio < 0 {
+		ratio = 0
+	} else if ratio > 1 {
+		ratio = 1
+	}
+
+	label := fmt.Sprintf(" %3.0f%%", ratio*100)
+	bar := w - len(label)
+	filled := int(ratio*float64(bar) + 0.5)
+
+	return r.sgr(strings.Repeat("█", filled), color) + r.sgr(strings.Repeat("░", bar-filled), "2") + label
+}
+
+// badge renders text on a background in the colour of the badge style
+func (r *terminalRenderer) badge(text string, style BadgeStyle, w int) string {
+	background := map[BadgeStyle]string{
+		BadgeStyleAccent:      "44",
+		BadgeStyleInformative: "44",
+		BadgeStyleGood:        "42",
+		BadgeStyleAttention:   "41",
+		BadgeStyleWarning:     "43",
+	}[style]
+	if background == "" {
+		background = "100"
+	}
+	if !r.color {
+		return truncate("["+text+"]", w)
+	}
+
+	return r.sgr(" "+truncate(text, w-2)+" ", background, "97")
+}
+
+func (r *terminalRenderer) compoundButton(c *CompoundButton, w int) []string {
+	title := []span{{c.Title, "1"}}
+	if c.Badge != "" {
+		title = append(title, span{" ", ""}, span{"[" + c.Badge + "]", terminalColors[ColorAccent]})
+	}
+
+	lines := r.wrap(title, w-4)
+	if c.Description != "" {
+		lines = append(lines, r.wrap([]span{{c.Description, "2"}}, w-4)...)
+	}
+
+	return r.box(lines, w-4, "")
+}
+
+func (r *terminalRenderer) factSet(f *FactSet, w int) []string {
+	titleWidth := 0
+	for _, fact := range f.Facts {
+		if n := utf8.RuneCountInString(stripMarkdown(expandTextFunctions(fact.Title, time.UTC))); n > titleWidth {
+			titleWidth = n
+		}
+	}
+	if max := w / 3; titleWidth > max {
+		titleWidth = max
+	}
+
+	var lines []string
+	for _, fact := range f.Facts {
+		titles := r.wrap([]span{{stripMarkdown(expandTextFunctions(fact.Title, time.UTC)), "1"}}, titleWidth)
+		values := r.wrap(markdownSpans(expandTextFunctions(fact.Value, time.UTC), ""), w-titleWidth-2)
+		for i := 0; i < len(titles) || i < len(values); i++ {
+			var title, value string
+			if i < len(titles) {
+				title = titles[i]
+			}
+			if i < len(values) {
+				value = values[i]
+			}
+			lines = append(lines, pad(title, titleWidth)+"  "+value)
+		}
+	}
+
+	return lines
+}
+
+// container renders items, in a box in the colour of the style if it differs from the enclosing one
+func (r *terminalRenderer) container(style ContainerStyle, items []Element, w int) []string {
+	if style == "" || style == r.style() {
+		return r.elements(items, w)
+	}
+
+	r.styles = append(r.styles, style)
+	defer func() { r.styles = r.styles[:len(r.styles)-1] }()
+
+	lines := r.elements(items, w-4)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return r.box(lines, w-4, style)
+}
+
+func (r *terminalRenderer) style() ContainerStyle {
+	return r.styles[len(r.styles)-1]
+}
+
+// minColumnWidth is the narrowest a column is laid out side by side; narrower columns are stacked
+const minColumnWidth = 8
+
+func (r *terminalRenderer) columnSet(c *ColumnSet, w int) []string {
+	if c.Style != "" && c.Style != r.style() {
+		r.styles = append(r.styles, c.Style)
+		defer func() { r.styles = r.styles[:len(r.styles)-1] }()
+
+		inner := *c
+		inner.Style = ""
+		if lines := r.columnSet(&inner, w-4); len(lines) > 0 {
+			return r.box(lines, w-4, c.Style)
+		}
+		return nil
+	}
+
+	var columns []*Column
+	for i := range c.Columns {
+		if !isHidden(&c.Columns[i]) {
+			columns = append(columns, &c.Columns[i])
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	// the gaps between columns: a space, or a line for separators
+	gaps := make([]int, len(columns))
+	available := w
+	for i, col := range columns[1:] {
+		gaps[i+1] = 2
+		if col.Separator {
+			gaps[i+1] = 3
+		}
+		available -= gaps[i+1]
+	}
+
+	widths := r.columnWidths(columns, available)
+	if widths == nil {
+		// stack the columns if they don’t fit
+		var lines []string
+		for _, col := range columns {
+			lines = append(lines, r.container(col.Style, col.Items, w)...)
+		}
+		return lines
+	}
+
+	rendered := make([][]string, len(columns))
+	height := 0
+	for i, col := range columns {
+		rendered[i] = r.container(col.Style, col.Items, widths[i])
+		if len(rendered[i]) > height {
+			height = len(rendered[i])
+		}
+	}
+
+	lines := make([]string, height)
+	for n := range lines {
+		var b strings.Builder
+		for i := range columns {
+			switch gaps[i] {
+			case 2:
+				b.WriteString("  ")
+			case 3:
+				b.WriteString(" " + r.sgr("│", "2") + " ")
+			}
+			line := ""
+			if n < len(rendered[i]) {
+				line = rendered[i][n]
+			}
+			b.WriteString(pad(line, widths[i]))
+		}
+		lines[n] = b.String()
+	}
+
+	return lines
+}
+
+// columnWidths distributes the available width among the columns according to their widths, or returns nil if
+// they don’t fit side by side
+func (r *terminalRenderer) columnWidths(columns []*Column, available int) []int {
+	widths := make([]int, len(columns))
+	weights := make([]float64, len(columns))
+	narrow := make([]bool, len(columns))
+	total := 0.0
+	remaining := available
+
+	for i, col := range columns {
+		switch w := col.Width.(type) {
+		case string:
+			switch {
+			case w == "auto":
+				// as wide as the content, at most an equal share. Rendering it mustn't count its actions twice
+				natural := 0
+				actions := r.actions
+				for _, line := range r.elements(col.Items, available) {
+					if n := visibleWidth(strings.TrimRight(line, " ")); n > natural {
+						natural = n
+					}
+				}
+				r.actions = actions
+				if share := available / len(columns); natural > share {
+					natural = share
+				} else {
+					// content narrower than a column still fits
+					narrow[i] = true
+				}
+				widths[i] = natural
+				remaining -= natural
+				continue
+			case strings.HasSuffix(w, "px"):
+				// assume 8 pixels per column
+				px, _ := strconv.Atoi(strings.TrimSuffix(w, "px"))
+				widths[i] = px / 8
+				remaining -= widths[i]
+				continue
+			}
+		}
+		weights[i] = columnWeight(col.Width)
+		if weights[i] <= 0 {
+			weights[i] = 1
+		}
+		total += weights[i]
+	}
+
+	if total > 0 {
+		distributed := 0
+		last := -1
+		for i := range columns {
+			if weights[i] > 0 {
+				widths[i] = int(float64(remaining) * weights[i] / total)
+				distributed += widths[i]
+				last = i
+			}
+		}
+		// rounding leftovers go to the last weighted column
+		widths[last] += remaining - distributed
+	}
+
+	for i, w := range widths {
+		if w < minColumnWidth && !(narrow[i] && w > 0) {
+			return nil
+		}
+	}
+
+	return widths
+}
+
+func (r *terminalRenderer) table(t *Table, w int) []string {
+	columns := len(t.Columns)
+	for _, row := range t.Rows {
+		if columns == 0 && len(row.Cells) > columns {
+			columns = len(row.Cells)
+		}
+	}
+	if columns == 0 || len(t.Rows) == 0 {
+		return nil
+	}
+
+	// every column takes 3 runes for its border and padding, plus one for the closing border
+	available := w - 3*columns - 1
+	weights := make([]float64, columns)
+	total := 0.0
+	for i := range weights {
+		weights[i] = 1
+		if i < len(t.Columns) {
+			if wt := columnWeight(t.Columns[i].Width); wt > 0 {
+				weights[i] = wt
+			}
+		}
+		total += weights[i]
+	}
+	widths := make([]int, columns)
+	used := 0
+	for i := range widths {
+		widths[i] = int(float64(available) * weights[i] / total)
+		used += widths[i]
+	}
+	widths[columns-1] += available - used
+	for _, cw := range widths {
+		if cw < 1 {
+			return r.wrap([]span{{"[table too wide]", "2"}}, w)
+		}
+	}
+
+	rule := func(left, mid, right string) string {
+		parts := make([]string, columns)
+		for i, cw := range widths {
+			parts[i] = strings.Repeat("─", cw+2)
+		}
+		return r.sgr(left+strings.Join(parts, mid)+right, "2")
+	}
+
+	header := t.FirstRowAsHeader == nil || *t.FirstRowAsHeader
+	lines := []string{rule("┌", "┬", "┐")}
+	for n := range t.Rows {
+		row := &t.Rows[n]
+		cells := make([][]string, columns)
+		height := 1
+		for i := 0; i < columns && i < len(row.Cells); i++ {
+			cells[i] = r.container(row.Cells[i].Style, row.Cells[i].Items, widths[i])
+			if n == 0 && header {
+				for j, line := range cells[i] {
+					cells[i][j] = r.sgr(ansiEscape.ReplaceAllString(line, ""), "1")
+				}
+			}
+			if len(cells[i]) > height {
+				height = len(cells[i])
+			}
+		}
+
+		for j := 0; j < height; j++ {
+			var b strings.Builder
+			for i := range cells {
+				b.WriteString(r.sgr("│", "2") + " ")
+				line := ""
+				if j < len(cells[i]) {
+					line = cells[i][j]
+				}
+				b.WriteString(pad(line, widths[i]) + " ")
+			}
+			b.WriteString(r.sgr("│", "2"))
+			lines = append(lines, b.String())
+		}
+		if n < len(t.Rows)-1 && (n == 0 && header || t.ShowGridLines == nil || *t.ShowGridLines) {
+			lines = append(lines, rule("├", "┼", "┤"))
+		}
+	}
+
+	return append(lines, rule("└", "┴", "┘"))
+}
+
+func (r *terminalRenderer) carousel(c *Carousel, w int) []string {
+	var pages []*CarouselPage
+	for i := range c.Pages {
+		if !isHidden(&c.Pages[i]) {
+			pages = append(pages, &c.Pages[i])
+		}
+	}
+
+	var lines []string
+	for i, page := range pages {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, r.sgr(fmt.Sprintf("‹ %d/%d ›", i+1, len(pages)), "2"))
+		lines = append(lines, r.container(page.Style, page.Items, w)...)
+	}
+
+	return lines
+}
+
+func (r *terminalRenderer) label(label string, required bool, w int) []string {
+	if label == "" {
+		return nil
+	}
+
+	spans := []span{{label, ""}}
+	if required {
+		spans = append(spans, span{" *", terminalColors[ColorAttention]})
+	}
+
+	return r.wrap(spans, w)
+}
+
+func (r *terminalRenderer) input(label string, required bool, value string, placeholder string, w int) []string {
+	text := r.sgr(truncate(value, w-4), "")
+	if value == "" {
+		text = r.sgr(truncate(placeholder, w-4), "2")
+	}
+
+	return append(r.label(label, required, w), r.sgr("[", "2")+" "+pad(text, w-4)+" "+r.sgr("]", "2"))
+}
+
+func (r *terminalRenderer) inputChoiceSet(c *InputChoiceSet, w int) []string {
+	selected := map[string]bool{}
+	for _, v := range strings.Split(c.Value, ",") {
+		selected[strings.TrimSpace(v)] = true
+	}
+
+	lines := r.label(c.Label, c.IsRequired, w)
+	if c.Style != ChoiceInputStyleExpanded && !c.IsMultiSelect {
+		value := ""
+		for _, choice := range c.Choices {
+			if selected[choice.Value] {
+				value = choice.Title
+			}
+		}
+		text := r.sgr(truncate(value, w-6), "")
+		if value == "" {
+			text = r.sgr(truncate(c.Placeholder, w-6), "2")
+		}
+		return append(lines, r.sgr("[", "2")+" "+pad(text, w-6)+" ▾ "+r.sgr("]", "2"))
+	}
+
+	for _, choice := range c.Choices {
+		mark := "( ) "
+		if c.IsMultiSelect {
+			mark = "[ ] "
+		}
+		if selected[choice.Value] {
+			mark = "(•) "
+			if c.IsMultiSelect {
+				mark = "[x] "
+			}
+		}
+		lines = append(lines, r.wrap([]span{{mark + choice.Title, ""}}, w)...)
+	}
+
+	return lines
+}
+
+// actionLines renders actions as numbered buttons, followed by the URLs of Action.OpenUrl and the cards of
+// Action.ShowCard
+func (r *terminalRenderer) actionLines(actions []Action, w int) []string {
+	var buttons []span
+	var notes []string
+	type showCard struct {
+		number int
+		action *ActionShowCard
+	}
+	var cards []showCard
+
+	for _, a := range actions {
+		var title string
+		var style ActionStyle
+		switch a := a.(type) {
+		case *ActionOpenUrl:
+			title, style = a.Title, a.Style
+		case *ActionSubmit:
+			title, style = a.Title, a.Style
+		case *ActionShowCard:
+			title, style = a.Title+" ▾", a.Style
+		case *ActionToggleVisibility:
+			title, style = a.Title, a.Style
+		case *ActionExecute:
+			title, style = a.Title, a.Style
+		default:
+			continue
+		}
+
+		r.actions++
+		sgr := "1"
+		switch style {
+		case ActionStylePositive:
+			sgr = joinSGR(sgr, terminalColors[ColorAccent])
+		case ActionStyleDestructive:
+			sgr = joinSGR(sgr, terminalColors[ColorAttention])
+		}
+		if len(buttons) > 0 {
+			buttons = append(buttons, span{" ", ""})
+		}
+		buttons = append(buttons, span{strings.ReplaceAll(fmt.Sprintf("[%d %s]", r.actions, title), " ", "\u00a0"), sgr})
+
+		switch a := a.(type) {
+		case *ActionOpenUrl:
+			notes = append(notes, r.wrap([]span{{fmt.Sprintf("%d: %s", r.actions, a.Url), "2"}}, w)...)
+		case *ActionShowCard:
+			cards = append(cards, showCard{r.actions, a})
+		}
+	}
+	if len(buttons) == 0 {
+		return nil
+	}
+
+	// buttons are kept together by non-breaking spaces while wrapping
+	lines := r.wrap(buttons, w)
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\u00a0", " ")
+	}
+	lines = append(lines, notes...)
+
+	for _, c := range cards {
+		lines = append(lines, r.sgr(truncate(fmt.Sprintf("%d: %s", c.number, c.action.Title), w), "2"))
+		r.styles = append(r.styles, ContainerStyleEmphasis)
+		lines = append(lines, r.box(r.card(&c.action.Card, w-4), w-4, ContainerStyleEmphasis)...)
+		r.styles = r.styles[:len(r.styles)-1]
+	}
+
+	return lines
+}
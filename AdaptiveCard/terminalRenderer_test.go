@@ -0,0 +1,35 @@
+package teams
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderTerminalStripsControlChars(t *testing.T) {
+	const evil = "\x1b]0;pwned\a \x1b[2J\u009b31m\x9b"
+	text := NewTextBlock("a" + evil + "b")
+	text.Wrap = true
+	open := NewActionOpenUrl()
+	open.Title = "open" + evil
+	open.Url = "https://example.com/" + evil
+	card := NewAdaptiveCard()
+	card.Body = append(card.Body, text, NewFactSet(Fact{Title: "t" + evil, Value: "v" + evil}))
+	card.Actions = append(card.Actions, open)
+
+	out := (&TerminalRenderer{Width: 40, NoColor: true}).Render(card)
+	for _, r := range out {
+		if r < 0x20 && r != '\n' && r != '\t' || r >= 0x7f && r <= 0x9f {
+			t.Fatalf("output contains control character %U:\n%q", r, out)
+		}
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if n := utf8.RuneCountInString(line); n != 40 {
+			t.Errorf("line %d is %d runes wide, want 40: %q", i, n, line)
+		}
+	}
+
+	if !strings.Contains(text.Text, "\x1b") {
+		t.Fatal("Render modified the card")
+	}
+}
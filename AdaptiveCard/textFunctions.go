@@ -0,0 +1,199 @@
+package teams
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The text of TextBlock, Fact.Title and Fact.Value may contain DATE() and TIME() functions which every client
+// formats in the locale and timezone of the user reading the card, e.g. {{DATE(2017-02-14T06:08:39Z, SHORT)}}.
+//
+// Source: https://learn.microsoft.com/en-us/adaptive-cards/authoring-cards/text-features#datetime-formatting-and-localization
+
+type DateFormat string
+
+const (
+	// e.g. 2/14/2017
+	DateFormatCompact DateFormat = "COMPACT"
+	// e.g. Tue, Feb 14, 2017
+	DateFormatShort DateFormat = "SHORT"
+	// e.g. Tuesday, February 14, 2017
+	DateFormatLong DateFormat = "LONG"
+)
+
+type TextFunctionKind string
+
+const (
+	TextFunctionDate TextFunctionKind = "DATE"
+	TextFunctionTime TextFunctionKind = "TIME"
+)
+
+// the timestamp of DATE() and TIME() must be RFC 3339 without fractional seconds
+const textFunctionLayout = "2006-01-02T15:04:05Z07:00"
+
+var (
+	textFunctionToken     = regexp.MustCompile(`\{\{\s*(DATE|TIME)\s*\(([^{}]*)\)\s*\}\}`)
+	textFunctionCandidate = regexp.MustCompile(`\{\{\s*(?i:date|time)\b[^}]*\}?\}?`)
+	textFunctionTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)
+)
+
+// FormatDate returns a DATE() function displaying the date of t in the locale and timezone of the reader
+func FormatDate(t time.Time, format DateFormat) string {
+	if format == "" || format == DateFormatCompact {
+		return fmt.Sprintf("{{DATE(%s)}}", t.UTC().Format(textFunctionLayout))
+	}
+
+	return fmt.Sprintf("{{DATE(%s, %s)}}", t.UTC().Format(textFunctionLayout), format)
+}
+
+// FormatTime returns a TIME() function displaying the time of t in the locale and timezone of the reader
+func FormatTime(t time.Time) string {
+	return fmt.Sprintf("{{TIME(%s)}}", t.UTC().Format(textFunctionLayout))
+}
+
+// FormatDateTime returns a DATE() followed by a TIME() function displaying t in the locale and timezone of the reader
+func FormatDateTime(t time.Time, format DateFormat) string {
+	return FormatDate(t, format) + " " + FormatTime(t)
+}
+
+// NewTimeFact returns a fact whose value displays the date and time of t in the locale and timezone of the reader
+func NewTimeFact(title string, t time.Time, format DateFormat) Fact {
+	return Fact{Title: title, Value: FormatDateTime(t, format)}
+}
+
+// A TextFunction is a DATE() or TIME() function found in a text
+type TextFunction struct {
+	// Whether the function is DATE() or TIME()
+	Kind TextFunctionKind
+	// The timestamp passed to the function
+	Time time.Time
+	// The format passed to DATE(), COMPACT if omitted. Empty for TIME()
+	Format DateFormat
+	// The byte offsets of the function in the text
+	Start int
+	End   int
+}
+
+// ParseTextFunctions returns the DATE() and TIME() functions of text, or an error naming the first malformed one,
+// e.g. one with a timestamp that isn’t RFC 3339 or with an unknown format. Malformed functions are displayed
+// verbatim by the clients
+func ParseTextFunctions(text string) ([]TextFunction, error) {
+	var functions []TextFunction
+
+	valid := map[int]bool{}
+	for _, m := range textFunctionToken.FindAllStringSubmatchIndex(text, -1) {
+		f := TextFunction{Kind: TextFunctionKind(text[m[2]:m[3]]), Start: m[0], End: m[1]}
+		if err := f.parseArgs(text[m[4]:m[5]]); err != nil {
+			return nil, fmt.Errorf("%s at offset %d: %w", text[m[0]:m[1]], m[0], err)
+		}
+		functions = append(functions, f)
+		valid[m[0]] = true
+	}
+
+	for _, m := range textFunctionCandidate.FindAllStringIndex(text, -1) {
+		if !valid[m[0]] {
+			return nil, fmt.Errorf("%s at offset %d: malformed function; expected {{DATE(timestamp, format)}} or {{TIME(timestamp)}}", text[m[0]:m[1]], m[0])
+		}
+	}
+
+	return functions, nil
+}
+
+func (f *TextFunction) parseArgs(args string) error {
+	parts := strings.Split(args, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	switch {
+	case f.Kind == TextFunctionTime && len(parts) != 1:
+		return fmt.Errorf("TIME takes 1 argument, got %d", len(parts))
+	case f.Kind == TextFunctionDate && len(parts) > 2:
+		return fmt.Errorf("DATE takes 1 or 2 arguments, got %d", len(parts))
+	}
+
+	if !textFunctionTimestamp.MatchString(parts[0]) {
+		return fmt.Errorf("timestamp %q is not RFC 3339, like 2017-02-14T06:08:39Z", parts[0])
+	}
+	t, err := time.Parse(textFunctionLayout, parts[0])
+	if err != nil {
+		return err
+	}
+	f.Time = t
+
+	if f.Kind == TextFunctionDate {
+		f.Format = DateFormatCompact
+		if len(parts) == 2 {
+			switch format := DateFormat(parts[1]); format {
+			case DateFormatCompact, DateFormatShort, DateFormatLong:
+				f.Format = format
+			default:
+				return fmt.Errorf("unknown format %q; expected %s, %s or %s", parts[1], DateFormatCompact, DateFormatShort, DateFormatLong)
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultTimeLayouts are the layouts LocalizeFactTimes recognizes when none are given
+var defaultTimeLayouts = []string{
+	time.RFC3339,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC822,
+	time.RFC822Z,
+	time.UnixDate,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 MST",
+}
+
+// parseFactTime parses value with the first matching layout. Timestamps with a zone abbreviation unknown to the
+// local timezone database are skipped, since time.Parse would silently assume UTC for them
+func parseFactTime(value string, layouts []string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if name, offset := t.Zone(); offset == 0 && name != "" && name != "UTC" && name != "GMT" && name != "Z" {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+// LocalizeFactTimes converts the values of all facts of the card that hold a timestamp, e.g. one formatted with
+// time.RFC1123, into DATE() and TIME() functions, so that every reader sees it in their own timezone instead of the
+// one of the sender. Values are matched against layouts, or a set of common layouts if none are given. Timestamps
+// whose zone abbreviation can’t be resolved, like "CEST" on a machine in another timezone, are left unchanged; prefer
+// layouts with numeric offsets. It returns the number of converted facts.
+//
+// It only rewrites values that were already formatted as strings; build facts from a time.Time with NewTimeFact
+func (a *AdaptiveCard) LocalizeFactTimes(format DateFormat, layouts ...string) int {
+	if len(layouts) == 0 {
+		layouts = defaultTimeLayouts
+	}
+
+	converted := 0
+	Inspect(a, func(node interface{}, path string) bool {
+		fs, ok := node.(*FactSet)
+		if !ok || fs == nil {
+			return true
+		}
+		for i := range fs.Facts {
+			if t, ok := parseFactTime(fs.Facts[i].Value, layouts); ok {
+				fs.Facts[i].Value = FormatDateTime(t, format)
+				converted++
+			}
+		}
+		return true
+	})
+
+	return converted
+}
@@ -0,0 +1,136 @@
+package teams
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatFunctions(t *testing.T) {
+	zurich := time.FixedZone("CET", 3600)
+	newYork := time.FixedZone("EST", -5*3600)
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"date compact", FormatDate(time.Date(2017, 2, 14, 6, 8, 39, 0, time.UTC), DateFormatCompact), "{{DATE(2017-02-14T06:08:39Z)}}"},
+		{"date default", FormatDate(time.Date(2017, 2, 14, 6, 8, 39, 0, time.UTC), ""), "{{DATE(2017-02-14T06:08:39Z)}}"},
+		{"date short", FormatDate(time.Date(2017, 2, 14, 6, 8, 39, 0, time.UTC), DateFormatShort), "{{DATE(2017-02-14T06:08:39Z, SHORT)}}"},
+		{"date long", FormatDate(time.Date(2017, 2, 14, 6, 8, 39, 0, time.UTC), DateFormatLong), "{{DATE(2017-02-14T06:08:39Z, LONG)}}"},
+		{"date in another zone", FormatDate(time.Date(2017, 2, 14, 0, 30, 0, 0, zurich), DateFormatShort), "{{DATE(2017-02-13T23:30:00Z, SHORT)}}"},
+		{"fractional seconds", FormatTime(time.Date(2017, 2, 14, 6, 8, 39, 999, time.UTC)), "{{TIME(2017-02-14T06:08:39Z)}}"},
+		{"time in another zone", FormatTime(time.Date(2017, 2, 14, 20, 0, 0, 0, newYork)), "{{TIME(2017-02-15T01:00:00Z)}}"},
+		{"date time", FormatDateTime(time.Date(2017, 2, 14, 6, 8, 39, 0, zurich), DateFormatLong), "{{DATE(2017-02-14T05:08:39Z, LONG)}} {{TIME(2017-02-14T05:08:39Z)}}"},
+		{"time fact", NewTimeFact("Started", time.Date(2017, 2, 14, 6, 8, 39, 0, newYork), DateFormatCompact).Value, "{{DATE(2017-02-14T11:08:39Z)}} {{TIME(2017-02-14T11:08:39Z)}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTextFunctions(t *testing.T) {
+	at := time.Date(2017, 2, 14, 6, 8, 39, 0, time.UTC)
+	tests := []struct {
+		name    string
+		text    string
+		want    []TextFunction
+		wantErr string
+	}{
+		{name: "none", text: "no functions {{here}}"},
+		{
+			name: "date without format",
+			text: "on {{DATE(2017-02-14T06:08:39Z)}}",
+			want: []TextFunction{{Kind: TextFunctionDate, Time: at, Format: DateFormatCompact, Start: 3, End: 33}},
+		},
+		{
+			name: "date and time with spaces",
+			text: "{{ DATE( 2017-02-14T06:08:39Z , LONG ) }} {{TIME(2017-02-14T06:08:39Z)}}",
+			want: []TextFunction{
+				{Kind: TextFunctionDate, Time: at, Format: DateFormatLong, Start: 0, End: 41},
+				{Kind: TextFunctionTime, Time: at, Start: 42, End: 72},
+			},
+		},
+		{
+			name: "offset",
+			text: "{{TIME(2017-02-14T07:08:39+01:00)}}",
+			want: []TextFunction{{Kind: TextFunctionTime, Time: at.In(time.FixedZone("", 3600)), Start: 0, End: 35}},
+		},
+		{name: "unknown format", text: "{{DATE(2017-02-14T06:08:39Z, MEDIUM)}}", wantErr: `unknown format "MEDIUM"`},
+		{name: "lower case format", text: "{{DATE(2017-02-14T06:08:39Z, short)}}", wantErr: `unknown format "short"`},
+		{name: "date without time", text: "{{DATE(2017-02-14)}}", wantErr: `timestamp "2017-02-14" is not RFC 3339`},
+		{name: "fractional seconds", text: "{{TIME(2017-02-14T06:08:39.5Z)}}", wantErr: "is not RFC 3339"},
+		{name: "invalid date", text: "{{DATE(2017-02-30T06:08:39Z)}}", wantErr: "day out of range"},
+		{name: "time with format", text: "{{TIME(2017-02-14T06:08:39Z, SHORT)}}", wantErr: "TIME takes 1 argument, got 2"},
+		{name: "date with too many arguments", text: "{{DATE(2017-02-14T06:08:39Z, SHORT, LONG)}}", wantErr: "DATE takes 1 or 2 arguments, got 3"},
+		{name: "lower case function", text: "{{date(2017-02-14T06:08:39Z)}}", wantErr: "malformed function"},
+		{name: "unclosed", text: "{{DATE(2017-02-14T06:08:39Z)", wantErr: "malformed function"},
+		{name: "offset in error", text: "ok {{TIME(2017-02-14T06:08:39Z)}} {{TIME(x)}}", wantErr: "{{TIME(x)}} at offset 34"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTextFunctions(tt.text)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseTextFunctions() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseTextFunctions() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Time.Equal(tt.want[i].Time) {
+					t.Errorf("functions[%d].Time = %v, want %v", i, got[i].Time, tt.want[i].Time)
+				}
+				got[i].Time, tt.want[i].Time = time.Time{}, time.Time{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTextFunctions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFunctionsParse(t *testing.T) {
+	at := time.Date(2017, 2, 14, 6, 8, 39, 0, time.FixedZone("CET", 3600))
+	for _, format := range []DateFormat{DateFormatCompact, DateFormatShort, DateFormatLong} {
+		functions, err := ParseTextFunctions(FormatDateTime(at, format))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(functions) != 2 || functions[0].Format != format || !functions[0].Time.Equal(at) || !functions[1].Time.Equal(at) {
+			t.Errorf("ParseTextFunctions(FormatDateTime(%s)) = %+v", format, functions)
+		}
+	}
+}
+
+func TestLocalizeFactTimes(t *testing.T) {
+	card := NewAdaptiveCard()
+	card.Body = append(card.Body, NewFactSet(
+		Fact{Title: "RFC 1123", Value: "Tue, 14 Feb 2017 06:08:39 UTC"},
+		Fact{Title: "RFC 3339", Value: "2017-02-14T07:08:39+01:00"},
+		Fact{Title: "text", Value: "not a time"},
+	))
+
+	if n := card.LocalizeFactTimes(DateFormatShort); n != 2 {
+		t.Errorf("LocalizeFactTimes() = %d, want 2", n)
+	}
+	want := "{{DATE(2017-02-14T06:08:39Z, SHORT)}} {{TIME(2017-02-14T06:08:39Z)}}"
+	for _, fact := range card.Body[0].(*FactSet).Facts[:2] {
+		if fact.Value != want {
+			t.Errorf("%s = %q, want %q", fact.Title, fact.Value, want)
+		}
+	}
+	if value := card.Body[0].(*FactSet).Facts[2].Value; value != "not a time" {
+		t.Errorf("text = %q, want it unchanged", value)
+	}
+}
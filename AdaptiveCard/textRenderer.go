@@ -0,0 +1,639 @@
+package teams
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// The text renderers mirror a card to places that don’t understand Adaptive Cards, like emails, logs or other chat
+// systems. They render what a reader would see: hidden elements are omitted, columns are flattened into a single
+// column, DATE() and TIME() functions are expanded in UTC and actions become links. Actions that only work inside
+// Teams, like Action.Submit, Action.Execute and Action.ToggleVisibility, are omitted, while the card of an
+// Action.ShowCard is rendered below its title.
+
+// RenderText renders the card as plain text without any markup
+func RenderText(card *AdaptiveCard) string {
+	r := &textRenderer{}
+	return r.render(card)
+}
+
+// MarkdownRenderer renders cards as CommonMark
+type MarkdownRenderer struct {
+	// Render FactSets as tables instead of lists
+	FactsAsTable bool
+}
+
+// RenderMarkdown renders the card as CommonMark, with FactSets as lists
+func RenderMarkdown(card *AdaptiveCard) string {
+	return (&MarkdownRenderer{}).Render(card)
+}
+
+// Render renders the card as CommonMark
+func (m *MarkdownRenderer) Render(card *AdaptiveCard) string {
+	r := &textRenderer{markdown: true, factsAsTable: m.FactsAsTable}
+	return r.render(card)
+}
+
+var (
+	markdownLink   = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)\)`)
+	markdownStrong = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownEmph   = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+)
+
+type textRenderer struct {
+	markdown     bool
+	factsAsTable bool
+}
+
+// render renders a card as blocks separated by blank lines in Markdown and by line breaks in plain text
+func (r *textRenderer) render(card *AdaptiveCard) string {
+	if card == nil {
+		return ""
+	}
+
+	blocks := r.elements(card.Body)
+	if actions := r.actions(card.Actions); len(actions) > 0 {
+		blocks = append(blocks, actions...)
+	}
+
+	return r.join(blocks)
+}
+
+func (r *textRenderer) join(blocks []string) string {
+	if r.markdown {
+		return strings.Join(blocks, "\n\n")
+	}
+	return strings.Join(blocks, "\n")
+}
+
+func (r *textRenderer) elements(elements []Element) []string {
+	var blocks []string
+	for _, el := range elements {
+		if el == nil || isHidden(el) {
+			continue
+		}
+		if block := r.element(el); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+func (r *textRenderer) element(el Element) string {
+	switch el := el.(type) {
+	case *TextBlock:
+		return r.textBlock(el)
+	case *RichTextBlock:
+		return r.richTextBlock(el)
+	case *Image:
+		return r.image(el)
+	case *ImageSet:
+		var blocks []string
+		for i := range el.Images {
+			if !isHidden(&el.Images[i]) {
+				blocks = append(blocks, r.image(&el.Images[i]))
+			}
+		}
+		return r.join(blocks)
+	case *Media:
+		return r.media(el)
+	case *CodeBlock:
+		return r.codeBlock(el)
+	case *ProgressBar:
+		return r.progressBar(el)
+	case *ProgressRing:
+		return r.escape(el.Label)
+	case *Badge:
+		if r.markdown && el.Text != "" {
+			return "**" + r.escape(el.Text) + "**"
+		}
+		return el.Text
+	case *CompoundButton:
+		return r.compoundButton(el)
+	case *FactSet:
+		return r.factSet(el)
+	case *Container:
+		return r.join(r.elements(el.Items))
+	case *ColumnSet:
+		var blocks []string
+		for i := range el.Columns {
+			if !isHidden(&el.Columns[i]) {
+				blocks = append(blocks, r.elements(el.Columns[i].Items)...)
+			}
+		}
+		return r.join(blocks)
+	case *Carousel:
+		var blocks []string
+		for i := range el.Pages {
+			if !isHidden(&el.Pages[i]) {
+				blocks = append(blocks, r.elements(el.Pages[i].Items)...)
+			}
+		}
+		return r.join(blocks)
+	case *Table:
+		return r.table(el)
+	case *ActionSet:
+		return r.join(r.actions(el.Actions))
+	case *InputText:
+		return r.input(el.Label, el.Placeholder, el.Value)
+	case *InputDate:
+		return r.input(el.Label, el.Placeholder, el.Value)
+	case *InputTime:
+		return r.input(el.Label, el.Placeholder, el.Value)
+	case *InputNumber:
+		value := ""
+		if el.Value != nil {
+			value = strconv.FormatFloat(*el.Value, 'f', -1, 64)
+		}
+		return r.input(el.Label, el.Placeholder, value)
+	case *InputRating:
+		max := el.Max
+		if max == 0 {
+			max = 5
+		}
+		return r.input(el.Label, "", strconv.FormatFloat(el.Value, 'f', -1, 64)+"/"+strconv.FormatFloat(max, 'f', -1, 64))
+	case *InputToggle:
+		return r.inputToggle(el)
+	case *InputChoiceSet:
+		return r.inputChoiceSet(el)
+	}
+
+	return ""
+}
+
+// headingLevel returns the Markdown heading level of a TextBlock, or 0 if it isn’t a heading
+func headingLevel(t *TextBlock) int {
+	switch {
+	case t.Size == FontSizeExtraLarge:
+		return 1
+	case t.Size == FontSizeLarge:
+		return 2
+	case t.Style == TextBlockStyleHeading, t.Size == FontSizeMedium && t.Weight == FontWeightBolder:
+		return 3
+	}
+
+	return 0
+}
+
+func (r *textRenderer) textBlock(t *TextBlock) string {
+	text := strings.TrimSpace(expandTextFunctions(t.Text, time.UTC))
+	if text == "" {
+		return ""
+	}
+
+	level := headingLevel(t)
+	if r.markdown {
+		if level > 0 {
+			// headings can’t span several lines
+			return strings.Repeat("#", level) + " " + strings.Join(strings.Fields(text), " ")
+		}
+		return text
+	}
+
+	text = stripMarkdown(text)
+	if level == 1 || level == 2 {
+		underline := "="
+		if level == 2 {
+			underline = "-"
+		}
+		return text + "\n" + strings.Repeat(underline, utf8.RuneCountInString(text))
+	}
+
+	return text
+}
+
+func (r *textRenderer) richTextBlock(rt *RichTextBlock) string {
+	var b strings.Builder
+	for i := range rt.Inlines {
+		run := &rt.Inlines[i]
+		text := expandTextFunctions(run.Text, time.UTC)
+		if !r.markdown {
+			b.WriteString(text)
+			continue
+		}
+
+		// TextRun.Text isn’t markdown
+		text = r.escape(text)
+		if strings.TrimSpace(text) == "" {
+			b.WriteString(text)
+			continue
+		}
+		if run.Weight == FontWeightBolder {
+			text = "**" + text + "**"
+		}
+		if run.Italic {
+			text = "_" + text + "_"
+		}
+		if run.Strikethrough {
+			text = "~~" + text + "~~"
+		}
+		if a, ok := run.SelectAction.(*ActionOpenUrl); ok && a.Url != "" {
+			text = "[" + text + "](" + a.Url + ")"
+		}
+		b.WriteString(text)
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func (r *textRenderer) image(i *Image) string {
+	if i.Url == "" {
+		return ""
+	}
+	if r.markdown {
+		return "![" + r.escape(i.AltText) + "](" + i.Url + ")"
+	}
+	if i.AltText != "" {
+		return i.AltText + " (" + i.Url + ")"
+	}
+
+	return i.Url
+}
+
+func (r *textRenderer) media(m *Media) string {
+	if len(m.Sources) == 0 {
+		return ""
+	}
+	title := m.AltText
+	if title == "" {
+		title = "Media"
+	}
+
+	return r.link(title, m.Sources[0].Url)
+}
+
+func (r *textRenderer) codeBlock(c *CodeBlock) string {
+	code := strings.TrimRight(c.CodeSnippet, "\n")
+	if r.markdown {
+		language := strings.ToLower(string(c.Language))
+		if c.Language == CodeLanguagePlainText {
+			language = ""
+		}
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return fence + language + "\n" + code + "\n" + fence
+	}
+
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = "    " + line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *textRenderer) progressBar(p *ProgressBar) string {
+	const width = 20
+	if p.Value == nil {
+		// indeterminate
+		return "[" + strings.Repeat(".", width) + "]"
+	}
+
+	max := p.Max
+	if max <= 0 {
+		max = 100
+	}
+	ratio := *p.Value / max
+	if ratio < 0 {
+		ratio = 0
+	} else if ratio > 1 {
+		ratio = 1
+	}
+	filled := int(ratio*width + 0.5)
+	bar := "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "] " + fmt.Sprintf("%.0f%%", ratio*100)
+	if r.markdown {
+		return "`" + bar + "`"
+	}
+
+	return bar
+}
+
+func (r *textRenderer) compoundButton(c *CompoundButton) string {
+	title := c.Title
+	if a, ok := c.SelectAction.(*ActionOpenUrl); ok && a.Url != "" {
+		title = r.link(title, a.Url)
+	} else if r.markdown {
+		title = "**" + r.escape(title) + "**"
+	}
+	if c.Description == "" {
+		return title
+	}
+	if r.markdown {
+		return title + "  \n" + r.escape(c.Description)
+	}
+
+	return title + "\n" + c.Description
+}
+
+func (r *textRenderer) factSet(f *FactSet) string {
+	if len(f.Facts) == 0 {
+		return ""
+	}
+
+	titles := make([]string, len(f.Facts))
+	values := make([]string, len(f.Facts))
+	width := 0
+	for i, fact := range f.Facts {
+		titles[i] = expandTextFunctions(fact.Title, time.UTC)
+		values[i] = expandTextFunctions(fact.Value, time.UTC)
+		if !r.markdown {
+			titles[i] = stripMarkdown(titles[i])
+			values[i] = stripMarkdown(values[i])
+		}
+		if n := utf8.RuneCountInString(titles[i]); n > width {
+			width = n
+		}
+	}
+
+	lines := make([]string, 0, len(f.Facts)+2)
+	switch {
+	case r.markdown && r.factsAsTable:
+		lines = append(lines, "| | |", "|---|---|")
+		for i := range titles {
+			lines = append(lines, "| **"+tableCell(titles[i])+"** | "+tableCell(values[i])+" |")
+		}
+	case r.markdown:
+		for i := range titles {
+			lines = append(lines, "- **"+titles[i]+":** "+singleLine(values[i]))
+		}
+	default:
+		for i := range titles {
+			// align the values; continuation lines are indented to the same column
+			value := strings.ReplaceAll(values[i], "\n", "\n"+strings.Repeat(" ", width+2))
+			lines = append(lines, titles[i]+":"+strings.Repeat(" ", width-utf8.RuneCountInString(titles[i])+1)+value)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *textRenderer) table(t *Table) string {
+	if len(t.Rows) == 0 {
+		return ""
+	}
+
+	rows := make([][]string, len(t.Rows))
+	columns := len(t.Columns)
+	for i, row := range t.Rows {
+		for _, cell := range row.Cells {
+			// cells are rendered on a single line
+			cellText := singleLine(r.join(r.elements(cell.Items)))
+			rows[i] = append(rows[i], cellText)
+		}
+		if len(rows[i]) > columns {
+			columns = len(rows[i])
+		}
+	}
+
+	header := t.FirstRowAsHeader == nil || *t.FirstRowAsHeader
+	lines := make([]string, 0, len(rows)+1)
+	if r.markdown {
+		if !header {
+			// Markdown tables always have a header
+			rows = append([][]string{make([]string, columns)}, rows...)
+		}
+		for i, row := range rows {
+			cells := make([]string, columns)
+			for n := range cells {
+				if n < len(row) {
+					cells[n] = tableCell(row[n])
+				}
+			}
+			lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+			if i == 0 {
+				lines = append(lines, "|"+strings.Repeat("---|", columns))
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	widths := make([]int, columns)
+	for _, row := range rows {
+		for n, cell := range row {
+			if w := utf8.RuneCountInString(cell); w > widths[n] {
+				widths[n] = w
+			}
+		}
+	}
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for n, cell := range row {
+			cells[n] = cell + strings.Repeat(" ", widths[n]-utf8.RuneCountInString(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, " | "), " "))
+		if i == 0 && header {
+			rule := make([]string, len(widths))
+			for n, w := range widths {
+				rule[n] = strings.Repeat("-", w)
+			}
+			lines = append(lines, strings.Join(rule, "-+-"))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *textRenderer) input(label string, placeholder string, value string) string {
+	if label == "" {
+		label = placeholder
+	}
+	if r.markdown {
+		label = r.escape(label)
+		value = r.escape(value)
+	}
+	switch {
+	case label == "":
+		return value
+	case value == "":
+		return label + ":"
+	}
+
+	return label + ": " + value
+}
+
+func (r *textRenderer) inputToggle(t *InputToggle) string {
+	on := t.ValueOn
+	if on == "" {
+		on = "true"
+	}
+	check := "[ ] "
+	if t.Value == on {
+		check = "[x] "
+	}
+	title := t.Title
+	if r.markdown {
+		check = "- " + check
+		title = r.escape(title)
+	}
+	if t.Label != "" {
+		return r.input(t.Label, "", "") + "\n" + check + title
+	}
+
+	return check + title
+}
+
+func (r *textRenderer) inputChoiceSet(c *InputChoiceSet) string {
+	selected := map[string]bool{}
+	for _, v := range strings.Split(c.Value, ",") {
+		selected[strings.TrimSpace(v)] = true
+	}
+
+	var lines []string
+	if label := r.input(c.Label, c.Placeholder, ""); label != "" {
+		lines = append(lines, label)
+	}
+	for _, choice := range c.Choices {
+		check := "[ ] "
+		if selected[choice.Value] {
+			check = "[x] "
+		}
+		title := choice.Title
+		if r.markdown {
+			check = "- " + check
+			title = r.escape(title)
+		}
+		lines = append(lines, check+title)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// actions renders the actions that make sense outside of Teams
+func (r *textRenderer) actions(actions []Action) []string {
+	var links []string
+	var cards []string
+	for _, a := range actions {
+		switch a := a.(type) {
+		case *ActionOpenUrl:
+			if a.Url != "" {
+				links = append(links, r.link(a.Title, a.Url))
+			}
+		case *ActionShowCard:
+			card := r.render(&a.Card)
+			if card == "" {
+				continue
+			}
+			title := a.Title
+			if r.markdown {
+				title = "**" + r.escape(title) + "**"
+			}
+			cards = append(cards, r.join([]string{title, card}))
+		}
+	}
+
+	var blocks []string
+	if len(links) > 0 {
+		if r.markdown {
+			blocks = append(blocks, strings.Join(links, " | "))
+		} else {
+			blocks = append(blocks, strings.Join(links, "\n"))
+		}
+	}
+
+	return append(blocks, cards...)
+}
+
+func (r *textRenderer) link(title string, url string) string {
+	if r.markdown {
+		if title == "" {
+			return "<" + url + ">"
+		}
+		return "[" + r.escape(title) + "](" + url + ")"
+	}
+	if title == "" || title == url {
+		return url
+	}
+
+	return title + ": " + url
+}
+
+// escape escapes text that isn’t markdown when rendering Markdown
+func (r *textRenderer) escape(s string) string {
+	if !r.markdown {
+		return s
+	}
+	return EscapeMarkdown(s)
+}
+
+// stripMarkdown removes the markdown supported by Teams from text: emphasis is dropped, links are replaced by their
+// title followed by the URL and escaped characters are unescaped
+func stripMarkdown(text string) string {
+	text, escaped := hideEscapes(text)
+
+	text = markdownLink.ReplaceAllStringFunc(text, func(m string) string {
+		parts := markdownLink.FindStringSubmatch(m)
+		if parts[1] == "" || parts[1] == parts[2] {
+			return parts[2]
+		}
+		return parts[1] + " (" + parts[2] + ")"
+	})
+	text = markdownStrong.ReplaceAllString(text, "$1$2")
+	text = markdownEmph.ReplaceAllString(text, "$1$2")
+
+	return restoreEscapes(text, escaped, func(s string) string { return s })
+}
+
+// hideEscapes replaces the escaped characters of markdown text with NUL, so that they aren’t matched by expressions
+// looking for markdown. It returns the escaped characters in order
+func hideEscapes(text string) (string, []string) {
+	var escaped []string
+	text = markdownEscaped.ReplaceAllStringFunc(text, func(m string) string {
+		escaped = append(escaped, m[1:])
+		return "\x00"
+	})
+
+	return text, escaped
+}
+
+// restoreEscapes replaces the NUL characters inserted by hideEscapes with the escaped characters, passed through f
+func restoreEscapes(text string, escaped []string, f func(string) string) string {
+	var b strings.Builder
+	for _, part := range strings.SplitAfter(text, "\x00") {
+		if strings.HasSuffix(part, "\x00") && len(escaped) > 0 {
+			b.WriteString(strings.TrimSuffix(part, "\x00"))
+			b.WriteString(f(escaped[0]))
+			escaped = escaped[1:]
+			continue
+		}
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+// expandTextFunctions replaces the DATE() and TIME() functions of text with the date or time they display in the
+// given location. Malformed functions are kept verbatim, like the clients do
+func expandTextFunctions(text string, loc *time.Location) string {
+	return textFunctionToken.ReplaceAllStringFunc(text, func(m string) string {
+		parts := textFunctionToken.FindStringSubmatch(m)
+		f := TextFunction{Kind: TextFunctionKind(parts[1])}
+		if err := f.parseArgs(parts[2]); err != nil {
+			return m
+		}
+		t := f.Time.In(loc)
+		switch {
+		case f.Kind == TextFunctionTime:
+			return t.Format("15:04 MST")
+		case f.Format == DateFormatShort:
+			return t.Format("Mon, Jan 2, 2006")
+		case f.Format == DateFormatLong:
+			return t.Format("Monday, January 2, 2006")
+		}
+		return t.Format("1/2/2006")
+	})
+}
+
+func tableCell(s string) string {
+	return strings.ReplaceAll(singleLine(s), "|", `\|`)
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -1,16 +1,18 @@
 package teams
 
-import "net/url"
+import (
+	"net/url"
+	"reflect"
+)
 
+// isValidUri reports whether s is an absolute http or https URL, the only ones allowed for webhooks and links
 func isValidUri(s string) bool {
-	if _, err := url.ParseRequestURI("http://www.google.com"); err != nil {
-		return false
-	}
-	if u, err := url.Parse("http://www.google.com"); err != nil || u.Scheme == "" || u.Host == "" {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
 		return false
 	}
 
-	return true
+	return u.Scheme == "http" || u.Scheme == "https"
 }
 
 func isElementExist(arr []string, s string) bool {
@@ -22,3 +24,85 @@ func isElementExist(arr []string, s string) bool {
 
 	return false
 }
+
+// itemType returns the value of the Type field of an element or action
+func itemType(v interface{}) Type {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := rv.FieldByName("Type"); f.IsValid() && f.Type() == reflect.TypeOf(Type("")) {
+		return f.Interface().(Type)
+	}
+
+	return ""
+}
+
+// itemFallback returns the value of the Fallback field of an element or action
+func itemFallback(v interface{}) *Fallback {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	if f := rv.FieldByName("Fallback"); f.IsValid() && f.Type() == reflect.TypeOf(&Fallback{}) {
+		return f.Interface().(*Fallback)
+	}
+
+	return nil
+}
+
+// itemId returns the value of the Id field of an element, action, column or carousel page
+func itemId(v interface{}) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := rv.FieldByName("Id"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
+
+	return ""
+}
+
+// Bool returns a pointer to v, for optional properties like IsVisible where false differs from unset
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Float returns a pointer to v, for optional numeric properties like InputNumber.Value where 0 differs from unset
+func Float(v float64) *float64 {
+	return &v
+}
+
+// isHidden reports whether an element, column or carousel page has IsVisible set to false
+func isHidden(v interface{}) bool {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return false
+	}
+	f := rv.FieldByName("IsVisible")
+	if !f.IsValid() || f.Kind() != reflect.Ptr || f.IsNil() {
+		return false
+	}
+
+	return !f.Elem().Bool()
+}
+
+// itemSpacing returns the values of the Spacing and Separator fields of an element or column
+func itemSpacing(v interface{}) (Spacing, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	var spacing Spacing
+	if f := rv.FieldByName("Spacing"); f.IsValid() && f.Type() == reflect.TypeOf(Spacing("")) {
+		spacing = f.Interface().(Spacing)
+	}
+	separator := false
+	if f := rv.FieldByName("Separator"); f.IsValid() && f.Kind() == reflect.Bool {
+		separator = f.Bool()
+	}
+
+	return spacing, separator
+}
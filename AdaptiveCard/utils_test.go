@@ -0,0 +1,41 @@
+package teams
+
+import "testing"
+
+func TestIsValidUri(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want bool
+	}{
+		{"https://example.com/hook", true},
+		{"http://example.com", true},
+		{"HTTPS://example.com/path?q=1", true},
+		{"", false},
+		{"not a url", false},
+		{"example.com/hook", false},
+		{"/relative/path", false},
+		{"https:///path", false},
+		{"javascript:alert(1)", false},
+		{"ftp://example.com/file", false},
+		{"file:///etc/passwd", false},
+		{"data:text/html,<script>alert(1)</script>", false},
+	}
+	for _, tt := range tests {
+		if got := isValidUri(tt.uri); got != tt.want {
+			t.Errorf("isValidUri(%q) = %v, want %v", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestActionOpenUrlRejectsScripts(t *testing.T) {
+	a := NewActionOpenUrl()
+	a.Url = "javascript:alert(1)"
+	if err := a.validate(); err == nil {
+		t.Errorf("validate() of %q = nil, want an error", a.Url)
+	}
+
+	a.Url = "https://example.com"
+	if err := a.validate(); err != nil {
+		t.Errorf("validate() of %q = %v, want nil", a.Url, err)
+	}
+}
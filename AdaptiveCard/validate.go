@@ -0,0 +1,98 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+)
+
+type validator interface {
+	validate() error
+}
+
+// validateItem validates v if it knows how to validate itself
+func validateItem(v interface{}) error {
+	if v, ok := v.(validator); ok {
+		return v.validate()
+	}
+
+	return nil
+}
+
+func validateType(got Type, expected Type) error {
+	if got == "" {
+		return errors.New("Type is required")
+	} else if got != expected {
+		return fmt.Errorf("Type is invalid; expected: %s, got %s", expected, got)
+	}
+
+	return nil
+}
+
+func validateElements(name string, elements []Element) error {
+	for i, el := range elements {
+		if el == nil {
+			return fmt.Errorf("%s[%d]: element is nil", name, i)
+		}
+		if err := validateItem(el); err != nil {
+			return fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateActions(name string, actions []Action) error {
+	for i, a := range actions {
+		if a == nil {
+			return fmt.Errorf("%s[%d]: action is nil", name, i)
+		}
+		if err := validateItem(a); err != nil {
+			return fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSelectAction(name string, a ISelectAction) error {
+	if a == nil {
+		return nil
+	}
+	if err := validateItem(a); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Validate checks the card and all of its elements and actions against the rules of the Adaptive Card schema
+// that can’t be enforced by the Go types alone, including that every element and action is supported by the
+// card’s version or has a fallback and that the refresh, if any, is one Teams can run. The returned error names the
+// offending item
+func (a *AdaptiveCard) Validate() error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+	if err := a.validateRefresh(); err != nil {
+		return err
+	}
+
+	return a.validateVersion()
+}
+
+func (a *AdaptiveCard) validate() error {
+	if err := validateType(a.Type, TypeAdaptiveCard); err != nil {
+		return err
+	}
+	if err := validateElements("body", a.Body); err != nil {
+		return err
+	}
+	if err := validateActions("actions", a.Actions); err != nil {
+		return err
+	}
+	if err := validateSelectAction("selectAction", a.SelectAction); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package teams
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RequiredVersion returns the schema version the given element or action type was introduced in, or an empty
+// Version if the type is unknown
+func RequiredVersion(t Type) Version {
+	return typeVersions[t]
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to or higher than o. Versions that can’t
+// be parsed are treated as 0.0
+func (v Version) Compare(o Version) int {
+	vMajor, vMinor := v.parse()
+	oMajor, oMinor := o.parse()
+
+	switch {
+	case vMajor < oMajor || vMajor == oMajor && vMinor < oMinor:
+		return -1
+	case vMajor == oMajor && vMinor == oMinor:
+		return 0
+	}
+
+	return 1
+}
+
+func (v Version) parse() (major int, minor int) {
+	parts := strings.SplitN(string(v), ".", 2)
+	major, _ = strconv.Atoi(parts[0])
+	if len(parts) == 2 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+
+	return major, minor
+}
+
+// validateVersion checks that every element and action of the card is supported by the card’s version, or has a
+// fallback. Cards without a version, like the ones of Action.ShowCard, inherit the version of their parent. The
+// children of an item replaced by its fallback are not checked, only the fallback itself
+func (a *AdaptiveCard) validateVersion() error {
+	var versions []Version
+	var replaced []string
+	var err error
+	Walk(a, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			if err != nil {
+				return false
+			}
+			for _, path := range replaced {
+				if strings.HasPrefix(c.Path(), path+"/") && !strings.HasPrefix(c.Path(), path+"/fallback") {
+					return false
+				}
+			}
+
+			if card, ok := c.Node().(*AdaptiveCard); ok {
+				version := card.Version
+				if version == "" && len(versions) > 0 {
+					version = versions[len(versions)-1]
+				}
+				versions = append(versions, version)
+				return true
+			}
+
+			version := versions[len(versions)-1]
+			if version == "" {
+				return true
+			}
+			t := itemType(c.Node())
+			if required := RequiredVersion(t); required != "" && version.Compare(required) < 0 {
+				if itemFallback(c.Node()) == nil {
+					err = fmt.Errorf("%s: %s requires version %s but the card declares %s; raise the version or add a fallback", c.Path(), t, required, version)
+					return false
+				}
+				replaced = append(replaced, c.Path())
+			}
+
+			return true
+		},
+		LeaveFunc: func(c *Cursor) {
+			if _, ok := c.Node().(*AdaptiveCard); ok {
+				versions = versions[:len(versions)-1]
+			}
+		},
+	})
+
+	return err
+}
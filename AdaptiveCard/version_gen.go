@@ -0,0 +1,42 @@
+// Code generated by schemagen from adaptive-card-1.6.json; DO NOT EDIT.
+
+package teams
+
+// typeVersions holds the schema version every element and action type was introduced in
+var typeVersions = map[Type]Version{
+	TypeActionOpenUrl:          Version10,
+	TypeActionShowCard:         Version10,
+	TypeActionSubmit:           Version10,
+	TypeColumn:                 Version10,
+	TypeColumnSet:              Version10,
+	TypeContainer:              Version10,
+	TypeFactSet:                Version10,
+	TypeImage:                  Version10,
+	TypeImageSet:               Version10,
+	TypeInputChoiceSet:         Version10,
+	TypeInputDate:              Version10,
+	TypeInputNumber:            Version10,
+	TypeInputText:              Version10,
+	TypeInputTime:              Version10,
+	TypeInputToggle:            Version10,
+	TypeTextBlock:              Version10,
+	TypeMedia:                  Version11,
+	TypeActionSet:              Version12,
+	TypeActionToggleVisibility: Version12,
+	TypeRichTextBlock:          Version12,
+	TypeTextRun:                Version12,
+	TypeActionExecute:          Version14,
+	TypeTable:                  Version15,
+	TypeTableCell:              Version15,
+	TypeTableRow:               Version15,
+	TypeBadge:                  Version16,
+	TypeCarousel:               Version16,
+	TypeCarouselPage:           Version16,
+	TypeCodeBlock:              Version16,
+	TypeCompoundButton:         Version16,
+	TypeDataQuery:              Version16,
+	TypeIcon:                   Version16,
+	TypeInputRating:            Version16,
+	TypeProgressBar:            Version16,
+	TypeProgressRing:           Version16,
+}
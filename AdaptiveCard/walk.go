@@ -0,0 +1,411 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// A Visitor is called by Walk for every node of a card. Nodes are the card itself, the cards of Action.ShowCard,
+// elements, actions (including selectAction and inlineAction), columns, carousel pages, table rows and cells, the
+// images of an ImageSet, the inlines of a RichTextBlock and the content of fallbacks
+type Visitor interface {
+	// Enter is called before the children of the node are walked. Returning false skips the children
+	Enter(c *Cursor) bool
+	// Leave is called after the children of the node have been walked
+	Leave(c *Cursor)
+}
+
+// VisitorFuncs adapts a pair of functions to the Visitor interface. Either function may be nil
+type VisitorFuncs struct {
+	EnterFunc func(c *Cursor) bool
+	LeaveFunc func(c *Cursor)
+}
+
+func (v VisitorFuncs) Enter(c *Cursor) bool {
+	if v.EnterFunc == nil {
+		return true
+	}
+	return v.EnterFunc(c)
+}
+
+func (v VisitorFuncs) Leave(c *Cursor) {
+	if v.LeaveFunc != nil {
+		v.LeaveFunc(c)
+	}
+}
+
+// A Cursor describes the node being visited by Walk and allows replacing or removing it in place
+type Cursor struct {
+	node   interface{}
+	parent interface{}
+	path   string
+	index  int
+	slot   slot
+}
+
+// Node returns the node being visited: an Element, an Action, a *Column, a *CarouselPage, a *TableRow, a
+// *TableCell, an *Image of an ImageSet, a *TextRun or an *AdaptiveCard
+func (c *Cursor) Node() interface{} {
+	return c.node
+}
+
+// Parent returns the node containing the current node, or nil for the root card
+func (c *Cursor) Parent() interface{} {
+	return c.parent
+}
+
+// Path returns the location of the current node in the JSON representation of the card as a JSON pointer
+// (RFC 6901), e.g. "/body/0/items/1". The root card has the empty path
+func (c *Cursor) Path() string {
+	return c.path
+}
+
+// Index returns the index of the current node in the array containing it, or -1 if it isn’t part of an array
+func (c *Cursor) Index() int {
+	return c.index
+}
+
+// Replace replaces the current node with n, which must be of a kind that fits its place: an Element in an array
+// of elements, an ISelectAction as selectAction, a *Column in the columns of a ColumnSet and so on. When called
+// from Enter, the children of n are walked instead of the ones of the replaced node
+func (c *Cursor) Replace(n interface{}) error {
+	if c.slot == nil {
+		return errors.New("the root card can’t be replaced")
+	}
+	if n == nil || reflect.ValueOf(n).Kind() == reflect.Ptr && reflect.ValueOf(n).IsNil() {
+		return errors.New("can’t replace a node with nil; use Remove instead")
+	}
+	if err := c.slot.set(n); err != nil {
+		return fmt.Errorf("%s: %w", c.path, err)
+	}
+	c.node = c.slot.get()
+
+	return nil
+}
+
+// Remove removes the current node from its parent. When called from Enter, the children of the node are not walked
+func (c *Cursor) Remove() error {
+	if c.slot == nil {
+		return errors.New("the root card can’t be removed")
+	}
+	if err := c.slot.remove(); err != nil {
+		return fmt.Errorf("%s: %w", c.path, err)
+	}
+	c.node = nil
+
+	return nil
+}
+
+// InsertAfter inserts n into the array containing the current node, right after it. n must be of a kind that fits
+// the array. Inserted nodes are not walked
+func (c *Cursor) InsertAfter(n interface{}) error {
+	s, ok := c.slot.(*sliceSlot)
+	if !ok || c.node == nil {
+		return fmt.Errorf("%s: node is not part of an array", c.path)
+	}
+	if err := s.insert(n); err != nil {
+		return fmt.Errorf("%s: %w", c.path, err)
+	}
+	// the array may have been reallocated
+	c.node = s.get()
+
+	return nil
+}
+
+// Walk traverses the card depth-first, calling v.Enter and v.Leave for every node, starting with the card itself
+func Walk(card *AdaptiveCard, v Visitor) {
+	if card == nil {
+		return
+	}
+
+	w := &walker{visitor: v}
+	w.visit(&Cursor{node: card, index: -1})
+}
+
+// Inspect traverses the card depth-first, calling f with every node and its JSON pointer. If f returns false,
+// the children of the node are skipped
+func Inspect(card *AdaptiveCard, f func(node interface{}, path string) bool) {
+	Walk(card, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			return f(c.Node(), c.Path())
+		},
+	})
+}
+
+type walker struct {
+	visitor Visitor
+}
+
+// visit calls the visitor for the node of the cursor and walks its children. It reports whether the node has been
+// removed
+func (w *walker) visit(c *Cursor) bool {
+	if w.visitor.Enter(c) && c.node != nil {
+		w.children(c.node, c.path)
+	}
+	if c.node == nil {
+		return true
+	}
+	w.visitor.Leave(c)
+
+	return c.node == nil
+}
+
+func (w *walker) children(n interface{}, path string) {
+	switch n := n.(type) {
+	case *AdaptiveCard:
+		w.slice(n, path+"/body", &n.Body)
+		w.slice(n, path+"/actions", &n.Actions)
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *Container:
+		w.slice(n, path+"/items", &n.Items)
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *ColumnSet:
+		w.slice(n, path+"/columns", &n.Columns)
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *Column:
+		w.slice(n, path+"/items", &n.Items)
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *ActionSet:
+		w.slice(n, path+"/actions", &n.Actions)
+	case *ImageSet:
+		w.slice(n, path+"/images", &n.Images)
+	case *Image:
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *RichTextBlock:
+		w.slice(n, path+"/inlines", &n.Inlines)
+	case *TextRun:
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *Table:
+		w.slice(n, path+"/rows", &n.Rows)
+	case *TableRow:
+		w.slice(n, path+"/cells", &n.Cells)
+	case *TableCell:
+		w.slice(n, path+"/items", &n.Items)
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *Carousel:
+		w.slice(n, path+"/pages", &n.Pages)
+	case *CarouselPage:
+		w.slice(n, path+"/items", &n.Items)
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *Icon:
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *CompoundButton:
+		w.field(n, path+"/selectAction", &n.SelectAction)
+	case *InputText:
+		w.field(n, path+"/inlineAction", &n.InlineAction)
+	case *ActionShowCard:
+		w.visit(&Cursor{node: &n.Card, parent: n, path: path + "/card", index: -1, slot: cardSlot{&n.Card}})
+	}
+
+	w.fallback(n, path+"/fallback")
+}
+
+// slice walks the nodes of the slice pointed to by ptr, which may be removed or replaced while walking
+func (w *walker) slice(parent interface{}, path string, ptr interface{}) {
+	s := sliceSlot{ptr: reflect.ValueOf(ptr)}
+	for i := 0; i < s.len(); i++ {
+		s.index = i
+		c := &Cursor{node: s.get(), parent: parent, path: path + "/" + strconv.Itoa(i), index: i, slot: &s}
+		if c.node == nil {
+			continue
+		}
+		if w.visit(c) {
+			i--
+		}
+		i += s.inserted
+		s.inserted = 0
+	}
+}
+
+// field walks the action held by the ISelectAction field pointed to by ptr
+func (w *walker) field(parent interface{}, path string, ptr *ISelectAction) {
+	if *ptr == nil {
+		return
+	}
+	w.visit(&Cursor{node: *ptr, parent: parent, path: path, index: -1, slot: selectActionSlot{ptr}})
+}
+
+func (w *walker) fallback(parent interface{}, path string) {
+	rv := reflect.Indirect(reflect.ValueOf(parent))
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	f := rv.FieldByName("Fallback")
+	if !f.IsValid() || f.Type() != reflect.TypeOf(&Fallback{}) || f.IsNil() {
+		return
+	}
+
+	s := fallbackSlot{ptr: f.Addr().Interface().(**Fallback)}
+	if n := s.get(); n != nil {
+		w.visit(&Cursor{node: n, parent: parent, path: path, index: -1, slot: s})
+	}
+}
+
+// A slot is the place a node is stored in
+type slot interface {
+	get() interface{}
+	set(n interface{}) error
+	remove() error
+}
+
+type sliceSlot struct {
+	ptr      reflect.Value
+	index    int
+	inserted int
+}
+
+func (s *sliceSlot) len() int {
+	return s.ptr.Elem().Len()
+}
+
+func (s *sliceSlot) get() interface{} {
+	v := s.ptr.Elem().Index(s.index)
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	case reflect.Struct:
+		// values stored in place, like the columns of a ColumnSet, are visited by pointer so they can be modified
+		return v.Addr().Interface()
+	}
+
+	return nil
+}
+
+func (s *sliceSlot) set(n interface{}) error {
+	v := s.ptr.Elem().Index(s.index)
+	nv := reflect.ValueOf(n)
+	switch {
+	case v.Kind() == reflect.Interface && nv.Type().Implements(v.Type()):
+		v.Set(nv)
+	case v.Kind() == reflect.Struct && nv.Type() == reflect.PtrTo(v.Type()):
+		v.Set(nv.Elem())
+	default:
+		return fmt.Errorf("%T can’t be stored in %s", n, s.ptr.Elem().Type())
+	}
+
+	return nil
+}
+
+func (s *sliceSlot) remove() error {
+	sv := s.ptr.Elem()
+	rest := reflect.AppendSlice(sv.Slice(0, s.index), sv.Slice(s.index+1, sv.Len()))
+	// clear the element left over at the end so it can be garbage collected
+	sv.Index(sv.Len() - 1).Set(reflect.Zero(sv.Type().Elem()))
+	sv.Set(rest)
+
+	return nil
+}
+
+// insert inserts n after the current element
+func (s *sliceSlot) insert(n interface{}) error {
+	sv := s.ptr.Elem()
+	nv := reflect.ValueOf(n)
+	switch et := sv.Type().Elem(); {
+	case et.Kind() == reflect.Interface && nv.Type().Implements(et):
+	case et.Kind() == reflect.Struct && nv.Type() == reflect.PtrTo(et):
+		nv = nv.Elem()
+	default:
+		return fmt.Errorf("%T can’t be stored in %s", n, sv.Type())
+	}
+
+	at := s.index + 1 + s.inserted
+	sv.Set(reflect.Append(sv, reflect.Zero(sv.Type().Elem())))
+	reflect.Copy(sv.Slice(at+1, sv.Len()), sv.Slice(at, sv.Len()-1))
+	sv.Index(at).Set(nv)
+	s.inserted++
+
+	return nil
+}
+
+type selectActionSlot struct {
+	ptr *ISelectAction
+}
+
+func (s selectActionSlot) get() interface{} {
+	if *s.ptr == nil {
+		return nil
+	}
+	return *s.ptr
+}
+
+func (s selectActionSlot) set(n interface{}) error {
+	a, ok := n.(ISelectAction)
+	if !ok {
+		return fmt.Errorf("%T can’t be used as selectAction", n)
+	}
+	*s.ptr = a
+
+	return nil
+}
+
+func (s selectActionSlot) remove() error {
+	*s.ptr = nil
+	return nil
+}
+
+type cardSlot struct {
+	ptr *AdaptiveCard
+}
+
+func (s cardSlot) get() interface{} {
+	return s.ptr
+}
+
+func (s cardSlot) set(n interface{}) error {
+	card, ok := n.(*AdaptiveCard)
+	if !ok {
+		return fmt.Errorf("%T is not an *AdaptiveCard", n)
+	}
+	*s.ptr = *card
+
+	return nil
+}
+
+func (s cardSlot) remove() error {
+	return errors.New("the card of an Action.ShowCard can’t be removed")
+}
+
+type fallbackSlot struct {
+	ptr **Fallback
+}
+
+func (s fallbackSlot) get() interface{} {
+	f := *s.ptr
+	switch {
+	case f == nil:
+		return nil
+	case f.element != nil:
+		return f.element
+	case f.action != nil:
+		return f.action
+	case f.column != nil:
+		return f.column
+	}
+
+	return nil
+}
+
+func (s fallbackSlot) set(n interface{}) error {
+	switch n := n.(type) {
+	case Element:
+		*s.ptr = FallbackElement(n)
+	case Action:
+		*s.ptr = FallbackAction(n)
+	case *Column:
+		*s.ptr = FallbackColumn(n)
+	default:
+		return fmt.Errorf("%T can’t be used as fallback", n)
+	}
+
+	return nil
+}
+
+func (s fallbackSlot) remove() error {
+	*s.ptr = nil
+	return nil
+}
@@ -0,0 +1,301 @@
+package teams
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// walkCard has a node in every place Walk visits
+const walkCard = `{"type":"AdaptiveCard","version":"1.6",
+	"body":[
+		{"type":"Container","items":[{"type":"TextBlock","text":"in container","fallback":{"type":"TextBlock","text":"fallback"}}],
+			"selectAction":{"type":"Action.Submit"}},
+		{"type":"ColumnSet","columns":[{"type":"Column","items":[{"type":"Image","url":"https://example.com/a.png",
+			"selectAction":{"type":"Action.OpenUrl","url":"https://example.com"}}],
+			"fallback":{"type":"Column"}}]},
+		{"type":"ImageSet","images":[{"type":"Image","url":"https://example.com/b.png"}]},
+		{"type":"RichTextBlock","inlines":[{"type":"TextRun","text":"run","selectAction":{"type":"Action.Submit"}}]},
+		{"type":"Table","rows":[{"type":"TableRow","cells":[{"type":"TableCell","items":[{"type":"TextBlock","text":"cell"}]}]}]},
+		{"type":"Carousel","pages":[{"type":"CarouselPage","items":[{"type":"TextBlock","text":"page"}]}]},
+		{"type":"Input.Text","id":"text","inlineAction":{"type":"Action.Submit"}},
+		{"type":"ActionSet","actions":[{"type":"Action.ShowCard","card":{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"shown"}]}}]}
+	],
+	"actions":[{"type":"Action.Submit","fallback":"drop"}],
+	"selectAction":{"type":"Action.OpenUrl","url":"https://example.com"}}`
+
+func decodeCard(t *testing.T, data string) *AdaptiveCard {
+	t.Helper()
+
+	var card AdaptiveCard
+	if err := json.Unmarshal([]byte(data), &card); err != nil {
+		t.Fatal(err)
+	}
+
+	return &card
+}
+
+// paths returns the paths of the nodes visited by Walk
+func paths(card *AdaptiveCard) []string {
+	var paths []string
+	Inspect(card, func(node interface{}, path string) bool {
+		paths = append(paths, path)
+		return true
+	})
+
+	return paths
+}
+
+// texts returns the texts of the TextBlocks of the body of the card
+func texts(card *AdaptiveCard) []string {
+	texts := []string{}
+	for _, el := range card.Body {
+		texts = append(texts, el.(*TextBlock).Text)
+	}
+
+	return texts
+}
+
+func newTextCard(texts ...string) *AdaptiveCard {
+	card := NewAdaptiveCard()
+	for _, text := range texts {
+		card.Body = append(card.Body, NewTextBlock(text))
+	}
+
+	return card
+}
+
+func TestWalkVisitsEverySlot(t *testing.T) {
+	want := []string{
+		"",
+		"/body/0",
+		"/body/0/items/0",
+		"/body/0/items/0/fallback",
+		"/body/0/selectAction",
+		"/body/1",
+		"/body/1/columns/0",
+		"/body/1/columns/0/items/0",
+		"/body/1/columns/0/items/0/selectAction",
+		"/body/1/columns/0/fallback",
+		"/body/2",
+		"/body/2/images/0",
+		"/body/3",
+		"/body/3/inlines/0",
+		"/body/3/inlines/0/selectAction",
+		"/body/4",
+		"/body/4/rows/0",
+		"/body/4/rows/0/cells/0",
+		"/body/4/rows/0/cells/0/items/0",
+		"/body/5",
+		"/body/5/pages/0",
+		"/body/5/pages/0/items/0",
+		"/body/6",
+		"/body/6/inlineAction",
+		"/body/7",
+		"/body/7/actions/0",
+		"/body/7/actions/0/card",
+		"/body/7/actions/0/card/body/0",
+		"/actions/0",
+		"/selectAction",
+	}
+	if got := paths(decodeCard(t, walkCard)); !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+}
+
+func TestWalkEnterAndLeave(t *testing.T) {
+	card := newTextCard("a")
+	card.Body = append(card.Body, NewContainer(NewTextBlock("b")))
+
+	var events []string
+	Walk(card, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			events = append(events, "enter "+c.Path())
+			// skip the children of the container
+			_, ok := c.Node().(*Container)
+			return !ok
+		},
+		LeaveFunc: func(c *Cursor) {
+			events = append(events, "leave "+c.Path())
+		},
+	})
+
+	want := []string{"enter ", "enter /body/0", "leave /body/0", "enter /body/1", "leave /body/1", "leave "}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+}
+
+func TestWalkRemove(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove []string
+		leave  bool
+		want   []string
+	}{
+		{name: "first", remove: []string{"a"}, want: []string{"b", "c", "d"}},
+		{name: "consecutive", remove: []string{"b", "c"}, want: []string{"a", "d"}},
+		{name: "last", remove: []string{"d"}, want: []string{"a", "b", "c"}},
+		{name: "all", remove: []string{"a", "b", "c", "d"}, want: []string{}},
+		{name: "from leave", remove: []string{"a", "c"}, leave: true, want: []string{"b", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := newTextCard("a", "b", "c", "d")
+			var visited []string
+			remove := func(c *Cursor) {
+				tb, ok := c.Node().(*TextBlock)
+				if !ok {
+					return
+				}
+				for _, text := range tt.remove {
+					if tb.Text == text {
+						if err := c.Remove(); err != nil {
+							t.Fatal(err)
+						}
+					}
+				}
+			}
+			Walk(card, VisitorFuncs{
+				EnterFunc: func(c *Cursor) bool {
+					if tb, ok := c.Node().(*TextBlock); ok {
+						visited = append(visited, tb.Text)
+					}
+					if !tt.leave {
+						remove(c)
+					}
+					return true
+				},
+				LeaveFunc: func(c *Cursor) {
+					if tt.leave {
+						remove(c)
+					}
+				},
+			})
+
+			if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(visited, want) {
+				t.Errorf("visited = %q, want %q", visited, want)
+			}
+			if got := texts(card); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkReplace(t *testing.T) {
+	card := newTextCard("a", "b")
+	var visited []string
+	Walk(card, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			tb, ok := c.Node().(*TextBlock)
+			if !ok {
+				return true
+			}
+			visited = append(visited, c.Path()+" "+tb.Text)
+			if tb.Text == "a" {
+				// the children of the replacement are walked
+				if err := c.Replace(NewContainer(NewTextBlock("child"))); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return true
+		},
+	})
+
+	want := []string{"/body/0 a", "/body/0/items/0 child", "/body/1 b"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited = %q, want %q", visited, want)
+	}
+	if _, ok := card.Body[0].(*Container); !ok {
+		t.Errorf("body[0] = %T, want *Container", card.Body[0])
+	}
+}
+
+func TestWalkInsertAfter(t *testing.T) {
+	card := newTextCard("a", "b", "c")
+	var visited []string
+	Walk(card, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			tb, ok := c.Node().(*TextBlock)
+			if !ok {
+				return true
+			}
+			visited = append(visited, tb.Text)
+			switch tb.Text {
+			case "a":
+				// inserted nodes are not walked
+				for _, text := range []string{"a1", "a2"} {
+					if err := c.InsertAfter(NewTextBlock(text)); err != nil {
+						t.Fatal(err)
+					}
+				}
+			case "b":
+				if err := c.InsertAfter(NewTextBlock("b1")); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.Remove(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return true
+		},
+	})
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited = %q, want %q", visited, want)
+	}
+	if got, want := texts(card), []string{"a", "a1", "a2", "b1", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestWalkSlots(t *testing.T) {
+	card := decodeCard(t, walkCard)
+	Walk(card, VisitorFuncs{
+		EnterFunc: func(c *Cursor) bool {
+			switch c.Path() {
+			case "/body/0/items/0/fallback":
+				if err := c.Remove(); err != nil {
+					t.Error(err)
+				}
+			case "/body/0/selectAction":
+				if err := c.Replace(NewActionOpenUrl()); err != nil {
+					t.Error(err)
+				}
+			case "/body/1/columns/0":
+				if err := c.Replace(NewActionSubmit()); err == nil {
+					t.Error("Replace() of a column with an action = nil, want an error")
+				}
+			case "/body/4/rows/0/cells/0/items/0":
+				if err := c.Replace(NewTextBlock("new cell")); err != nil {
+					t.Error(err)
+				}
+			case "/body/7/actions/0/card":
+				if err := c.Remove(); err == nil {
+					t.Error("Remove() of the card of an Action.ShowCard = nil, want an error")
+				}
+			case "/selectAction":
+				if err := c.InsertAfter(NewActionSubmit()); err == nil {
+					t.Error("InsertAfter() of a selectAction = nil, want an error")
+				}
+			case "":
+				if err := c.Remove(); err == nil {
+					t.Error("Remove() of the root card = nil, want an error")
+				}
+			}
+			return true
+		},
+	})
+
+	container := card.Body[0].(*Container)
+	if container.Items[0].(*TextBlock).Fallback != nil {
+		t.Error("fallback not removed")
+	}
+	if _, ok := container.SelectAction.(*ActionOpenUrl); !ok {
+		t.Errorf("selectAction = %T, want *ActionOpenUrl", container.SelectAction)
+	}
+	if text := card.Body[4].(*Table).Rows[0].Cells[0].Items[0].(*TextBlock).Text; text != "new cell" {
+		t.Errorf("cell text = %q, want new cell", text)
+	}
+}
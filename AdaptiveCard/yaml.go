@@ -0,0 +1,415 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cards can be written in YAML, which allows comments and is less verbose than JSON. The YAML is the same model
+// as the JSON, with the same "type" properties selecting the elements and actions, plus a few shorthands that are
+// expanded when the card is decoded:
+//
+//   - a string in an array of elements, like body or the items of a Container, is a TextBlock with wrap enabled
+//   - an element without type but with a map under facts is a FactSet, the keys of the map being the titles of
+//     the facts. The facts of an explicit FactSet can be a map too
+//   - the type of the root card, the card of an Action.ShowCard, columns, table rows and cells and carousel pages
+//     can be omitted
+//
+// For example:
+//
+//	version: "1.5"
+//	body:
+//	  - Deployment of **api** finished  # a TextBlock
+//	  - facts:
+//	      Environment: production
+//	      Duration: 4m 12s
+//	actions:
+//	  - type: Action.OpenUrl
+//	    title: Open pipeline
+//	    url: https://ci.example.com/runs/42
+//
+// Anchors, aliases and merge keys are resolved, as long as the expanded card has at most maxYAMLNodes nodes, so that
+// a few nested aliases can’t expand to gigabytes of JSON. Cards are always encoded in the full form, without
+// shorthands
+
+// maxYAMLNodes is the maximum number of nodes of a card written in YAML once its aliases are expanded
+const maxYAMLNodes = 100000
+
+// ParseYAML decodes a card written in YAML, expanding the shorthands
+func ParseYAML(data []byte) (*AdaptiveCard, error) {
+	card := &AdaptiveCard{}
+	if err := yaml.Unmarshal(data, card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// YAMLToJSON converts a card written in YAML to the JSON of the full model, e.g. to check it against the JSON
+// schema with ValidateJSONAgainstSchema
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return nil, fmt.Errorf("yaml: empty document")
+	}
+
+	return cardYAMLToJSON(&doc)
+}
+
+// ToYAML encodes the card as YAML, indented by two spaces. Multi-line strings use the literal block style
+func (a *AdaptiveCard) ToYAML() ([]byte, error) {
+	node, err := a.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalYAML decodes the card from YAML, expanding the shorthands, so cards can be part of larger YAML documents
+// decoded with gopkg.in/yaml.v3
+func (a *AdaptiveCard) UnmarshalYAML(value *yaml.Node) error {
+	data, err := cardYAMLToJSON(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, a)
+}
+
+// MarshalYAML encodes the card as YAML with the properties in the same order as the JSON
+func (a *AdaptiveCard) MarshalYAML() (interface{}, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML, so decoding it keeps the order of the properties and the types of the values
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	node := doc.Content[0]
+	blockStyle(node)
+
+	return node, nil
+}
+
+// blockStyle replaces the flow style of nodes decoded from JSON with the block style
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && strings.Contains(n.Value, "\n") {
+		n.Style = yaml.LiteralStyle
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// yamlKind is the role of a YAML node in a card, which decides the shorthands it may use
+type yamlKind int
+
+const (
+	yamlValue yamlKind = iota
+	yamlCard
+	yamlElement
+	yamlElements
+	yamlFacts
+)
+
+// yamlChildren lists the properties of each type that hold cards, elements or facts, and the implied type of the
+// items of arrays whose items all have the same type
+var yamlChildren = map[Type]map[string]struct {
+	kind    yamlKind
+	implied Type
+}{
+	TypeAdaptiveCard:   {"body": {kind: yamlElements}},
+	TypeContainer:      {"items": {kind: yamlElements}},
+	TypeColumn:         {"items": {kind: yamlElements}},
+	TypeTableCell:      {"items": {kind: yamlElements}},
+	TypeCarouselPage:   {"items": {kind: yamlElements}},
+	TypeColumnSet:      {"columns": {implied: TypeColumn}},
+	TypeTable:          {"rows": {implied: TypeTableRow}},
+	TypeTableRow:       {"cells": {implied: TypeTableCell}},
+	TypeCarousel:       {"pages": {implied: TypeCarouselPage}},
+	TypeFactSet:        {"facts": {kind: yamlFacts}},
+	TypeActionShowCard: {"card": {kind: yamlCard}},
+}
+
+func cardYAMLToJSON(n *yaml.Node) ([]byte, error) {
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil, fmt.Errorf("yaml: empty document")
+		}
+		n = n.Content[0]
+	}
+	size, err := yamlExpandedSize(n, map[*yaml.Node]int{})
+	if err != nil {
+		return nil, err
+	}
+	if size > maxYAMLNodes {
+		return nil, fmt.Errorf("yaml: the card has more than %d nodes once its aliases are expanded", maxYAMLNodes)
+	}
+
+	var b bytes.Buffer
+	if err := writeYAMLValue(&b, n, yamlCard, ""); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// yamlExpandedSize returns the number of nodes of n once its aliases are expanded, or maxYAMLNodes+1 if there are
+// more. The sizes of the nodes already counted are kept in sizes, so shared anchors are only walked once, and nodes
+// being counted are marked with -1 to reject aliases to a node containing them
+func yamlExpandedSize(n *yaml.Node, sizes map[*yaml.Node]int) (int, error) {
+	if size, ok := sizes[n]; ok {
+		if size < 0 {
+			return 0, fmt.Errorf("yaml: line %d: anchor %s contains an alias to itself", n.Line, n.Anchor)
+		}
+		return size, nil
+	}
+	sizes[n] = -1
+
+	nodes := n.Content
+	if n.Kind == yaml.AliasNode {
+		nodes = []*yaml.Node{n.Alias}
+	}
+	size := 1
+	for _, c := range nodes {
+		s, err := yamlExpandedSize(c, sizes)
+		if err != nil {
+			return 0, err
+		}
+		if size += s; size > maxYAMLNodes {
+			size = maxYAMLNodes + 1
+			break
+		}
+	}
+	sizes[n] = size
+
+	return size, nil
+}
+
+// writeYAMLValue writes the node as JSON, expanding the shorthands allowed by its kind. implied is the type of
+// objects without a type property
+func writeYAMLValue(b *bytes.Buffer, n *yaml.Node, kind yamlKind, implied Type) error {
+	if n.Kind == yaml.AliasNode {
+		return writeYAMLValue(b, n.Alias, kind, implied)
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		return writeYAMLMapping(b, n, kind, implied)
+	case yaml.SequenceNode:
+		itemKind := yamlValue
+		if kind == yamlElements {
+			itemKind = yamlElement
+		}
+		b.WriteByte('[')
+		for i, item := range n.Content {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeYAMLValue(b, item, itemKind, implied); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+		return nil
+	case yaml.ScalarNode:
+		if kind == yamlElement && n.ShortTag() == "!!str" {
+			text, _ := json.Marshal(n.Value)
+			fmt.Fprintf(b, `{"type":%q,"text":%s,"wrap":true}`, TypeTextBlock, text)
+			return nil
+		}
+		return writeYAMLScalar(b, n)
+	}
+
+	return fmt.Errorf("yaml: line %d: unexpected node", n.Line)
+}
+
+func writeYAMLMapping(b *bytes.Buffer, n *yaml.Node, kind yamlKind, implied Type) error {
+	pairs, err := yamlPairs(n)
+	if err != nil {
+		return err
+	}
+
+	if kind == yamlFacts {
+		b.WriteByte('[')
+		for i, p := range pairs {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			title, _ := json.Marshal(p.key.Value)
+			fmt.Fprintf(b, `{"title":%s,"value":`, title)
+			if err := writeYAMLFactValue(b, p.value); err != nil {
+				return err
+			}
+			b.WriteByte('}')
+		}
+		b.WriteByte(']')
+		return nil
+	}
+
+	t := implied
+	if kind == yamlCard {
+		t = TypeAdaptiveCard
+	}
+	explicit, facts := false, false
+	for _, p := range pairs {
+		switch p.key.Value {
+		case "type":
+			t = Type(yamlResolve(p.value).Value)
+			explicit = true
+		case "facts":
+			facts = true
+		}
+	}
+	if kind == yamlElement && !explicit && facts {
+		t = TypeFactSet
+	}
+
+	b.WriteByte('{')
+	if !explicit && t != "" {
+		fmt.Fprintf(b, `"type":%q`, t)
+		if len(pairs) > 0 {
+			b.WriteByte(',')
+		}
+	}
+	for i, p := range pairs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(p.key.Value)
+		b.Write(key)
+		b.WriteByte(':')
+
+		child := yamlChildren[t][p.key.Value]
+		value := yamlResolve(p.value)
+		if child.kind == yamlFacts && value.Kind != yaml.MappingNode {
+			child.kind = yamlValue
+		}
+		if err := writeYAMLValue(b, p.value, child.kind, child.implied); err != nil {
+			return err
+		}
+	}
+	b.WriteByte('}')
+
+	return nil
+}
+
+// writeYAMLFactValue writes the value of a fact of the map shorthand, which is always a string
+func writeYAMLFactValue(b *bytes.Buffer, n *yaml.Node) error {
+	n = yamlResolve(n)
+	if n.Kind != yaml.ScalarNode {
+		return fmt.Errorf("yaml: line %d: the value of a fact must be a scalar", n.Line)
+	}
+	value := n.Value
+	if n.ShortTag() == "!!null" {
+		value = ""
+	}
+	data, _ := json.Marshal(value)
+	b.Write(data)
+
+	return nil
+}
+
+func writeYAMLScalar(b *bytes.Buffer, n *yaml.Node) error {
+	var v interface{}
+	switch n.ShortTag() {
+	case "!!null", "!!bool", "!!int", "!!float":
+		if err := n.Decode(&v); err != nil {
+			return err
+		}
+	default:
+		// timestamps, binary data and custom tags are kept as written
+		v = n.Value
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("yaml: line %d: %w", n.Line, err)
+	}
+	b.Write(data)
+
+	return nil
+}
+
+type yamlPair struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// yamlPairs returns the key/value pairs of a mapping, resolving merge keys. Keys of the mapping itself take
+// precedence over merged ones
+func yamlPairs(n *yaml.Node) ([]yamlPair, error) {
+	var own, merged []yamlPair
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := yamlResolve(n.Content[i]), n.Content[i+1]
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("yaml: line %d: keys must be strings", key.Line)
+		}
+
+		if key.ShortTag() == "!!merge" {
+			sources := []*yaml.Node{yamlResolve(value)}
+			if sources[0].Kind == yaml.SequenceNode {
+				sources = sources[0].Content
+			}
+			for _, source := range sources {
+				source = yamlResolve(source)
+				if source.Kind != yaml.MappingNode {
+					return nil, fmt.Errorf("yaml: line %d: only mappings can be merged", source.Line)
+				}
+				pairs, err := yamlPairs(source)
+				if err != nil {
+					return nil, err
+				}
+				merged = append(merged, pairs...)
+			}
+			continue
+		}
+
+		if seen[key.Value] {
+			return nil, fmt.Errorf("yaml: line %d: key %q is already defined", key.Line, key.Value)
+		}
+		seen[key.Value] = true
+		own = append(own, yamlPair{key: key, value: value})
+	}
+
+	for _, p := range merged {
+		if !seen[p.key.Value] {
+			seen[p.key.Value] = true
+			own = append(own, p)
+		}
+	}
+
+	return own, nil
+}
+
+// yamlResolve returns the node an alias refers to, or the node itself if it isn’t an alias
+func yamlResolve(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	return n
+}
@@ -0,0 +1,159 @@
+package teams
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "text block shorthand",
+			yaml: "version: \"1.5\"\nbody:\n  - Deployment of **api** finished\n",
+			want: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"Deployment of **api** finished","wrap":true}]}`,
+		},
+		{
+			name: "fact set shorthand",
+			yaml: "body:\n  - facts:\n      Environment: production\n      Replicas: 3\n      Empty:\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"FactSet","facts":[{"title":"Environment","value":"production"},{"title":"Replicas","value":"3"},{"title":"Empty","value":""}]}]}`,
+		},
+		{
+			name: "facts map of an explicit fact set",
+			yaml: "body:\n  - type: FactSet\n    facts: {a: b}\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"FactSet","facts":[{"title":"a","value":"b"}]}]}`,
+		},
+		{
+			name: "facts list is kept",
+			yaml: "body:\n  - type: FactSet\n    facts: [{title: a, value: b}]\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"FactSet","facts":[{"title":"a","value":"b"}]}]}`,
+		},
+		{
+			name: "implied types",
+			yaml: "body:\n  - type: ColumnSet\n    columns:\n      - items: [left]\n  - type: Table\n    rows:\n      - cells:\n          - items: [cell]\n",
+			want: `{"type":"AdaptiveCard","body":[` +
+				`{"type":"ColumnSet","columns":[{"type":"Column","items":[{"type":"TextBlock","text":"left","wrap":true}]}]},` +
+				`{"type":"Table","rows":[{"type":"TableRow","cells":[{"type":"TableCell","items":[{"type":"TextBlock","text":"cell","wrap":true}]}]}]}]}`,
+		},
+		{
+			name: "carousel pages",
+			yaml: "body:\n  - type: Carousel\n    pages:\n      - items: [one]\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"Carousel","pages":[{"type":"CarouselPage","items":[{"type":"TextBlock","text":"one","wrap":true}]}]}]}`,
+		},
+		{
+			name: "show card",
+			yaml: "actions:\n  - type: Action.ShowCard\n    title: More\n    card:\n      body: [details]\n",
+			want: `{"type":"AdaptiveCard","actions":[{"type":"Action.ShowCard","title":"More","card":{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"details","wrap":true}]}}]}`,
+		},
+		{
+			name: "strings outside of elements are kept",
+			yaml: "body:\n  - type: Input.ChoiceSet\n    id: c\n    value: \"1\"\n    choices: [{title: One, value: \"1\"}]\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"Input.ChoiceSet","id":"c","value":"1","choices":[{"title":"One","value":"1"}]}]}`,
+		},
+		{
+			name: "scalars",
+			yaml: "body:\n  - type: TextBlock\n    text: 2024-01-02\n    wrap: yes\n    maxLines: 2\n    isVisible: true\n    id: ~\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"2024-01-02","wrap":"yes","maxLines":2,"isVisible":true,"id":null}]}`,
+		},
+		{
+			// merged keys follow the keys of the mapping
+			name: "anchors, aliases and merge keys",
+			yaml: "body:\n  - &base {type: TextBlock, text: a, wrap: true}\n  - {<<: *base, text: b}\n  - *base\n",
+			want: `{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"a","wrap":true},{"text":"b","type":"TextBlock","wrap":true},{"type":"TextBlock","text":"a","wrap":true}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := YAMLToJSON([]byte(tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("YAMLToJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLToJSONErrors(t *testing.T) {
+	// every level repeats the previous one 10 times, so the last one expands to 10^9 nodes
+	var laughs strings.Builder
+	laughs.WriteString("a: &a [lol, lol, lol, lol, lol, lol, lol, lol, lol, lol]\n")
+	for c := 'b'; c <= 'i'; c++ {
+		prev := string(c - 1)
+		laughs.WriteString(string(c) + ": &" + string(c) + " [" + strings.Repeat("*"+prev+", ", 9) + "*" + prev + "]\n")
+	}
+
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"empty", "", "empty document"},
+		{"invalid", "body: [", "yaml: "},
+		{"alias expansion", laughs.String(), "more than 100000 nodes once its aliases are expanded"},
+		{"recursive alias", "body: &a [*a]", "anchor a contains an alias to itself"},
+		{"recursive merge", "body: &a {<<: *a}", "anchor a contains an alias to itself"},
+		{"duplicate key", "version: \"1.5\"\nversion: \"1.6\"", `key "version" is already defined`},
+		{"fact value", "body:\n  - facts:\n      a: [b]\n", "the value of a fact must be a scalar"},
+		{"merge of a list", "a: &a [1]\nb: {<<: *a}", "only mappings can be merged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := YAMLToJSON([]byte(tt.yaml)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("YAMLToJSON() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	const data = `{"type":"AdaptiveCard","version":"1.5","body":[
+		{"type":"TextBlock","text":"line one\nline two","wrap":true,"isVisible":false},
+		{"type":"FactSet","facts":[{"title":"a","value":"1"}]},
+		{"type":"ColumnSet","columns":[{"type":"Column","width":"auto","items":[{"type":"Image","url":"https://example.com/a.png"}]}]},
+		{"type":"Input.Number","id":"n","value":0}
+	],"actions":[{"type":"Action.ShowCard","title":"More","card":{"type":"AdaptiveCard","body":[{"type":"TextBlock","text":"x"}]}}]}`
+	card := roundTrip(t, data)
+
+	out, err := card.ToYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "text: |-\n      line one\n      line two\n") {
+		t.Errorf("ToYAML() = %s, want the multi-line text in the literal style", out)
+	}
+
+	decoded, err := ParseYAML(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jsonEqual(t, decoded, data) {
+		t.Errorf("ParseYAML(ToYAML()) = %+v, want %s", decoded, data)
+	}
+}
+
+func TestYAMLEmbedded(t *testing.T) {
+	var doc struct {
+		Cards []*AdaptiveCard `yaml:"cards"`
+	}
+	if err := yaml.Unmarshal([]byte("cards:\n  - body: [a]\n  - body: [b]\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Cards) != 2 || doc.Cards[1].Body[0].(*TextBlock).Text != "b" {
+		t.Errorf("cards = %+v", doc.Cards)
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "type: AdaptiveCard") {
+		t.Errorf("yaml.Marshal() = %s, want the cards in the full form", out)
+	}
+}
@@ -0,0 +1,392 @@
+// Package auth gets the app tokens of the Azure Active Directory needed to call the Bot Connector, with the OAuth 2.0
+// client credentials flow, and adds them to the requests of the clients of the other packages. App tokens of
+// Microsoft Graph only work for the APIs allowing application permissions: posting or editing messages with
+// package graph needs the delegated token of a signed-in user instead.
+//
+// Source: https://learn.microsoft.com/en-us/entra/identity-platform/v2-oauth2-client-creds-grant-flow
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAuthority is the URL of the Azure Active Directory
+const DefaultAuthority = "https://login.microsoftonline.com"
+
+// TenantBotFramework is the tenant of multi-tenant bots
+const TenantBotFramework = "botframework.com"
+
+// The scopes of the app tokens of the Bot Connector and of the Microsoft Graph APIs allowing application
+// permissions, which don’t include sending messages
+const (
+	ScopeBotFramework = "https://api.botframework.com/.default"
+	ScopeGraph        = "https://graph.microsoft.com/.default"
+)
+
+// DefaultRefreshBefore is how long before their expiry tokens are refreshed
+const DefaultRefreshBefore = 5 * time.Minute
+
+// DefaultTimeout is the time the token endpoint has to answer
+const DefaultTimeout = 30 * time.Second
+
+// how long a client assertion is valid
+const assertionLifetime = 10 * time.Minute
+
+// the largest token response read
+const maxTokenResponseSize = 1 << 20
+
+// A TokenSource returns access tokens
+type TokenSource interface {
+	// Token returns a valid access token
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenError is returned when the token endpoint doesn’t issue a token
+type TokenError struct {
+	// HTTP status code of the response
+	StatusCode int
+	// OAuth error code, e.g. "invalid_client"
+	Code string
+	// Description of the error, e.g. "AADSTS7000215: Invalid client secret provided…"
+	Description string
+}
+
+func (e *TokenError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("token endpoint returned status %d", e.StatusCode)
+	}
+	if e.Description == "" {
+		return fmt.Sprintf("token endpoint returned status %d: %s", e.StatusCode, e.Code)
+	}
+
+	return fmt.Sprintf("token endpoint returned status %d: %s: %s", e.StatusCode, e.Code, e.Description)
+}
+
+// TokenProvider is a TokenSource getting app tokens with the client credentials of an app registration, either a
+// secret or a certificate. Tokens are cached and refreshed in the background before they expire; concurrent callers
+// share a single request to the token endpoint
+type TokenProvider struct {
+	// URL of the Azure Active Directory, DefaultAuthority if empty, e.g. the URL of a fake identity provider in tests
+	Authority string
+	// Tenant of the app, TenantBotFramework for multi-tenant bots
+	TenantId string
+	// Id of the app
+	ClientId string
+	// Scope of the tokens, e.g. ScopeBotFramework, or ScopeGraph for the Graph APIs allowing application permissions
+	Scope string
+	// How long before their expiry tokens are refreshed, DefaultRefreshBefore if 0
+	RefreshBefore time.Duration
+	// Time the token endpoint has to answer, DefaultTimeout if 0
+	Timeout time.Duration
+	// Client sending the requests to the token endpoint, http.DefaultClient if nil
+	Client *http.Client
+
+	secret string
+	cert   *tls.Certificate
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	fetching  *fetch
+}
+
+// fetch is a request to the token endpoint shared by the callers waiting for a token
+type fetch struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// NewSecretTokenProvider returns a TokenProvider authenticating the app with a client secret
+func NewSecretTokenProvider(tenantId string, clientId string, secret string, scope string) *TokenProvider {
+	return &TokenProvider{TenantId: tenantId, ClientId: clientId, Scope: scope, secret: secret}
+}
+
+// NewCertificateTokenProvider returns a TokenProvider authenticating the app with a client assertion signed by the
+// RSA key of the certificate, e.g. loaded with tls.LoadX509KeyPair. The certificate must be uploaded to the app
+// registration
+func NewCertificateTokenProvider(tenantId string, clientId string, cert tls.Certificate, scope string) (*TokenProvider, error) {
+	if len(cert.Certificate) == 0 {
+		return nil, errors.New("cert is required")
+	}
+	if _, ok := cert.PrivateKey.(*rsa.PrivateKey); !ok {
+		return nil, errors.New("cert is invalid; expected an RSA private key")
+	}
+
+	return &TokenProvider{TenantId: tenantId, ClientId: clientId, Scope: scope, cert: &cert}, nil
+}
+
+// Token returns the cached token, or waits for a new one if there is none or it expired. A token about to expire is
+// returned while a new one is requested in the background
+func (p *TokenProvider) Token(ctx context.Context) (string, error) {
+	refreshBefore := p.RefreshBefore
+	if refreshBefore == 0 {
+		refreshBefore = DefaultRefreshBefore
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	if p.token != "" && now.Before(p.expiresAt) {
+		token := p.token
+		if now.After(p.expiresAt.Add(-refreshBefore)) {
+			p.startFetch()
+		}
+		p.mu.Unlock()
+		return token, nil
+	}
+	f := p.startFetch()
+	p.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.token, f.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Invalidate drops the cached token, e.g. when it was rejected
+func (p *TokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.token = ""
+	p.expiresAt = time.Time{}
+}
+
+// startFetch requests a new token unless a request is already running, and returns the running request. p.mu must
+// be held
+func (p *TokenProvider) startFetch() *fetch {
+	if p.fetching != nil {
+		return p.fetching
+	}
+
+	f := &fetch{done: make(chan struct{})}
+	p.fetching = f
+	go func() {
+		// the request isn’t tied to the context of the first caller, since other callers may be waiting for it
+		timeout := p.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		token, expiresAt, err := p.requestToken(ctx)
+
+		p.mu.Lock()
+		if err == nil {
+			p.token = token
+			p.expiresAt = expiresAt
+		}
+		p.fetching = nil
+		p.mu.Unlock()
+
+		f.token, f.err = token, err
+		close(f.done)
+	}()
+
+	return f
+}
+
+// tokenUrl returns the URL of the token endpoint of the tenant
+func (p *TokenProvider) tokenUrl() string {
+	authority := p.Authority
+	if authority == "" {
+		authority = DefaultAuthority
+	}
+
+	return strings.TrimSuffix(authority, "/") + "/" + url.PathEscape(p.TenantId) + "/oauth2/v2.0/token"
+}
+
+// requestToken requests a token from the token endpoint and returns it with its expiry
+func (p *TokenProvider) requestToken(ctx context.Context) (string, time.Time, error) {
+	if p.TenantId == "" {
+		return "", time.Time{}, errors.New("TenantId is required")
+	}
+	if p.ClientId == "" {
+		return "", time.Time{}, errors.New("ClientId is required")
+	}
+	if p.Scope == "" {
+		return "", time.Time{}, errors.New("Scope is required")
+	}
+
+	endpoint := p.tokenUrl()
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"client_id":  {p.ClientId},
+		"scope":      {p.Scope},
+	}
+	if p.cert != nil {
+		assertion, err := p.clientAssertion(endpoint)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		form.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+		form.Set("client_assertion", assertion)
+	} else {
+		form.Set("client_secret", p.secret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	requested := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseSize))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var body struct {
+		AccessToken      string      `json:"access_token"`
+		ExpiresIn        interface{} `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	jsonErr := json.Unmarshal(data, &body)
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return "", time.Time{}, &TokenError{StatusCode: resp.StatusCode, Code: body.Error, Description: body.ErrorDescription}
+	}
+	if jsonErr != nil {
+		return "", time.Time{}, fmt.Errorf("invalid token response: %w", jsonErr)
+	}
+	if body.AccessToken == "" {
+		return "", time.Time{}, errors.New("invalid token response: access_token is required")
+	}
+
+	// expires_in is a number, or a string in older versions of the endpoint
+	var seconds float64
+	switch v := body.ExpiresIn.(type) {
+	case float64:
+		seconds = v
+	case string:
+		seconds, err = strconv.ParseFloat(v, 64)
+	default:
+		err = errors.New("expires_in is required")
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid token response: %w", err)
+	}
+
+	return body.AccessToken, requested.Add(time.Duration(seconds * float64(time.Second))), nil
+}
+
+// clientAssertion returns the JSON Web Token authenticating the app to the token endpoint, signed with RS256 by the
+// key of the certificate
+//
+// Source: https://learn.microsoft.com/en-us/entra/identity-platform/certificate-credentials
+func (p *TokenProvider) clientAssertion(audience string) (string, error) {
+	thumbprint := sha1.Sum(p.cert.Certificate[0])
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := map[string]interface{}{
+		"aud": audience,
+		"iss": p.ClientId,
+		"sub": p.ClientId,
+		"jti": hex.EncodeToString(id),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(assertionLifetime).Unix(),
+	}
+
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	hash := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.cert.PrivateKey.(*rsa.PrivateKey), crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Transport is an http.RoundTripper adding the Bearer token of its Source to the requests, e.g. the ones of a
+// bot.Connector
+type Transport struct {
+	// Source of the tokens
+	Source TokenSource
+	// Transport sending the requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+}
+
+// RoundTrip sends the request with an Authorization header. The request itself is not modified
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(r)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		// the token may have been revoked; the next request gets a new one
+		if p, ok := t.Source.(interface{ Invalidate() }); ok {
+			p.Invalidate()
+		}
+	}
+
+	return resp, err
+}
+
+// HTTPClient returns an http.Client adding the provider’s tokens to its requests
+func (p *TokenProvider) HTTPClient() *http.Client {
+	return &http.Client{Transport: &Transport{Source: p}}
+}
@@ -0,0 +1,303 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// idp is a fake identity provider issuing the tokens "token-1", "token-2"… valid for expiresIn
+type idp struct {
+	*httptest.Server
+	expiresIn interface{}
+	// closed to let the token requests be answered, nil to answer them at once
+	release chan struct{}
+
+	requests int32
+	mu       sync.Mutex
+	forms    []map[string][]string
+}
+
+func newIdp(t *testing.T) *idp {
+	i := &idp{expiresIn: 3600}
+	i.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		r.ParseForm()
+		i.mu.Lock()
+		i.forms = append(i.forms, r.PostForm)
+		i.mu.Unlock()
+		if i.release != nil {
+			<-i.release
+		}
+
+		n := atomic.AddInt32(&i.requests, 1)
+		if r.PostForm.Get("client_secret") == "wrong" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":             "invalid_client",
+				"error_description": "AADSTS7000215: Invalid client secret provided.",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token_type":   "Bearer",
+			"access_token": fmt.Sprintf("token-%d", n),
+			"expires_in":   i.expiresIn,
+		})
+	}))
+	t.Cleanup(i.Close)
+
+	return i
+}
+
+func (i *idp) provider(secret string) *TokenProvider {
+	p := NewSecretTokenProvider("tenant", "client", secret, ScopeBotFramework)
+	p.Authority = i.URL
+	p.Client = i.Client()
+	return p
+}
+
+func TestTokenProviderCachesTokens(t *testing.T) {
+	i := newIdp(t)
+	p := i.provider("secret")
+
+	for n := 0; n < 3; n++ {
+		token, err := p.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "token-1" {
+			t.Errorf("Token() = %q, want token-1", token)
+		}
+	}
+	if i.requests != 1 {
+		t.Errorf("token endpoint called %d times, want 1", i.requests)
+	}
+
+	form := i.forms[0]
+	want := map[string]string{"grant_type": "client_credentials", "client_id": "client", "client_secret": "secret", "scope": ScopeBotFramework}
+	for name, value := range want {
+		if got := strings.Join(form[name], ","); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestTokenProviderSharesRequests(t *testing.T) {
+	i := newIdp(t)
+	i.release = make(chan struct{})
+	p := i.provider("secret")
+
+	const callers = 20
+	var wg sync.WaitGroup
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	for n := 0; n < callers; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			tokens[n], errs[n] = p.Token(context.Background())
+		}(n)
+	}
+	// let all the callers wait for the request before answering it
+	for {
+		p.mu.Lock()
+		fetching := p.fetching != nil
+		p.mu.Unlock()
+		i.mu.Lock()
+		requested := len(i.forms) > 0
+		i.mu.Unlock()
+		if fetching && requested {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(i.release)
+	wg.Wait()
+
+	for n := range tokens {
+		if errs[n] != nil || tokens[n] != "token-1" {
+			t.Errorf("caller %d: Token() = %q, %v, want token-1", n, tokens[n], errs[n])
+		}
+	}
+	if i.requests != 1 {
+		t.Errorf("token endpoint called %d times, want 1", i.requests)
+	}
+}
+
+func TestTokenProviderRefreshesInBackground(t *testing.T) {
+	i := newIdp(t)
+	// tokens are valid for 1 minute, and refreshed 5 minutes before they expire, so right away
+	i.expiresIn = "60"
+	p := i.provider("secret")
+
+	if token, err := p.Token(context.Background()); err != nil || token != "token-1" {
+		t.Fatalf("Token() = %q, %v, want token-1", token, err)
+	}
+	// the token about to expire is still returned while a new one is requested
+	if token, err := p.Token(context.Background()); err != nil || token != "token-1" {
+		t.Fatalf("Token() = %q, %v, want token-1", token, err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&i.requests) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	p.mu.Lock()
+	for p.fetching != nil {
+		p.mu.Unlock()
+		time.Sleep(time.Millisecond)
+		p.mu.Lock()
+	}
+	p.mu.Unlock()
+	if token, err := p.Token(context.Background()); err != nil || token != "token-2" {
+		t.Errorf("Token() = %q, %v, want token-2", token, err)
+	}
+}
+
+func TestTokenProviderErrors(t *testing.T) {
+	i := newIdp(t)
+
+	_, err := i.provider("wrong").Token(context.Background())
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) {
+		t.Fatalf("Token() = %v, want a *TokenError", err)
+	}
+	if tokenErr.StatusCode != http.StatusUnauthorized || tokenErr.Code != "invalid_client" || !strings.HasPrefix(tokenErr.Description, "AADSTS7000215") {
+		t.Errorf("Token() = %+v, want the error of the response", tokenErr)
+	}
+
+	p := i.provider("secret")
+	p.Scope = ""
+	if _, err := p.Token(context.Background()); err == nil || err.Error() != "Scope is required" {
+		t.Errorf("Token() = %v, want Scope is required", err)
+	}
+
+	// a failed request isn't cached
+	if _, err := i.provider("secret").Token(context.Background()); err != nil {
+		t.Errorf("Token() = %v, want nil", err)
+	}
+}
+
+func TestTokenProviderCertificate(t *testing.T) {
+	i := newIdp(t)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bot"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewCertificateTokenProvider("tenant", "client", tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, ScopeGraph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Authority = i.URL
+	p.Client = i.Client()
+	if _, err := p.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	form := i.forms[0]
+	if form["client_secret"] != nil {
+		t.Errorf("client_secret = %q, want none", form["client_secret"])
+	}
+	if got := form["client_assertion_type"]; len(got) != 1 || got[0] != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+		t.Errorf("client_assertion_type = %q", got)
+	}
+	parts := strings.Split(strings.Join(form["client_assertion"], ""), ".")
+	if len(parts) != 3 {
+		t.Fatalf("client_assertion has %d parts, want 3", len(parts))
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature); err != nil {
+		t.Errorf("client_assertion signature: %v", err)
+	}
+	var claims map[string]interface{}
+	data, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	json.Unmarshal(data, &claims)
+	if claims["aud"] != i.URL+"/tenant/oauth2/v2.0/token" || claims["iss"] != "client" || claims["sub"] != "client" {
+		t.Errorf("client_assertion claims = %v", claims)
+	}
+
+	if _, err := NewCertificateTokenProvider("tenant", "client", tls.Certificate{}, ScopeGraph); err == nil {
+		t.Error("NewCertificateTokenProvider() without certificate = nil error, want an error")
+	}
+}
+
+func TestTransportRefreshesRejectedTokens(t *testing.T) {
+	i := newIdp(t)
+	p := i.provider("secret")
+
+	var authorizations []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		// the first token is revoked
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer api.Close()
+
+	client := &http.Client{Transport: &Transport{Source: p, Base: api.Client().Transport}}
+	want := []int{http.StatusUnauthorized, http.StatusOK, http.StatusOK}
+	for n, status := range want {
+		req, _ := http.NewRequest(http.MethodGet, api.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("request %d: status = %d, want %d", n, resp.StatusCode, status)
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Errorf("request %d: Authorization added to the original request", n)
+		}
+	}
+
+	wantAuthorizations := []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"}
+	if strings.Join(authorizations, ",") != strings.Join(wantAuthorizations, ",") {
+		t.Errorf("Authorization headers = %q, want %q", authorizations, wantAuthorizations)
+	}
+}
+
+func TestTransportTokenError(t *testing.T) {
+	i := newIdp(t)
+	client := i.provider("wrong").HTTPClient()
+
+	_, err := client.Get(i.URL)
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) {
+		t.Errorf("Get() = %v, want a *TokenError", err)
+	}
+}
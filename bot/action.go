@@ -0,0 +1,330 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// Action.Execute is sent to the bot as an invoke activity named "adaptiveCard/action", which the bot answers in the
+// response to the request with a message or a card replacing the card of the action. Action.Submit is sent as a
+// message activity whose value holds the data of the action merged with the input values. Action.Submit has no
+// verb, so the "verb" property of its data is used instead.
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/universal-actions-for-adaptive-cards/overview
+
+// InvokeNameAdaptiveCardAction is the name of the invoke activities of Action.Execute
+const InvokeNameAdaptiveCardAction = "adaptiveCard/action"
+
+// The types of the values of invoke responses
+const (
+	ContentTypeMessage      = "application/vnd.microsoft.activity.message"
+	ContentTypeAdaptiveCard = teams.ContentTypeAdaptiveCard
+	ContentTypeError        = "application/vnd.microsoft.error"
+)
+
+// the largest activity read by the handlers
+const maxActivitySize = 1 << 20
+
+// An InvokeResponse is the body of the response to an adaptiveCard/action invoke activity
+type InvokeResponse struct {
+	// Status of the invocation, 200 on success
+	StatusCode int `json:"statusCode"`
+	// Type of the value, e.g. ContentTypeMessage
+	Type string `json:"type"`
+	// The text of a message, a card or an InvokeError
+	Value interface{} `json:"value,omitempty"`
+}
+
+// An InvokeError is the value of a failed InvokeResponse
+type InvokeError struct {
+	// Machine-readable code of the error, e.g. "BadRequest"
+	Code string `json:"code"`
+	// Description of the error shown to the user
+	Message string `json:"message"`
+}
+
+// An ActionRequest is an invocation of an Action.Execute or Action.Submit of a card
+type ActionRequest struct {
+	// Type of the action, teams.TypeActionExecute or teams.TypeActionSubmit
+	Type teams.Type
+	// Id of the action, if it has one
+	ActionId string
+	// Verb of an Action.Execute, or the "verb" property of the data of an Action.Submit
+	Verb string
+	// The data of the action merged with the values of its inputs, keyed by the ids of the inputs
+	Data json.RawMessage
+	// How the action was triggered: "manual" when the user selected it, "automatic" for a refresh
+	Trigger string
+	// The activity carrying the action, with the user who selected it in From
+	Activity *Activity
+}
+
+// Decode decodes the data of the action into v, like json.Unmarshal. Since cards send the values of all inputs as
+// strings, they are converted to the type of the field they are decoded into: numbers for Input.Number, booleans for
+// Input.Toggle and slices for the comma-separated choices of a multi-select Input.ChoiceSet
+func (r *ActionRequest) Decode(v interface{}) error {
+	if len(r.Data) == 0 {
+		return nil
+	}
+
+	return decodeInputs(r.Data, v)
+}
+
+// An ActionResponse is the answer of the bot to an action
+type ActionResponse struct {
+	// Text shown to the user, used if Card is nil
+	Message string
+	// Card replacing the card of the action
+	Card *teams.AdaptiveCard
+}
+
+// MessageResponse returns an ActionResponse showing the text to the user
+func MessageResponse(text string) *ActionResponse {
+	return &ActionResponse{Message: text}
+}
+
+// CardResponse returns an ActionResponse replacing the card of the action with the given card
+func CardResponse(card *teams.AdaptiveCard) *ActionResponse {
+	return &ActionResponse{Card: card}
+}
+
+// ActionError is returned by an ActionFunc to answer with an error shown to the user. Other errors are answered with
+// a generic error and status 500
+type ActionError struct {
+	// HTTP status of the error, 400 if not set
+	StatusCode int
+	// Machine-readable code of the error, derived from the status if not set
+	Code string
+	// Description of the error shown to the user
+	Message string
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("action failed with status %d: %s", e.status(), e.Message)
+}
+
+func (e *ActionError) status() int {
+	if e.StatusCode == 0 {
+		return http.StatusBadRequest
+	}
+	return e.StatusCode
+}
+
+// An ActionFunc handles the actions with a given verb
+type ActionFunc func(ctx context.Context, req *ActionRequest) (*ActionResponse, error)
+
+// ActionHandler is an http.Handler receiving the activities of Action.Execute and Action.Submit and dispatching them
+// to the ActionFunc registered for their verb. It doesn’t authenticate the requests
+type ActionHandler struct {
+	// Handles the activities that aren’t card actions, which are answered with status 200 and ignored when nil. The
+	// body of the request can be read again
+	Other http.Handler
+	// Logs the errors returned by the ActionFuncs, the standard logger when nil
+	ErrorLog *log.Logger
+
+	mu      sync.RWMutex
+	actions map[string]ActionFunc
+}
+
+func NewActionHandler() *ActionHandler {
+	return &ActionHandler{actions: map[string]ActionFunc{}}
+}
+
+// Handle registers the function handling the actions with the given verb. The empty verb handles Action.Submit
+// actions without a verb
+func (h *ActionHandler) Handle(verb string, f ActionFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.actions == nil {
+		h.actions = map[string]ActionFunc{}
+	}
+	h.actions[verb] = f
+}
+
+// HandleAction registers the function handling the actions with the given verb, passing it the data of the action
+// decoded into a T with ActionRequest.Decode. Data that can’t be decoded is answered with status 400
+func HandleAction[T any](h *ActionHandler, verb string, f func(ctx context.Context, req *ActionRequest, data T) (*ActionResponse, error)) {
+	h.Handle(verb, func(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+		var data T
+		if err := req.Decode(&data); err != nil {
+			return nil, &ActionError{Message: fmt.Sprintf("invalid data: %v", err)}
+		}
+		return f(ctx, req, data)
+	})
+}
+
+func (h *ActionHandler) handler(verb string) (ActionFunc, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	f, ok := h.actions[verb]
+	return f, ok
+}
+
+func (h *ActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxActivitySize))
+	if err != nil {
+		http.Error(w, "activity too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	var activity Activity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		http.Error(w, fmt.Sprintf("invalid activity: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case activity.Type == ActivityTypeInvoke && activity.Name == InvokeNameAdaptiveCardAction:
+		h.execute(w, r, &activity)
+	case activity.Type == ActivityTypeMessage && len(activity.Value) > 0 && activity.Value[0] == '{':
+		h.submit(w, r, &activity)
+	case h.Other != nil:
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		h.Other.ServeHTTP(w, r)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// execute answers the invoke activity of an Action.Execute with an InvokeResponse
+func (h *ActionHandler) execute(w http.ResponseWriter, r *http.Request, activity *Activity) {
+	var value struct {
+		Action struct {
+			Type teams.Type      `json:"type"`
+			Id   string          `json:"id"`
+			Verb string          `json:"verb"`
+			Data json.RawMessage `json:"data"`
+		} `json:"action"`
+		Trigger string `json:"trigger"`
+	}
+	if err := json.Unmarshal(activity.Value, &value); err != nil {
+		writeInvokeError(w, &ActionError{Message: fmt.Sprintf("invalid value: %v", err)})
+		return
+	}
+
+	req := &ActionRequest{
+		Type:     value.Action.Type,
+		ActionId: value.Action.Id,
+		Verb:     value.Action.Verb,
+		Data:     value.Action.Data,
+		Trigger:  value.Trigger,
+		Activity: activity,
+	}
+	resp, err := h.dispatch(r.Context(), req)
+	if err != nil {
+		writeInvokeError(w, err)
+		return
+	}
+	writeInvokeResponse(w, resp)
+}
+
+// submit handles the message activity of an Action.Submit. Replies to messages can only be sent through the Bot
+// Connector, so the response of the ActionFunc is ignored
+func (h *ActionHandler) submit(w http.ResponseWriter, r *http.Request, activity *Activity) {
+	var data struct {
+		Verb string `json:"verb"`
+	}
+	// the value is an object, so only a verb that isn’t a string fails
+	_ = json.Unmarshal(activity.Value, &data)
+
+	req := &ActionRequest{
+		Type:     teams.TypeActionSubmit,
+		Verb:     data.Verb,
+		Data:     activity.Value,
+		Trigger:  "manual",
+		Activity: activity,
+	}
+	if _, err := h.dispatch(r.Context(), req); err != nil {
+		var actionErr *ActionError
+		if errors.As(err, &actionErr) {
+			http.Error(w, actionErr.Message, actionErr.status())
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch calls the ActionFunc of the verb of the request. Errors other than ActionErrors are logged
+func (h *ActionHandler) dispatch(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+	f, ok := h.handler(req.Verb)
+	if !ok {
+		return nil, &ActionError{Code: "BadRequest", Message: fmt.Sprintf("unknown verb %q", req.Verb)}
+	}
+
+	resp, err := f(ctx, req)
+	var actionErr *ActionError
+	if err != nil && !errors.As(err, &actionErr) {
+		h.logf("bot: action %q: %v", req.Verb, err)
+	}
+
+	return resp, err
+}
+
+func (h *ActionHandler) logf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+func writeInvokeResponse(w http.ResponseWriter, resp *ActionResponse) {
+	body := InvokeResponse{StatusCode: http.StatusOK, Type: ContentTypeMessage, Value: ""}
+	if resp != nil && resp.Card != nil {
+		body.Type, body.Value = ContentTypeAdaptiveCard, resp.Card
+	} else if resp != nil {
+		body.Value = resp.Message
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+func writeInvokeError(w http.ResponseWriter, err error) {
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) {
+		actionErr = &ActionError{StatusCode: http.StatusInternalServerError, Message: "the action failed"}
+	}
+
+	status := actionErr.status()
+	code := actionErr.Code
+	if code == "" {
+		// e.g. "BadRequest"
+		code = strings.ReplaceAll(http.StatusText(status), " ", "")
+	}
+	writeJSON(w, status, InvokeResponse{
+		StatusCode: status,
+		Type:       ContentTypeError,
+		Value:      InvokeError{Code: code, Message: actionErr.Message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+type approval struct {
+	Id       string   `json:"id"`
+	Replicas int      `json:"replicas"`
+	Notify   bool     `json:"notify"`
+	Regions  []string `json:"regions"`
+}
+
+func newActionHandler(t *testing.T) (*ActionHandler, *[]approval) {
+	var approvals []approval
+	h := NewActionHandler()
+	h.ErrorLog = log.New(io.Discard, "", 0)
+	HandleAction(h, "approve", func(ctx context.Context, req *ActionRequest, data approval) (*ActionResponse, error) {
+		approvals = append(approvals, data)
+		if data.Id == "card" {
+			card := teams.NewAdaptiveCard()
+			card.Body = append(card.Body, teams.NewTextBlock("Approved"))
+			return CardResponse(card), nil
+		}
+		return MessageResponse("approved " + data.Id), nil
+	})
+	h.Handle("reject", func(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+		return nil, &ActionError{StatusCode: http.StatusForbidden, Message: "not allowed"}
+	})
+	h.Handle("crash", func(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+		return nil, errors.New("database unavailable")
+	})
+
+	return h, &approvals
+}
+
+func invoke(verb string, data string) string {
+	return `{"type":"invoke","name":"adaptiveCard/action","value":{"action":{"type":"Action.Execute","verb":"` + verb +
+		`","data":` + data + `},"trigger":"manual"}}`
+}
+
+func TestActionHandlerExecute(t *testing.T) {
+	tests := []struct {
+		name      string
+		activity  string
+		status    int
+		valueType string
+		value     string
+	}{
+		{"message", invoke("approve", `{"id":"42"}`), http.StatusOK, ContentTypeMessage, `"approved 42"`},
+		{"card", invoke("approve", `{"id":"card"}`), http.StatusOK, ContentTypeAdaptiveCard, `Approved`},
+		{"invalid data", invoke("approve", `{"replicas":"many"}`), http.StatusBadRequest, ContentTypeError, `"code":"BadRequest"`},
+		{"action error", invoke("reject", `{}`), http.StatusForbidden, ContentTypeError, `{"code":"Forbidden","message":"not allowed"}`},
+		{"other error", invoke("crash", `{}`), http.StatusInternalServerError, ContentTypeError, `{"code":"InternalServerError","message":"the action failed"}`},
+		{"unknown verb", invoke("deploy", `{}`), http.StatusBadRequest, ContentTypeError, `unknown verb \"deploy\"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newActionHandler(t)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader(tt.activity)))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			var resp struct {
+				StatusCode int             `json:"statusCode"`
+				Type       string          `json:"type"`
+				Value      json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status || resp.Type != tt.valueType || !strings.Contains(string(resp.Value), tt.value) {
+				t.Errorf("response = %s, want %s %s containing %s", w.Body, tt.valueType, http.StatusText(tt.status), tt.value)
+			}
+		})
+	}
+}
+
+func TestActionHandlerDecodesInputs(t *testing.T) {
+	h, approvals := newActionHandler(t)
+	activity := invoke("approve", `{"id":"42","replicas":"3","notify":"true","regions":"eu,us"}`)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader(activity)))
+
+	want := []approval{{Id: "42", Replicas: 3, Notify: true, Regions: []string{"eu", "us"}}}
+	if !reflect.DeepEqual(*approvals, want) {
+		t.Errorf("data = %+v, want %+v", *approvals, want)
+	}
+}
+
+func TestActionHandlerSubmit(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		status int
+	}{
+		{"approve", `{"verb":"approve","id":"42"}`, http.StatusOK},
+		{"action error", `{"verb":"reject"}`, http.StatusForbidden},
+		{"other error", `{"verb":"crash"}`, http.StatusInternalServerError},
+		{"without verb", `{"id":"42"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, approvals := newActionHandler(t)
+			activity := `{"type":"message","value":` + tt.value + `}`
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader(activity)))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status == http.StatusOK && (len(*approvals) != 1 || (*approvals)[0].Id != "42") {
+				t.Errorf("data = %+v, want id 42", *approvals)
+			}
+		})
+	}
+}
+
+func TestActionHandlerOther(t *testing.T) {
+	h, _ := newActionHandler(t)
+	activity := `{"type":"message","text":"hello"}`
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader(activity)))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var body []byte
+	h.Other = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader(activity)))
+	if w.Code != http.StatusAccepted || string(body) != activity {
+		t.Errorf("status = %d, body = %s, want %d and the activity", w.Code, body, http.StatusAccepted)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader("{")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status of invalid activity = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,260 @@
+// Package bot implements the Bot Framework side of a Teams bot: the Activity schema, a Connector client to send,
+// update and delete activities, and the handlers receiving the invocations of the Action.Execute and Action.Submit
+// actions of the cards built with package teams.
+package bot
+
+import (
+	"encoding/json"
+	"html"
+	"time"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// The types of activities
+const (
+	ActivityTypeMessage            = "message"
+	ActivityTypeInvoke             = "invoke"
+	ActivityTypeEvent              = "event"
+	ActivityTypeTyping             = "typing"
+	ActivityTypeConversationUpdate = "conversationUpdate"
+	ActivityTypeMessageReaction    = "messageReaction"
+	ActivityTypeMessageUpdate      = "messageUpdate"
+	ActivityTypeMessageDelete      = "messageDelete"
+)
+
+// The formats of the text of message activities
+const (
+	TextFormatMarkdown = "markdown"
+	TextFormatPlain    = "plain"
+	TextFormatXML      = "xml"
+)
+
+// An Activity is a message, an invocation or another event exchanged between a channel like Teams and a bot
+//
+// Source: https://github.com/microsoft/botframework-sdk/blob/main/specs/botframework-activity/botframework-activity.md
+type Activity struct {
+	// Type of the activity, e.g. ActivityTypeMessage or ActivityTypeInvoke
+	Type string `json:"type"`
+	// Id of the activity
+	Id string `json:"id,omitempty"`
+	// Name of the operation of invoke and event activities, e.g. "adaptiveCard/action"
+	Name string `json:"name,omitempty"`
+	// When the activity was sent, in UTC
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// URL of the Bot Connector of the channel, where replies to the activity are sent to
+	ServiceUrl string `json:"serviceUrl,omitempty"`
+	// Id of the channel, "msteams" for Teams
+	ChannelId string `json:"channelId,omitempty"`
+	// The sender of the activity
+	From ChannelAccount `json:"from"`
+	// The recipient of the activity, usually the bot
+	Recipient ChannelAccount `json:"recipient"`
+	// The conversation the activity is part of
+	Conversation ConversationAccount `json:"conversation"`
+	// Id of the activity this activity replies to, e.g. the message containing the card of an action
+	ReplyToId string `json:"replyToId,omitempty"`
+	// Text of a message activity
+	Text string `json:"text,omitempty"`
+	// Format of Text, e.g. TextFormatMarkdown
+	TextFormat string `json:"textFormat,omitempty"`
+	// Text shown in notifications instead of the attachments
+	Summary string `json:"summary,omitempty"`
+	// Locale of the sender, e.g. "en-US"
+	Locale string `json:"locale,omitempty"`
+	// Attachments of a message activity, like cards
+	Attachments []teams.Attachment `json:"attachments,omitempty"`
+	// How several attachments are shown: "list" or "carousel"
+	AttachmentLayout string `json:"attachmentLayout,omitempty"`
+	// Metadata of the activity, like the @mentions of its text
+	Entities []Entity `json:"entities,omitempty"`
+	// Value of the activity, e.g. the data of an Action.Submit or the action of an invoke
+	Value json.RawMessage `json:"value,omitempty"`
+	// Teams-specific data of the activity, like the team and channel it was sent in
+	ChannelData *TeamsChannelData `json:"channelData,omitempty"`
+}
+
+// NewMessageActivity returns a message activity with the given text, which may contain markdown
+func NewMessageActivity(text string) *Activity {
+	return &Activity{
+		Type:       ActivityTypeMessage,
+		Text:       text,
+		TextFormat: TextFormatMarkdown,
+	}
+}
+
+// NewCardActivity returns a message activity with the card as its only attachment. Like teams.NewMessage, its
+// summary is the first line of the plain-text rendering of the card
+func NewCardActivity(card *teams.AdaptiveCard) *Activity {
+	m := teams.NewMessage(card)
+
+	return &Activity{
+		Type:        ActivityTypeMessage,
+		Summary:     m.Summary,
+		Attachments: m.Attachments,
+	}
+}
+
+// MarshalJSON omits the accounts that aren’t set, since activities sent to the Bot Connector usually only set the
+// conversation in the URL
+func (a Activity) MarshalJSON() ([]byte, error) {
+	type alias Activity
+	aux := struct {
+		alias
+		From         *ChannelAccount      `json:"from,omitempty"`
+		Recipient    *ChannelAccount      `json:"recipient,omitempty"`
+		Conversation *ConversationAccount `json:"conversation,omitempty"`
+	}{alias: alias(a)}
+	if a.From != (ChannelAccount{}) {
+		aux.From = &a.From
+	}
+	if a.Recipient != (ChannelAccount{}) {
+		aux.Recipient = &a.Recipient
+	}
+	if a.Conversation != (ConversationAccount{}) {
+		aux.Conversation = &a.Conversation
+	}
+
+	return json.Marshal(aux)
+}
+
+// AddMention appends an @mention of the account to the text of the activity and adds its Entity
+func (a *Activity) AddMention(account ChannelAccount) {
+	mention := NewMention(account)
+	if a.Text != "" && a.Text[len(a.Text)-1] != ' ' {
+		a.Text += " "
+	}
+	a.Text += mention.Text
+	a.Entities = append(a.Entities, mention)
+}
+
+// The types of entities
+const (
+	EntityTypeMention    = "mention"
+	EntityTypeClientInfo = "clientInfo"
+)
+
+// An Entity is metadata of an activity, like an @mention in its text. The properties of entity types other than
+// mentions are kept in Properties
+type Entity struct {
+	// Type of the entity, e.g. EntityTypeMention
+	Type string `json:"type"`
+	// The account mentioned by a mention
+	Mentioned *ChannelAccount `json:"mentioned,omitempty"`
+	// The text of a mention in the text of the activity, e.g. "<at>Jane Doe</at>"
+	Text string `json:"text,omitempty"`
+	// The other properties of the entity
+	Properties map[string]json.RawMessage `json:"-"`
+}
+
+// NewMention returns the mention entity of the account, whose text must be part of the text of the activity
+func NewMention(account ChannelAccount) Entity {
+	return Entity{
+		Type:      EntityTypeMention,
+		Mentioned: &account,
+		Text:      "<at>" + html.EscapeString(account.Name) + "</at>",
+	}
+}
+
+func (e Entity) MarshalJSON() ([]byte, error) {
+	type alias Entity
+	data, err := json.Marshal(alias(e))
+	if err != nil || len(e.Properties) == 0 {
+		return data, err
+	}
+
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+	for key, value := range e.Properties {
+		if _, ok := props[key]; !ok {
+			props[key] = value
+		}
+	}
+
+	return json.Marshal(props)
+}
+
+func (e *Entity) UnmarshalJSON(data []byte) error {
+	type alias Entity
+	if err := json.Unmarshal(data, (*alias)(e)); err != nil {
+		return err
+	}
+
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(data, &props); err != nil {
+		return err
+	}
+	for _, known := range []string{"type", "mentioned", "text"} {
+		delete(props, known)
+	}
+	e.Properties = nil
+	if len(props) > 0 {
+		e.Properties = props
+	}
+
+	return nil
+}
+
+// A ChannelAccount is a user or bot of a channel
+type ChannelAccount struct {
+	// Id of the account in the channel, e.g. "29:1abc…" in Teams
+	Id string `json:"id"`
+	// Display name of the account
+	Name string `json:"name,omitempty"`
+	// Id of the user in Azure Active Directory
+	AadObjectId string `json:"aadObjectId,omitempty"`
+}
+
+// A ConversationAccount is a conversation of a channel, like a channel thread or a chat
+type ConversationAccount struct {
+	// Id of the conversation
+	Id string `json:"id"`
+	// Name of the conversation
+	Name string `json:"name,omitempty"`
+	// Type of the conversation in Teams: personal, groupChat or channel
+	ConversationType string `json:"conversationType,omitempty"`
+	// Id of the Azure Active Directory tenant of the conversation
+	TenantId string `json:"tenantId,omitempty"`
+	// Whether the conversation has more than two participants
+	IsGroup bool `json:"isGroup,omitempty"`
+}
+
+// TeamsChannelData is the Teams-specific data of an activity
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/bots/how-to/conversations/send-and-receive-messages#teams-channel-data
+type TeamsChannelData struct {
+	// The team the activity was sent in, if it was sent in a channel
+	Team *TeamInfo `json:"team,omitempty"`
+	// The channel the activity was sent in, if it was sent in a channel
+	Channel *ChannelInfo `json:"channel,omitempty"`
+	// The Azure Active Directory tenant of the conversation
+	Tenant *TenantInfo `json:"tenant,omitempty"`
+	// Type of conversation update events, e.g. "channelCreated"
+	EventType string `json:"eventType,omitempty"`
+}
+
+// A TeamInfo identifies a team
+type TeamInfo struct {
+	// Id of the team, which is the id of its General channel
+	Id string `json:"id"`
+	// Name of the team
+	Name string `json:"name,omitempty"`
+	// Id of the Azure Active Directory group of the team, used by Microsoft Graph
+	AadGroupId string `json:"aadGroupId,omitempty"`
+}
+
+// A ChannelInfo identifies a channel of a team
+type ChannelInfo struct {
+	// Id of the channel, e.g. "19:abc…@thread.skype"
+	Id string `json:"id"`
+	// Name of the channel, empty for the General channel
+	Name string `json:"name,omitempty"`
+}
+
+// A TenantInfo identifies an Azure Active Directory tenant
+type TenantInfo struct {
+	// Id of the tenant
+	Id string `json:"id"`
+}
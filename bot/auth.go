@@ -0,0 +1,313 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Issuer is the issuer of the tokens of the requests the Bot Framework sends to bots
+const Issuer = "https://api.botframework.com"
+
+// DefaultClockSkew is the difference allowed between the clocks of the Bot Framework and the bot when checking the
+// expiry of tokens
+const DefaultClockSkew = 5 * time.Minute
+
+var (
+	// ErrMissingToken is returned when a request has no Bearer token in its Authorization header
+	ErrMissingToken = errors.New("missing bearer token")
+	// ErrInvalidToken is wrapped by the errors returned for tokens that aren’t valid
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Claims are the claims of a valid token
+type Claims struct {
+	// Issuer of the token, Issuer for the Bot Framework
+	Issuer string
+	// Audiences of the token, including the app id of the bot
+	Audience []string
+	// When the token expires
+	ExpiresAt time.Time
+	// When the token becomes valid, zero if not set
+	NotBefore time.Time
+	// URL of the Bot Connector the token was issued for, compared to the ServiceUrl of the activity
+	ServiceUrl string
+	// All the claims of the token
+	Raw map[string]json.RawMessage
+}
+
+// Verifier verifies that requests sent to a bot come from the Bot Framework, by checking the RS256 JSON Web Token in
+// their Authorization header
+//
+// Source: https://learn.microsoft.com/en-us/azure/bot-service/rest-api/bot-framework-rest-connector-authentication#bot-connector-to-bot
+type Verifier struct {
+	// App id of the bot, the expected audience of the tokens
+	AppId string
+	// Expected issuer of the tokens, Issuer if empty
+	Issuer string
+	// Source of the keys signing the tokens
+	Keys KeySource
+	// Difference allowed between the clocks, DefaultClockSkew if 0
+	ClockSkew time.Duration
+	// Logs why requests are rejected by the middleware, the standard logger when nil
+	ErrorLog *log.Logger
+}
+
+// NewVerifier returns a Verifier for the bot with the given app id. Use NewOpenIDKeys(OpenIDMetadataUrl, nil) as
+// source of the keys of the Bot Framework
+func NewVerifier(appId string, keys KeySource) *Verifier {
+	return &Verifier{AppId: appId, Keys: keys}
+}
+
+// Verify checks the signature, issuer, audience and expiry of the token and returns its claims
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims, _, err := v.verify(ctx, token)
+	return claims, err
+}
+
+// VerifyActivity checks the Authorization header of the request of the activity: its Bearer token must be valid, be
+// issued for the ServiceUrl of the activity and be signed by a key endorsed for its channel
+func (v *Verifier) VerifyActivity(ctx context.Context, authorization string, activity *Activity) (*Claims, error) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrMissingToken
+	}
+
+	claims, key, err := v.verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ServiceUrl == "" {
+		return nil, fmt.Errorf("%w: serviceUrl claim is required", ErrInvalidToken)
+	}
+	if strings.TrimSuffix(claims.ServiceUrl, "/") != strings.TrimSuffix(activity.ServiceUrl, "/") {
+		return nil, fmt.Errorf("%w: serviceUrl claim is invalid; expected: %s, got %s", ErrInvalidToken,
+			activity.ServiceUrl, claims.ServiceUrl)
+	}
+	if !key.endorses(activity.ChannelId) {
+		return nil, fmt.Errorf("%w: key %q is not endorsed for channel %q", ErrInvalidToken, key.Kid, activity.ChannelId)
+	}
+
+	return claims, nil
+}
+
+// verify checks the token and returns its claims and the key that signed it
+func (v *Verifier) verify(ctx context.Context, token string) (*Claims, *JSONWebKey, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, nil, fmt.Errorf("%w: alg is invalid; expected: RS256, got %s", ErrInvalidToken, header.Alg)
+	}
+
+	if v.Keys == nil {
+		return nil, nil, errors.New("Keys is required")
+	}
+	key, err := v.Keys.Key(ctx, header.Kid)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, nil, fmt.Errorf("%w: key %q: %v", ErrInvalidToken, header.Kid, err)
+	} else if err != nil {
+		return nil, nil, err
+	}
+	pub, err := key.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], signature); err != nil {
+		return nil, nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+
+	claims, err := parseClaims(parts[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return claims, key, nil
+}
+
+// checkClaims checks the issuer, audience and validity period of the claims
+func (v *Verifier) checkClaims(claims *Claims) error {
+	issuer := v.Issuer
+	if issuer == "" {
+		issuer = Issuer
+	}
+	if claims.Issuer != issuer {
+		return fmt.Errorf("iss is invalid; expected: %s, got %s", issuer, claims.Issuer)
+	}
+
+	if v.AppId == "" {
+		return errors.New("AppId is required")
+	}
+	audience := false
+	for _, aud := range claims.Audience {
+		audience = audience || aud == v.AppId
+	}
+	if !audience {
+		return fmt.Errorf("aud is invalid; expected: %s, got %s", v.AppId, strings.Join(claims.Audience, ", "))
+	}
+
+	skew := v.ClockSkew
+	if skew == 0 {
+		skew = DefaultClockSkew
+	}
+	now := time.Now()
+	if claims.ExpiresAt.IsZero() {
+		return errors.New("exp is required")
+	}
+	if now.After(claims.ExpiresAt.Add(skew)) {
+		return fmt.Errorf("token expired at %s", claims.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if !claims.NotBefore.IsZero() && now.Before(claims.NotBefore.Add(-skew)) {
+		return fmt.Errorf("token not valid before %s", claims.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// parseClaims decodes the payload segment of a token
+func parseClaims(segment string) (*Claims, error) {
+	var raw map[string]json.RawMessage
+	if err := decodeSegment(segment, &raw); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{Raw: raw}
+	var err error
+	if data, ok := raw["iss"]; ok {
+		err = json.Unmarshal(data, &claims.Issuer)
+	}
+	if data, ok := raw["aud"]; ok && err == nil {
+		// aud is either a string or an array of strings
+		if err = json.Unmarshal(data, &claims.Audience); err != nil {
+			var aud string
+			if err = json.Unmarshal(data, &aud); err == nil {
+				claims.Audience = []string{aud}
+			}
+		}
+	}
+	if err == nil {
+		claims.ExpiresAt, err = numericDate(raw, "exp")
+	}
+	if err == nil {
+		claims.NotBefore, err = numericDate(raw, "nbf")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the Bot Framework names the claim "serviceurl"
+	for name, data := range raw {
+		if strings.EqualFold(name, "serviceUrl") {
+			if err := json.Unmarshal(data, &claims.ServiceUrl); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+// numericDate returns the time of the NumericDate claim, the zero time if not set
+func numericDate(raw map[string]json.RawMessage, name string) (time.Time, error) {
+	data, ok := raw[name]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
+
+// decodeSegment decodes the JSON of a base64url-encoded segment of a token
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims of the token of a request authenticated by the middleware of a Verifier
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// Middleware returns a handler that passes the activities whose request is authenticated by VerifyActivity to next,
+// with their claims in the context of the request, and rejects the others with 401 Unauthorized
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxActivitySize))
+		if err != nil {
+			http.Error(w, "activity too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		var activity Activity
+		if err := json.Unmarshal(body, &activity); err != nil {
+			http.Error(w, fmt.Sprintf("invalid activity: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		claims, err := v.VerifyActivity(r.Context(), r.Header.Get("Authorization"), &activity)
+		if err != nil {
+			v.logf("bot: rejected %s activity from %s: %v", activity.Type, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims))
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (v *Verifier) logf(format string, args ...interface{}) {
+	if v.ErrorLog != nil {
+		v.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
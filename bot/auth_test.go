@@ -0,0 +1,186 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	testAppId      = "bot-app-id"
+	testServiceUrl = "https://smba.trafficmanager.net/emea/"
+)
+
+var testKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+
+// testKeys returns a key set with testKey as "k1", endorsed for msteams, and another key as "k2"
+func testKeys(t *testing.T) *JSONWebKeySet {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &JSONWebKeySet{Keys: []JSONWebKey{
+		jwk("k1", &testKey.PublicKey, "msteams"),
+		jwk("k2", &other.PublicKey),
+	}}
+}
+
+func jwk(kid string, pub *rsa.PublicKey, endorsements ...string) JSONWebKey {
+	return JSONWebKey{
+		Kty:          "RSA",
+		Kid:          kid,
+		N:            base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:            base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		Endorsements: endorsements,
+	}
+}
+
+// testClaims returns the claims of a valid token
+func testClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":        Issuer,
+		"aud":        testAppId,
+		"exp":        time.Now().Add(time.Hour).Unix(),
+		"nbf":        time.Now().Add(-time.Minute).Unix(),
+		"serviceurl": testServiceUrl,
+	}
+}
+
+// token returns a token with the given header and claims signed by key
+func token(t *testing.T, key *rsa.PrivateKey, header map[string]interface{}, claims map[string]interface{}) string {
+	segment := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signed := segment(header) + "." + segment(claims)
+	hash := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyActivity(t *testing.T) {
+	v := NewVerifier(testAppId, testKeys(t))
+	header := map[string]interface{}{"alg": "RS256", "kid": "k1"}
+	with := func(name string, value interface{}) map[string]interface{} {
+		claims := testClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	valid := token(t, testKey, header, testClaims())
+
+	tests := []struct {
+		name          string
+		authorization string
+		channelId     string
+		want          error
+	}{
+		{"valid", "Bearer " + valid, "msteams", nil},
+		{"lower-case scheme", "bearer " + valid, "msteams", nil},
+		{"audience array", "Bearer " + token(t, testKey, header, with("aud", []string{"other", testAppId})), "msteams", nil},
+		{"service url without slash", "Bearer " + token(t, testKey, header, with("serviceurl", "https://smba.trafficmanager.net/emea")), "msteams", nil},
+		{"expired within clock skew", "Bearer " + token(t, testKey, header, with("exp", time.Now().Add(-time.Minute).Unix())), "msteams", nil},
+		{"missing", "", "msteams", ErrMissingToken},
+		{"basic", "Basic dXNlcjpwYXNz", "msteams", ErrMissingToken},
+		{"bearer without token", "Bearer ", "msteams", ErrMissingToken},
+		{"malformed", "Bearer abc.def", "msteams", ErrInvalidToken},
+		{"alg none", "Bearer " + token(t, testKey, map[string]interface{}{"alg": "none", "kid": "k1"}, testClaims()), "msteams", ErrInvalidToken},
+		{"alg HS256", "Bearer " + token(t, testKey, map[string]interface{}{"alg": "HS256", "kid": "k1"}, testClaims()), "msteams", ErrInvalidToken},
+		{"unknown key", "Bearer " + token(t, testKey, map[string]interface{}{"alg": "RS256", "kid": "k3"}, testClaims()), "msteams", ErrInvalidToken},
+		{"signed by another key", "Bearer " + token(t, testKey, map[string]interface{}{"alg": "RS256", "kid": "k2"}, testClaims()), "msteams", ErrInvalidToken},
+		{"tampered claims", "Bearer " + tamper(valid), "msteams", ErrInvalidToken},
+		{"wrong issuer", "Bearer " + token(t, testKey, header, with("iss", "https://sts.windows.net/")), "msteams", ErrInvalidToken},
+		{"missing issuer", "Bearer " + token(t, testKey, header, with("iss", nil)), "msteams", ErrInvalidToken},
+		{"wrong audience", "Bearer " + token(t, testKey, header, with("aud", "other-app")), "msteams", ErrInvalidToken},
+		{"missing audience", "Bearer " + token(t, testKey, header, with("aud", nil)), "msteams", ErrInvalidToken},
+		{"expired", "Bearer " + token(t, testKey, header, with("exp", time.Now().Add(-time.Hour).Unix())), "msteams", ErrInvalidToken},
+		{"missing expiry", "Bearer " + token(t, testKey, header, with("exp", nil)), "msteams", ErrInvalidToken},
+		{"not yet valid", "Bearer " + token(t, testKey, header, with("nbf", time.Now().Add(time.Hour).Unix())), "msteams", ErrInvalidToken},
+		{"wrong service url", "Bearer " + token(t, testKey, header, with("serviceurl", "https://evil.example.com/")), "msteams", ErrInvalidToken},
+		{"missing service url", "Bearer " + token(t, testKey, header, with("serviceurl", nil)), "msteams", ErrInvalidToken},
+		{"key not endorsed for channel", "Bearer " + valid, "skype", ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := &Activity{Type: "message", ServiceUrl: testServiceUrl, ChannelId: tt.channelId}
+			claims, err := v.VerifyActivity(context.Background(), tt.authorization, activity)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyActivity() = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (claims == nil || claims.Issuer != Issuer) {
+				t.Errorf("VerifyActivity() claims = %+v, want the claims of the token", claims)
+			}
+		})
+	}
+}
+
+// tamper replaces the claims of a token, keeping its signature
+func tamper(token string) string {
+	parts := bytes.Split([]byte(token), []byte("."))
+	claims, _ := json.Marshal(map[string]interface{}{"iss": Issuer, "aud": "other-app", "exp": time.Now().Add(time.Hour).Unix()})
+	parts[1] = []byte(base64.RawURLEncoding.EncodeToString(claims))
+
+	return string(bytes.Join(parts, []byte(".")))
+}
+
+func TestMiddleware(t *testing.T) {
+	v := NewVerifier(testAppId, testKeys(t))
+	v.ErrorLog = log.New(io.Discard, "", 0)
+	var got *Claims
+	var gotBody []byte
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = ClaimsFromContext(r.Context())
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	body, _ := json.Marshal(Activity{Type: "message", ServiceUrl: testServiceUrl, ChannelId: "msteams"})
+
+	r := httptest.NewRequest(http.MethodPost, "/api/messages", bytes.NewReader(body))
+	r.Header.Set("Authorization", "Bearer "+token(t, testKey, map[string]interface{}{"alg": "RS256", "kid": "k1"}, testClaims()))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got == nil || got.ServiceUrl != testServiceUrl {
+		t.Errorf("claims = %+v, want the claims of the token", got)
+	}
+	if !bytes.Equal(gotBody, body) {
+		t.Errorf("body = %s, want %s", gotBody, body)
+	}
+
+	got = nil
+	r = httptest.NewRequest(http.MethodPost, "/api/messages", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized || got != nil {
+		t.Errorf("status = %d, want %d without calling the handler", w.Code, http.StatusUnauthorized)
+	}
+	if w.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("WWW-Authenticate = %q, want Bearer", w.Header().Get("WWW-Authenticate"))
+	}
+}
@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// the longest part of a response body kept in a ConnectorError
+const maxErrorBodyLength = 4096
+
+// Connector sends, updates and deletes activities through the Bot Connector REST API of a channel. The requests are
+// sent with the given http.Client, whose transport must add the bot’s token to them, like the HTTPClient of an
+// auth.TokenProvider
+//
+// Source: https://learn.microsoft.com/en-us/azure/bot-service/rest-api/bot-framework-rest-connector-api-reference
+type Connector struct {
+	// URL of the Bot Connector, the ServiceUrl of the activities received from the channel
+	ServiceUrl string
+
+	client *http.Client
+}
+
+// NewConnector returns a Connector for the Bot Connector at serviceUrl, e.g. "https://smba.trafficmanager.net/emea/"
+func NewConnector(serviceUrl string, client *http.Client) (*Connector, error) {
+	u, err := url.Parse(serviceUrl)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("serviceUrl is not valid")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Connector{ServiceUrl: strings.TrimSuffix(serviceUrl, "/"), client: client}, nil
+}
+
+// A ResourceResponse identifies the activity created or updated by a request
+type ResourceResponse struct {
+	// Id of the activity
+	Id string `json:"id"`
+}
+
+// ConnectorError is returned when the Bot Connector doesn’t accept a request
+type ConnectorError struct {
+	// HTTP status code of the response
+	StatusCode int
+	// Code of the error, e.g. "BadSyntax" or "ConversationNotFound", if the response contained one
+	Code string
+	// Description of the error, or the body of the response if it wasn’t an error response
+	Message string
+}
+
+func (e *ConnectorError) Error() string {
+	switch {
+	case e.Code != "" && e.Message != "":
+		return fmt.Sprintf("bot connector returned status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+	case e.Code != "" || e.Message != "":
+		return fmt.Sprintf("bot connector returned status %d: %s", e.StatusCode, e.Code+e.Message)
+	}
+
+	return fmt.Sprintf("bot connector returned status %d", e.StatusCode)
+}
+
+// Temporary reports whether sending the request again later may succeed, which is the case when the Bot Connector
+// is throttled or has a server error
+func (e *ConnectorError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// SendToConversation posts the activity to the end of the conversation, e.g. as a new thread of a channel
+func (c *Connector) SendToConversation(ctx context.Context, conversationId string, activity *Activity) (*ResourceResponse, error) {
+	if conversationId == "" {
+		return nil, errors.New("conversationId is required")
+	}
+
+	var resp ResourceResponse
+	err := c.do(ctx, http.MethodPost, "/v3/conversations/"+url.PathEscape(conversationId)+"/activities", activity, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ReplyToActivity posts the activity as a reply to another activity of the conversation, e.g. in the thread of a
+// channel message
+func (c *Connector) ReplyToActivity(ctx context.Context, conversationId string, activityId string, activity *Activity) (*ResourceResponse, error) {
+	path, err := activityPath(conversationId, activityId)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := *activity
+	reply.ReplyToId = activityId
+	var resp ResourceResponse
+	if err := c.do(ctx, http.MethodPost, path, &reply, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// UpdateActivity replaces an activity sent by the bot, e.g. to show that an alert was resolved
+func (c *Connector) UpdateActivity(ctx context.Context, conversationId string, activityId string, activity *Activity) (*ResourceResponse, error) {
+	path, err := activityPath(conversationId, activityId)
+	if err != nil {
+		return nil, err
+	}
+
+	update := *activity
+	update.Id = activityId
+	var resp ResourceResponse
+	if err := c.do(ctx, http.MethodPut, path, &update, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeleteActivity deletes an activity sent by the bot
+func (c *Connector) DeleteActivity(ctx context.Context, conversationId string, activityId string) error {
+	path, err := activityPath(conversationId, activityId)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+func activityPath(conversationId string, activityId string) (string, error) {
+	if conversationId == "" {
+		return "", errors.New("conversationId is required")
+	}
+	if activityId == "" {
+		return "", errors.New("activityId is required")
+	}
+
+	return "/v3/conversations/" + url.PathEscape(conversationId) + "/activities/" + url.PathEscape(activityId), nil
+}
+
+// do sends a request with the JSON of in, if not nil, and decodes the JSON response into out, if not nil
+func (c *Connector) do(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.ServiceUrl+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return connectorError(resp)
+	}
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// some operations answer with an empty body
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	return nil
+}
+
+// connectorError returns the ConnectorError of a failed response, parsing the Bot Framework error response if the
+// body is one
+func connectorError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	var errResp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &errResp) == nil && (errResp.Error.Code != "" || errResp.Error.Message != "") {
+		return &ConnectorError{StatusCode: resp.StatusCode, Code: errResp.Error.Code, Message: errResp.Error.Message}
+	}
+
+	return &ConnectorError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+}
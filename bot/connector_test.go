@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// request is a request received by the fake Bot Connector
+type request struct {
+	Method   string
+	Path     string
+	Activity Activity
+}
+
+func newConnector(t *testing.T, status int, response string) (*Connector, *[]request) {
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{Method: r.Method, Path: r.URL.EscapedPath()}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &req.Activity); err != nil {
+				t.Errorf("%s %s: %v", r.Method, r.URL, err)
+			}
+		}
+		requests = append(requests, req)
+		w.WriteHeader(status)
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewConnector(srv.URL+"/", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, &requests
+}
+
+func TestConnector(t *testing.T) {
+	ctx := context.Background()
+	c, requests := newConnector(t, http.StatusOK, `{"id":"1"}`)
+	activity := &Activity{Type: "message", Text: "hello"}
+
+	if resp, err := c.SendToConversation(ctx, "19:abc@thread.skype", activity); err != nil || resp.Id != "1" {
+		t.Errorf("SendToConversation() = %+v, %v", resp, err)
+	}
+	if _, err := c.ReplyToActivity(ctx, "19:abc@thread.skype", "a/1", activity); err != nil {
+		t.Errorf("ReplyToActivity() = %v", err)
+	}
+	if _, err := c.UpdateActivity(ctx, "19:abc@thread.skype", "a1", activity); err != nil {
+		t.Errorf("UpdateActivity() = %v", err)
+	}
+	if err := c.DeleteActivity(ctx, "19:abc@thread.skype", "a1"); err != nil {
+		t.Errorf("DeleteActivity() = %v", err)
+	}
+
+	want := []request{
+		{http.MethodPost, "/v3/conversations/19:abc@thread.skype/activities", Activity{Type: "message", Text: "hello"}},
+		{http.MethodPost, "/v3/conversations/19:abc@thread.skype/activities/a%2F1", Activity{Type: "message", Text: "hello", ReplyToId: "a/1"}},
+		{http.MethodPut, "/v3/conversations/19:abc@thread.skype/activities/a1", Activity{Type: "message", Text: "hello", Id: "a1"}},
+		{http.MethodDelete, "/v3/conversations/19:abc@thread.skype/activities/a1", Activity{}},
+	}
+	if len(*requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(*requests), len(want))
+	}
+	for n, got := range *requests {
+		if got.Method != want[n].Method || got.Path != want[n].Path || got.Activity.Text != want[n].Activity.Text ||
+			got.Activity.ReplyToId != want[n].Activity.ReplyToId || got.Activity.Id != want[n].Activity.Id {
+			t.Errorf("request %d = %+v, want %+v", n, got, want[n])
+		}
+	}
+	if activity.ReplyToId != "" || activity.Id != "" {
+		t.Errorf("activity modified: %+v", activity)
+	}
+}
+
+func TestConnectorErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		response  string
+		want      string
+		temporary bool
+	}{
+		{"error response", http.StatusNotFound, `{"error":{"code":"ConversationNotFound","message":"Conversation not found."}}`,
+			"bot connector returned status 404: ConversationNotFound: Conversation not found.", false},
+		{"text response", http.StatusBadGateway, "bad gateway\n", "bot connector returned status 502: bad gateway", true},
+		{"throttled", http.StatusTooManyRequests, "", "bot connector returned status 429", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newConnector(t, tt.status, tt.response)
+			_, err := c.SendToConversation(context.Background(), "c1", &Activity{Type: "message"})
+			var connErr *ConnectorError
+			if !errors.As(err, &connErr) {
+				t.Fatalf("SendToConversation() = %v, want a *ConnectorError", err)
+			}
+			if err.Error() != tt.want || connErr.Temporary() != tt.temporary {
+				t.Errorf("SendToConversation() = %q, temporary %v, want %q, temporary %v", err, connErr.Temporary(), tt.want, tt.temporary)
+			}
+		})
+	}
+}
+
+func TestConnectorRequiresIds(t *testing.T) {
+	c, requests := newConnector(t, http.StatusOK, "")
+	ctx := context.Background()
+
+	if _, err := c.SendToConversation(ctx, "", &Activity{}); err == nil {
+		t.Error("SendToConversation() without conversationId = nil error")
+	}
+	if _, err := c.ReplyToActivity(ctx, "c1", "", &Activity{}); err == nil {
+		t.Error("ReplyToActivity() without activityId = nil error")
+	}
+	if err := c.DeleteActivity(ctx, "", "a1"); err == nil {
+		t.Error("DeleteActivity() without conversationId = nil error")
+	}
+	if len(*requests) != 0 {
+		t.Errorf("got %d requests, want none", len(*requests))
+	}
+
+	if _, err := NewConnector("smba.trafficmanager.net", nil); err == nil {
+		t.Error("NewConnector() with a relative URL = nil error")
+	}
+}
@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decodeInputs decodes the data of an action into v, converting the strings of input values to the types of the
+// fields they are decoded into
+func decodeInputs(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Ptr {
+		value = coerceInput(value, t.Elem())
+	}
+	coerced, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(coerced, v)
+}
+
+// coerceInput converts the strings in value to the kinds of t: numbers, booleans and comma-separated lists. Values
+// that can’t be converted are kept, so that decoding them reports the error
+func coerceInput(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return value
+	}
+
+	s, isString := value.(string)
+	switch t.Kind() {
+	case reflect.Struct:
+		if obj, ok := value.(map[string]interface{}); ok {
+			fields := structFields(t)
+			for key, v := range obj {
+				if f, ok := fields[strings.ToLower(key)]; ok {
+					obj[key] = coerceInput(v, f)
+				}
+			}
+		}
+	case reflect.Map:
+		if obj, ok := value.(map[string]interface{}); ok {
+			for key, v := range obj {
+				obj[key] = coerceInput(v, t.Elem())
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		var items []interface{}
+		if isString {
+			// the values of a multi-select Input.ChoiceSet
+			if s != "" {
+				for _, item := range strings.Split(s, ",") {
+					items = append(items, item)
+				}
+			}
+			value = items
+		} else if items, _ = value.([]interface{}); items == nil {
+			break
+		}
+		for i, item := range items {
+			items[i] = coerceInput(item, t.Elem())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if isString {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				return nil
+			}
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				return json.Number(s)
+			}
+		}
+	case reflect.Bool:
+		if isString {
+			if s == "" {
+				return nil
+			}
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b
+			}
+		}
+	case reflect.String:
+		switch v := value.(type) {
+		case json.Number:
+			return string(v)
+		case bool:
+			return strconv.FormatBool(v)
+		}
+	}
+
+	return value
+}
+
+// structFields returns the types of the fields of a struct by their lower-cased JSON names, including the fields
+// of embedded structs
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for n, t := range structFields(ft) {
+				if _, ok := fields[n]; !ok {
+					fields[n] = t
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+
+	return fields
+}
@@ -0,0 +1,255 @@
+package bot
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// OpenIDMetadataUrl is the OpenID metadata of the Bot Framework, listing the keys signing the tokens of the requests
+// sent to bots
+const OpenIDMetadataUrl = "https://login.botframework.com/v1/.well-known/openidconfiguration"
+
+// DefaultKeysRefreshInterval is how long OpenIDKeys caches the keys. The Bot Framework recommends refreshing them
+// daily
+const DefaultKeysRefreshInterval = 24 * time.Hour
+
+// the shortest time between two fetches of the keys once some are cached, e.g. when tokens are signed by an unknown key
+const minKeysRefreshInterval = 5 * time.Minute
+
+// the largest metadata or key set read by OpenIDKeys
+const maxKeysSize = 1 << 20
+
+// ErrKeyNotFound is returned by a KeySource that has no key with the requested id
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// A KeySource returns the keys signing the tokens of the requests sent to a bot
+type KeySource interface {
+	// Key returns the key with the given id, or ErrKeyNotFound
+	Key(ctx context.Context, id string) (*JSONWebKey, error)
+}
+
+// A JSONWebKey is a public key of a JSONWebKeySet
+//
+// Source: https://www.rfc-editor.org/rfc/rfc7517
+type JSONWebKey struct {
+	// Type of the key, "RSA" for the keys of the Bot Framework
+	Kty string `json:"kty"`
+	// Id of the key, the "kid" in the header of the tokens it signs
+	Kid string `json:"kid"`
+	// Use of the key, "sig" for signing keys
+	Use string `json:"use,omitempty"`
+	// Modulus of an RSA key, base64url-encoded
+	N string `json:"n,omitempty"`
+	// Exponent of an RSA key, base64url-encoded
+	E string `json:"e,omitempty"`
+	// Certificate chain of the key, base64-encoded DER
+	X5c []string `json:"x5c,omitempty"`
+	// The channels allowed to use the key, e.g. "msteams"
+	Endorsements []string `json:"endorsements,omitempty"`
+}
+
+// PublicKey returns the RSA public key, from its modulus and exponent or else from its certificate
+func (k *JSONWebKey) PublicKey() (*rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("key %q: kty is invalid; expected: RSA, got %s", k.Kid, k.Kty)
+	}
+
+	if k.N != "" && k.E != "" {
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: n: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: e: %w", k.Kid, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %q: e is invalid", k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	}
+
+	if len(k.X5c) > 0 {
+		der, err := base64.StdEncoding.DecodeString(k.X5c[0])
+		if err != nil {
+			return nil, fmt.Errorf("key %q: x5c: %w", k.Kid, err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: x5c: %w", k.Kid, err)
+		}
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+		return nil, fmt.Errorf("key %q: x5c is not an RSA certificate", k.Kid)
+	}
+
+	return nil, fmt.Errorf("key %q: n and e or x5c are required", k.Kid)
+}
+
+// endorses reports whether the key may be used by the channel. Keys without endorsements may be used by all channels
+func (k *JSONWebKey) endorses(channelId string) bool {
+	if len(k.Endorsements) == 0 {
+		return true
+	}
+	for _, e := range k.Endorsements {
+		if e == channelId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A JSONWebKeySet is a static KeySource, e.g. for tests or air-gapped environments
+type JSONWebKeySet struct {
+	// The keys
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// ReadKeySet reads a JSON Web Key Set file, like the one at the jwks_uri of the OpenID metadata
+func ReadKeySet(path string) (*JSONWebKeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &set, nil
+}
+
+// Key returns the key with the given id
+func (s *JSONWebKeySet) Key(ctx context.Context, id string) (*JSONWebKey, error) {
+	for i := range s.Keys {
+		if s.Keys[i].Kid == id {
+			return &s.Keys[i], nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// OpenIDKeys is a KeySource fetching the keys from OpenID metadata, like the one of the Bot Framework. The keys are
+// cached for RefreshInterval, and fetched again when a token is signed by an unknown key, at most every 5 minutes
+type OpenIDKeys struct {
+	// URL of the OpenID metadata, OpenIDMetadataUrl for the Bot Framework
+	MetadataUrl string
+	// How long the keys are cached, DefaultKeysRefreshInterval if 0
+	RefreshInterval time.Duration
+
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        *JSONWebKeySet
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	err         error
+}
+
+// NewOpenIDKeys returns an OpenIDKeys fetching the metadata at metadataUrl with the given client, or
+// http.DefaultClient if nil
+func NewOpenIDKeys(metadataUrl string, client *http.Client) *OpenIDKeys {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &OpenIDKeys{MetadataUrl: metadataUrl, client: client}
+}
+
+// Key returns the key with the given id. When the keys can’t be fetched again, the cached ones are used
+func (o *OpenIDKeys) Key(ctx context.Context, id string) (*JSONWebKey, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	interval := o.RefreshInterval
+	if interval == 0 {
+		interval = DefaultKeysRefreshInterval
+	}
+	if o.keys == nil || time.Since(o.fetchedAt) > interval {
+		if err := o.refresh(ctx); err != nil && o.keys == nil {
+			return nil, err
+		}
+	}
+
+	key, err := o.keys.Key(ctx, id)
+	if err == ErrKeyNotFound && o.refresh(ctx) == nil {
+		// the keys may have been rotated
+		return o.keys.Key(ctx, id)
+	}
+
+	return key, err
+}
+
+// refresh fetches the keys, unless there are cached keys and they were fetched, or failed to be, in the last 5
+// minutes
+func (o *OpenIDKeys) refresh(ctx context.Context) error {
+	if o.keys != nil && time.Since(o.attemptedAt) < minKeysRefreshInterval {
+		return o.err
+	}
+
+	o.attemptedAt = time.Now()
+	o.err = o.fetch(ctx)
+	return o.err
+}
+
+// fetch replaces the cached keys by the ones of the metadata
+func (o *OpenIDKeys) fetch(ctx context.Context) error {
+	var metadata struct {
+		JwksUri string `json:"jwks_uri"`
+	}
+	if err := o.get(ctx, o.MetadataUrl, &metadata); err != nil {
+		return fmt.Errorf("openid metadata: %w", err)
+	}
+	if metadata.JwksUri == "" {
+		return errors.New("openid metadata: jwks_uri is required")
+	}
+
+	var keys JSONWebKeySet
+	if err := o.get(ctx, metadata.JwksUri, &keys); err != nil {
+		return fmt.Errorf("openid keys: %w", err)
+	}
+
+	o.keys = &keys
+	o.fetchedAt = time.Now()
+	return nil
+}
+
+func (o *OpenIDKeys) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxKeysSize))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOpenIDKeys(t *testing.T) {
+	keys := testKeys(t)
+	var fetches int32
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"jwks_uri": srv.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		json.NewEncoder(w).Encode(keys)
+	})
+
+	o := NewOpenIDKeys(srv.URL+"/metadata", srv.Client())
+	for i := 0; i < 3; i++ {
+		key, err := o.Key(context.Background(), "k1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key.Kid != "k1" {
+			t.Fatalf("Key() = %q, want k1", key.Kid)
+		}
+	}
+	if _, err := o.Key(context.Background(), "unknown"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Key(unknown) = %v, want %v", err, ErrKeyNotFound)
+	}
+	// an unknown key doesn't fetch the keys again within 5 minutes
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("keys fetched %d times, want 1", n)
+	}
+}
+
+func TestOpenIDKeysUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	o := NewOpenIDKeys(srv.URL, srv.Client())
+	if _, err := o.Key(context.Background(), "k1"); err == nil || errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Key() = %v, want the error of the metadata request", err)
+	}
+}
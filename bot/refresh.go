@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// TriggerAutomatic is the trigger of the Action.Execute of a refresh run by Teams when a user views the card
+const TriggerAutomatic = "automatic"
+
+// IsRefresh reports whether the action is the refresh of a card that Teams runs when a user views it
+func (r *ActionRequest) IsRefresh() bool {
+	return r.Trigger == TriggerAutomatic
+}
+
+// HandleRefresh registers the function building the card for the user viewing a card whose refresh has the given
+// verb, passing it the data of the refresh decoded into a T. The user is the From of req.Activity.
+//
+// The returned card replaces the card for this user only. It must be valid, and should have the same refresh to
+// stay personal on the next views, since Teams only shows the latest card of every user. Invalid cards are logged
+// and answered with status 500
+func HandleRefresh[T any](h *ActionHandler, verb string, f func(ctx context.Context, req *ActionRequest, data T) (*teams.AdaptiveCard, error)) {
+	HandleAction(h, verb, func(ctx context.Context, req *ActionRequest, data T) (*ActionResponse, error) {
+		card, err := f(ctx, req, data)
+		if err != nil {
+			return nil, err
+		}
+		if card == nil {
+			return nil, errors.New("no card returned for the refresh")
+		}
+		if err := card.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid refresh card: %w", err)
+		}
+
+		return CardResponse(card), nil
+	})
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+func refreshInvoke(trigger string, data string) string {
+	return `{"type":"invoke","name":"adaptiveCard/action","from":{"id":"29:user"},"value":{"action":{"type":"Action.Execute","verb":"refresh","data":` +
+		data + `},"trigger":"` + trigger + `"}}`
+}
+
+func TestHandleRefresh(t *testing.T) {
+	var requests []*ActionRequest
+	h := NewActionHandler()
+	h.ErrorLog = log.New(io.Discard, "", 0)
+	HandleRefresh(h, "refresh", func(ctx context.Context, req *ActionRequest, data struct{ Card string }) (*teams.AdaptiveCard, error) {
+		requests = append(requests, req)
+		switch data.Card {
+		case "none":
+			return nil, nil
+		case "invalid":
+			return &teams.AdaptiveCard{}, nil
+		case "error":
+			return nil, errors.New("database unavailable")
+		}
+		card := teams.NewAdaptiveCard()
+		card.Version = teams.Version14
+		card.Body = append(card.Body, teams.NewTextBlock("for "+req.Activity.From.Id))
+		return card, nil
+	})
+
+	tests := []struct {
+		name      string
+		activity  string
+		status    int
+		valueType string
+		value     string
+		refresh   bool
+	}{
+		{"automatic", refreshInvoke("automatic", `{}`), http.StatusOK, ContentTypeAdaptiveCard, `"text":"for 29:user"`, true},
+		{"manual", refreshInvoke("manual", `{}`), http.StatusOK, ContentTypeAdaptiveCard, `"text":"for 29:user"`, false},
+		{"no card", refreshInvoke("automatic", `{"card":"none"}`), http.StatusInternalServerError, ContentTypeError, `"code":"InternalServerError"`, true},
+		{"invalid card", refreshInvoke("automatic", `{"card":"invalid"}`), http.StatusInternalServerError, ContentTypeError, `"code":"InternalServerError"`, true},
+		{"error", refreshInvoke("automatic", `{"card":"error"}`), http.StatusInternalServerError, ContentTypeError, `"message":"the action failed"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/messages", strings.NewReader(tt.activity)))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			var resp struct {
+				StatusCode int             `json:"statusCode"`
+				Type       string          `json:"type"`
+				Value      json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status || resp.Type != tt.valueType || !strings.Contains(string(resp.Value), tt.value) {
+				t.Errorf("response = %s, want %s %s containing %s", w.Body, tt.valueType, http.StatusText(tt.status), tt.value)
+			}
+			if len(requests) != 1 || requests[0].IsRefresh() != tt.refresh {
+				t.Errorf("requests = %+v, want one with IsRefresh() = %t", requests, tt.refresh)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// convert prints the card in the given file, or read from stdin if the file is missing or "-", as an Adaptive Card
+// in JSON or YAML. Legacy MessageCards are converted to Adaptive Cards
+func convert(args []string) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `Usage: teams convert [flags] [card.json|card.yaml|messagecard.json]
+
+Prints the card as an Adaptive Card in JSON or YAML. A legacy MessageCard of Office 365 connectors is converted to
+an Adaptive Card; what can’t be converted, like HttpPOST actions, is dropped and reported on stderr. YAML input has
+its shorthands expanded.
+
+Flags:`)
+		flags.PrintDefaults()
+	}
+	to := flags.String("to", "adaptive", "the format to convert to: adaptive for Adaptive Card JSON, or yaml for Adaptive Card YAML")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *to != "adaptive" && *to != "yaml" {
+		return usageError(flags, "-to: invalid format %q; expected adaptive or yaml", *to)
+	}
+
+	path := flags.Arg(0)
+	data, err := readInput(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	var card *teams.AdaptiveCard
+	if isMessageCard(data) {
+		var messageCard teams.MessageCard
+		if err := json.Unmarshal(data, &messageCard); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid MessageCard: %v\n", err)
+			return exitError
+		}
+		c, notes := messageCard.ToAdaptiveCard()
+		for _, note := range notes {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", inputName(path), note)
+		}
+		card = c
+	} else {
+		cardData, err := cardJSON(path, data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		card = &teams.AdaptiveCard{}
+		if err := json.Unmarshal(cardData, card); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid card: %v\n", err)
+			return exitError
+		}
+	}
+
+	var out []byte
+	if *to == "yaml" {
+		out, err = card.ToYAML()
+	} else {
+		out, err = json.MarshalIndent(card, "", "  ")
+		out = append(out, '\n')
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	os.Stdout.Write(out)
+
+	return exitOK
+}
+
+// isMessageCard reports whether data is the JSON of a legacy MessageCard
+func isMessageCard(data []byte) bool {
+	var head struct {
+		Type teams.Type `json:"@type"`
+	}
+
+	return json.Unmarshal(data, &head) == nil && head.Type == teams.TypeMessageCard
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertExitCodes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"card.json":        `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"x"}]}`,
+		"card.yaml":        "type: AdaptiveCard\nversion: \"1.5\"\nbody:\n  - type: TextBlock\n    text: x\n",
+		"messagecard.json": `{"@type":"MessageCard","title":"Build","potentialAction":[{"@type":"HttpPOST","name":"Save"}]}`,
+		"badmessage.json":  `{"@type":"MessageCard","sections":{}}`,
+		"invalid.json":     `{"type":"AdaptiveCard","body":`,
+		"invalid.yaml":     "type: [",
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+		out  string
+	}{
+		{"card", []string{path("card.json")}, exitOK, `"text": "x"`},
+		{"card to YAML", []string{"-to", "yaml", path("card.json")}, exitOK, "text: x"},
+		{"YAML card", []string{path("card.yaml")}, exitOK, `"text": "x"`},
+		{"MessageCard with dropped actions", []string{path("messagecard.json")}, exitOK, `"text": "Build"`},
+		{"invalid MessageCard", []string{path("badmessage.json")}, exitError, ""},
+		{"invalid JSON", []string{path("invalid.json")}, exitError, ""},
+		{"invalid YAML", []string{path("invalid.yaml")}, exitError, ""},
+		{"missing file", []string{path("missing.json")}, exitError, ""},
+		{"unknown format", []string{"-to", "xml", path("card.json")}, exitUsage, ""},
+		{"too many arguments", []string{path("card.json"), path("card.json")}, exitUsage, ""},
+		{"unknown flag", []string{"-nope"}, exitUsage, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := run(t, convert, tt.args...)
+			if code != tt.want {
+				t.Errorf("convert(%q) = %d, want %d", tt.args, code, tt.want)
+			}
+			if !strings.Contains(out, tt.out) {
+				t.Errorf("convert(%q) printed %s, want %s", tt.args, out, tt.out)
+			}
+		})
+	}
+}
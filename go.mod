@@ -1,5 +1,5 @@
 module github.com/smantel-ch/teams-go
 
-go 1.17
+go 1.18
 
-require github.com/google/uuid v1.3.0
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,375 @@
+// Package graph posts, replies to, edits and deletes the messages of Teams channels through Microsoft Graph, with
+// the cards built with package teams as attachments. Unlike incoming webhooks, it can reply in the thread of a message
+// and update a card once posted, e.g. to turn a FIRING alert into a RESOLVED one.
+//
+// Source: https://learn.microsoft.com/en-us/graph/api/resources/chatmessage
+package graph
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// DefaultBaseUrl is the URL of the v1.0 API of Microsoft Graph
+const DefaultBaseUrl = "https://graph.microsoft.com/v1.0"
+
+// The content types of the body of a message
+const (
+	ContentTypeHTML = "html"
+	ContentTypeText = "text"
+)
+
+// the longest part of a response body kept in an Error
+const maxErrorBodyLength = 4096
+
+// A ChatMessage is a message, or a reply to a message, of a channel
+//
+// Source: https://learn.microsoft.com/en-us/graph/api/resources/chatmessage
+type ChatMessage struct {
+	// Id of the message, set by Microsoft Graph
+	Id string `json:"id,omitempty"`
+	// Id of the message this message replies to, set by Microsoft Graph
+	ReplyToId string `json:"replyToId,omitempty"`
+	// Subject of the message, shown above its body in channels
+	Subject string `json:"subject,omitempty"`
+	// Text shown in notifications instead of the body
+	Summary string `json:"summary,omitempty"`
+	// Importance of the message: normal, high or urgent
+	Importance string `json:"importance,omitempty"`
+	// Body of the message
+	Body ItemBody `json:"body"`
+	// Attachments of the message, like cards, referenced in the body
+	Attachments []ChatMessageAttachment `json:"attachments,omitempty"`
+	// When the message was created, set by Microsoft Graph
+	CreatedDateTime *time.Time `json:"createdDateTime,omitempty"`
+	// When the message was last changed, set by Microsoft Graph
+	LastModifiedDateTime *time.Time `json:"lastModifiedDateTime,omitempty"`
+	// When the message was deleted, set by Microsoft Graph
+	DeletedDateTime *time.Time `json:"deletedDateTime,omitempty"`
+	// Link to the message in Teams, set by Microsoft Graph
+	WebUrl string `json:"webUrl,omitempty"`
+}
+
+// An ItemBody is the body of a message
+type ItemBody struct {
+	// Type of the content: ContentTypeHTML or ContentTypeText
+	ContentType string `json:"contentType,omitempty"`
+	// Content of the body
+	Content string `json:"content"`
+}
+
+// A ChatMessageAttachment is an attachment of a message, like a card
+//
+// Source: https://learn.microsoft.com/en-us/graph/api/resources/chatmessageattachment
+type ChatMessageAttachment struct {
+	// Id of the attachment, referenced by an <attachment id="…"></attachment> element of the body
+	Id string `json:"id"`
+	// Content type of the attachment, e.g. teams.ContentTypeAdaptiveCard
+	ContentType string `json:"contentType"`
+	// URL of the content of a file attachment
+	ContentUrl string `json:"contentUrl,omitempty"`
+	// Content of the attachment, e.g. the JSON of a card
+	Content string `json:"content,omitempty"`
+	// Name of the attachment
+	Name string `json:"name,omitempty"`
+}
+
+// NewCardMessage returns a message whose body shows the card. Like teams.NewMessage, its summary is the first line of
+// the plain-text rendering of the card, and the card’s FallbackText defaults to the whole rendering
+func NewCardMessage(card *teams.AdaptiveCard) (*ChatMessage, error) {
+	m := teams.NewMessage(card)
+	content, err := json.Marshal(m.Attachments[0].Content)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := attachmentId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatMessage{
+		Summary: m.Summary,
+		Body: ItemBody{
+			ContentType: ContentTypeHTML,
+			Content:     `<attachment id="` + id + `"></attachment>`,
+		},
+		Attachments: []ChatMessageAttachment{
+			{
+				Id:          id,
+				ContentType: teams.ContentTypeAdaptiveCard,
+				Content:     string(content),
+			},
+		},
+	}, nil
+}
+
+// attachmentId returns a random id for an attachment
+func attachmentId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+// Error is returned when Microsoft Graph doesn’t accept a request
+//
+// Source: https://learn.microsoft.com/en-us/graph/errors
+type Error struct {
+	// HTTP status code of the response
+	StatusCode int
+	// Code of the error, e.g. "NotFound" or "Forbidden", if the response contained one
+	Code string
+	// Description of the error, or the body of the response if it wasn’t an error response
+	Message string
+	// Id of the request, needed by Microsoft support
+	RequestId string
+	// How long to wait before sending the request again, from the Retry-After header of throttled responses
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Code != "" && e.Message != "":
+		return fmt.Sprintf("graph returned status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+	case e.Code != "" || e.Message != "":
+		return fmt.Sprintf("graph returned status %d: %s", e.StatusCode, e.Code+e.Message)
+	}
+
+	return fmt.Sprintf("graph returned status %d", e.StatusCode)
+}
+
+// Temporary reports whether sending the request again later may succeed, which is the case when Microsoft Graph is
+// throttled or has a server error
+func (e *Error) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Client sends the requests to Microsoft Graph with the given http.Client, whose transport must add the delegated
+// token of a signed-in user to them, like an auth.Transport with a TokenSource of such tokens. App tokens, like the
+// ones of an auth.TokenProvider, can't post or edit channel messages
+type Client struct {
+	// URL of Microsoft Graph, DefaultBaseUrl if empty, e.g. the URL of a local server in tests
+	BaseUrl string
+
+	client *http.Client
+}
+
+// NewClient returns a Client sending its requests with the given client, or http.DefaultClient if nil
+func NewClient(client *http.Client) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Client{client: client}
+}
+
+// SendMessage posts the message as a new thread of the channel, and returns it as created
+func (c *Client) SendMessage(ctx context.Context, teamId string, channelId string, msg *ChatMessage) (*ChatMessage, error) {
+	path, err := messagesPath(teamId, channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	var created ChatMessage
+	if err := c.do(ctx, http.MethodPost, path, msg, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// SendCard posts the card as a new thread of the channel
+func (c *Client) SendCard(ctx context.Context, teamId string, channelId string, card *teams.AdaptiveCard) (*ChatMessage, error) {
+	msg, err := NewCardMessage(card)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendMessage(ctx, teamId, channelId, msg)
+}
+
+// ReplyToMessage posts the message in the thread of another message of the channel, and returns it as created
+func (c *Client) ReplyToMessage(ctx context.Context, teamId string, channelId string, messageId string, msg *ChatMessage) (*ChatMessage, error) {
+	path, err := messagePath(teamId, channelId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	var created ChatMessage
+	if err := c.do(ctx, http.MethodPost, path+"/replies", msg, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateMessage replaces the body and attachments of a message of the channel, e.g. with another card
+func (c *Client) UpdateMessage(ctx context.Context, teamId string, channelId string, messageId string, msg *ChatMessage) error {
+	path, err := messagePath(teamId, channelId, messageId)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPatch, path, msg, nil)
+}
+
+// UpdateReply replaces the body and attachments of a reply to a message of the channel
+func (c *Client) UpdateReply(ctx context.Context, teamId string, channelId string, messageId string, replyId string, msg *ChatMessage) error {
+	path, err := replyPath(teamId, channelId, messageId, replyId)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPatch, path, msg, nil)
+}
+
+// DeleteMessage soft-deletes a message of the channel, which can be undone in Teams
+func (c *Client) DeleteMessage(ctx context.Context, teamId string, channelId string, messageId string) error {
+	path, err := messagePath(teamId, channelId, messageId)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path+"/softDelete", nil, nil)
+}
+
+// DeleteReply soft-deletes a reply to a message of the channel
+func (c *Client) DeleteReply(ctx context.Context, teamId string, channelId string, messageId string, replyId string) error {
+	path, err := replyPath(teamId, channelId, messageId, replyId)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path+"/softDelete", nil, nil)
+}
+
+func messagesPath(teamId string, channelId string) (string, error) {
+	if teamId == "" {
+		return "", errors.New("teamId is required")
+	}
+	if channelId == "" {
+		return "", errors.New("channelId is required")
+	}
+
+	return "/teams/" + url.PathEscape(teamId) + "/channels/" + url.PathEscape(channelId) + "/messages", nil
+}
+
+func messagePath(teamId string, channelId string, messageId string) (string, error) {
+	path, err := messagesPath(teamId, channelId)
+	if err != nil {
+		return "", err
+	}
+	if messageId == "" {
+		return "", errors.New("messageId is required")
+	}
+
+	return path + "/" + url.PathEscape(messageId), nil
+}
+
+func replyPath(teamId string, channelId string, messageId string, replyId string) (string, error) {
+	path, err := messagePath(teamId, channelId, messageId)
+	if err != nil {
+		return "", err
+	}
+	if replyId == "" {
+		return "", errors.New("replyId is required")
+	}
+
+	return path + "/replies/" + url.PathEscape(replyId), nil
+}
+
+// do sends a request with the JSON of in, if not nil, and decodes the JSON response into out, if not nil
+func (c *Client) do(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = DefaultBaseUrl
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(baseUrl, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return graphError(resp)
+	}
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	return nil
+}
+
+// graphError returns the Error of a failed response, parsing the Microsoft Graph error response if the body is one
+func graphError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	e := &Error{StatusCode: resp.StatusCode, RequestId: resp.Header.Get("request-id")}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	var errResp struct {
+		Error struct {
+			Code       string `json:"code"`
+			Message    string `json:"message"`
+			InnerError struct {
+				RequestId string `json:"request-id"`
+			} `json:"innerError"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &errResp) == nil && (errResp.Error.Code != "" || errResp.Error.Message != "") {
+		e.Code = errResp.Error.Code
+		e.Message = errResp.Error.Message
+		if errResp.Error.InnerError.RequestId != "" {
+			e.RequestId = errResp.Error.InnerError.RequestId
+		}
+		return e
+	}
+
+	e.Message = strings.TrimSpace(string(data))
+	return e
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// request is a request received by the fake Microsoft Graph
+type request struct {
+	Method  string
+	Path    string
+	Message *ChatMessage
+}
+
+func newClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]request) {
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{Method: r.Method, Path: r.URL.EscapedPath()}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			req.Message = &ChatMessage{}
+			if err := json.Unmarshal(data, req.Message); err != nil {
+				t.Errorf("%s %s: %v", r.Method, r.URL, err)
+			}
+		}
+		requests = append(requests, req)
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.Client())
+	c.BaseUrl = srv.URL + "/v1.0/"
+	return c, &requests
+}
+
+func TestNewCardMessage(t *testing.T) {
+	card := teams.NewAdaptiveCard()
+	card.Body = append(card.Body, teams.NewTextBlock("FIRING: disk full"))
+
+	msg, err := NewCardMessage(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msg.Attachments) != 1 {
+		t.Fatalf("got %d attachments, want 1", len(msg.Attachments))
+	}
+	a := msg.Attachments[0]
+	if msg.Body.ContentType != ContentTypeHTML || msg.Body.Content != `<attachment id="`+a.Id+`"></attachment>` {
+		t.Errorf("Body = %+v, want a reference to attachment %q", msg.Body, a.Id)
+	}
+	if a.ContentType != teams.ContentTypeAdaptiveCard || !strings.Contains(a.Content, "FIRING: disk full") {
+		t.Errorf("attachment = %+v, want the card", a)
+	}
+	if msg.Summary != "FIRING: disk full" {
+		t.Errorf("Summary = %q, want the first line of the card", msg.Summary)
+	}
+
+	other, err := NewCardMessage(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Attachments[0].Id == a.Id {
+		t.Errorf("attachment ids are not unique: %q", a.Id)
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c, requests := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && !strings.HasSuffix(r.URL.Path, "/softDelete") {
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id":"m1","webUrl":"https://teams.microsoft.com/l/message/m1"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	msg := &ChatMessage{Body: ItemBody{Content: "hello"}}
+
+	created, err := c.SendMessage(ctx, "team 1", "19:abc@thread.tacv2", msg)
+	if err != nil || created.Id != "m1" || created.WebUrl == "" {
+		t.Errorf("SendMessage() = %+v, %v, want the created message", created, err)
+	}
+	if _, err := c.SendCard(ctx, "team 1", "19:abc@thread.tacv2", teams.NewAdaptiveCard()); err != nil {
+		t.Errorf("SendCard() = %v", err)
+	}
+	if _, err := c.ReplyToMessage(ctx, "team 1", "19:abc@thread.tacv2", "m1", msg); err != nil {
+		t.Errorf("ReplyToMessage() = %v", err)
+	}
+	if err := c.UpdateMessage(ctx, "team 1", "19:abc@thread.tacv2", "m1", msg); err != nil {
+		t.Errorf("UpdateMessage() = %v", err)
+	}
+	if err := c.UpdateReply(ctx, "team 1", "19:abc@thread.tacv2", "m1", "r1", msg); err != nil {
+		t.Errorf("UpdateReply() = %v", err)
+	}
+	if err := c.DeleteMessage(ctx, "team 1", "19:abc@thread.tacv2", "m1"); err != nil {
+		t.Errorf("DeleteMessage() = %v", err)
+	}
+	if err := c.DeleteReply(ctx, "team 1", "19:abc@thread.tacv2", "m1", "r1"); err != nil {
+		t.Errorf("DeleteReply() = %v", err)
+	}
+
+	const messages = "/v1.0/teams/team%201/channels/19:abc@thread.tacv2/messages"
+	want := []struct {
+		method string
+		path   string
+		body   bool
+	}{
+		{http.MethodPost, messages, true},
+		{http.MethodPost, messages, true},
+		{http.MethodPost, messages + "/m1/replies", true},
+		{http.MethodPatch, messages + "/m1", true},
+		{http.MethodPatch, messages + "/m1/replies/r1", true},
+		{http.MethodPost, messages + "/m1/softDelete", false},
+		{http.MethodPost, messages + "/m1/replies/r1/softDelete", false},
+	}
+	if len(*requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(*requests), len(want))
+	}
+	for n, got := range *requests {
+		if got.Method != want[n].method || got.Path != want[n].path || (got.Message != nil) != want[n].body {
+			t.Errorf("request %d = %s %s with body %v, want %s %s with body %v", n, got.Method, got.Path,
+				got.Message != nil, want[n].method, want[n].path, want[n].body)
+		}
+	}
+	if card := (*requests)[1].Message; card == nil || len(card.Attachments) != 1 {
+		t.Errorf("SendCard() sent %+v, want a message with the card", card)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		header    map[string]string
+		response  string
+		want      Error
+		temporary bool
+	}{
+		{
+			name:     "error response",
+			status:   http.StatusForbidden,
+			header:   map[string]string{"request-id": "header-id"},
+			response: `{"error":{"code":"Forbidden","message":"Missing role permissions.","innerError":{"request-id":"inner-id"}}}`,
+			want:     Error{StatusCode: http.StatusForbidden, Code: "Forbidden", Message: "Missing role permissions.", RequestId: "inner-id"},
+		},
+		{
+			name:      "throttled",
+			status:    http.StatusTooManyRequests,
+			header:    map[string]string{"Retry-After": "7", "request-id": "header-id"},
+			response:  `{"error":{"code":"TooManyRequests","message":"Too many requests."}}`,
+			want:      Error{StatusCode: http.StatusTooManyRequests, Code: "TooManyRequests", Message: "Too many requests.", RequestId: "header-id", RetryAfter: 7 * time.Second},
+			temporary: true,
+		},
+		{
+			name:      "text response",
+			status:    http.StatusBadGateway,
+			response:  "bad gateway\n",
+			want:      Error{StatusCode: http.StatusBadGateway, Message: "bad gateway"},
+			temporary: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.header {
+					w.Header().Set(name, value)
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.response)
+			})
+
+			err := c.UpdateMessage(context.Background(), "t1", "c1", "m1", &ChatMessage{})
+			var graphErr *Error
+			if !errors.As(err, &graphErr) {
+				t.Fatalf("UpdateMessage() = %v, want an *Error", err)
+			}
+			if *graphErr != tt.want || graphErr.Temporary() != tt.temporary {
+				t.Errorf("UpdateMessage() = %+v, temporary %v, want %+v, temporary %v", *graphErr, graphErr.Temporary(), tt.want, tt.temporary)
+			}
+		})
+	}
+}
+
+func TestClientRequiresIds(t *testing.T) {
+	c, requests := newClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	ctx := context.Background()
+
+	if _, err := c.SendMessage(ctx, "", "c1", &ChatMessage{}); err == nil {
+		t.Error("SendMessage() without teamId = nil error")
+	}
+	if _, err := c.ReplyToMessage(ctx, "t1", "c1", "", &ChatMessage{}); err == nil {
+		t.Error("ReplyToMessage() without messageId = nil error")
+	}
+	if err := c.DeleteReply(ctx, "t1", "c1", "m1", ""); err == nil {
+		t.Error("DeleteReply() without replyId = nil error")
+	}
+	if len(*requests) != 0 {
+		t.Errorf("got %d requests, want none", len(*requests))
+	}
+}
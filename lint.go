@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// lint reports the likely mistakes in the cards in the given files, or read from stdin if there are none
+func lint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `Usage: teams lint [card.json|card.yaml...]
+
+Reports parts of the cards that are valid but likely mistakes: markdown Teams doesn’t render, images without
+alternative text, inputs without a label, actions without a title, duplicate ids and actions that need a bot.
+
+Exit codes: 0 no issues, 1 issues found or a card can’t be read, 2 invalid flags`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	code := exitOK
+	for _, path := range paths {
+		card, err := readCard(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", inputName(path), err)
+			code = exitError
+			continue
+		}
+		for _, issue := range teams.Lint(card) {
+			fmt.Printf("%s: %s\n", inputName(path), issue)
+			code = exitError
+		}
+	}
+
+	return code
+}
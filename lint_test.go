@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintExitCodes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"clean.json":   `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"x"}]}`,
+		"issues.json":  `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"# x"}]}`,
+		"clean.yaml":   "type: AdaptiveCard\nversion: \"1.5\"\nbody:\n  - type: TextBlock\n    text: x\n",
+		"invalid.json": `{"type":"AdaptiveCard","body":[{"type":"Nope"}]}`,
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+		out  string
+	}{
+		{"clean", []string{path("clean.json"), path("clean.yaml")}, exitOK, ""},
+		{"issues", []string{path("issues.json")}, exitError, path("issues.json") + ": /body/0/text: line 1: heading is not supported: # x"},
+		{"issues in one of the cards", []string{path("clean.json"), path("issues.json")}, exitError, "heading"},
+		{"invalid card", []string{path("invalid.json")}, exitError, ""},
+		{"missing file", []string{path("missing.json")}, exitError, ""},
+		{"unknown flag", []string{"-nope"}, exitUsage, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := run(t, lint, tt.args...)
+			if code != tt.want {
+				t.Errorf("lint(%q) = %d, want %d", tt.args, code, tt.want)
+			}
+			if !strings.Contains(out, tt.out) {
+				t.Errorf("lint(%q) printed %s, want %s", tt.args, out, tt.out)
+			}
+		})
+	}
+}
@@ -1,90 +1,135 @@
 package main
 
 import (
-	"log"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
 )
 
+// Exit codes of the teams command
+const (
+	exitOK = 0
+	// the card couldn’t be read, parsed or validated, or another error occurred
+	exitError = 1
+	// the command line is invalid
+	exitUsage = 2
+	// the webhook rejected the message, sending it again won’t help
+	exitRejected = 3
+	// the webhook couldn’t be reached, is throttled or has a server error, sending the message again later may help
+	exitUnavailable = 4
+)
+
+// commands maps the subcommands to their implementation, which returns the exit code
+var commands = map[string]func(args []string) int{
+	"send":     send,
+	"preview":  preview,
+	"validate": validate,
+	"lint":     lint,
+	"render":   render,
+	"convert":  convert,
+	"template": template,
+}
+
+const usage = `Usage: teams <command> [flags]
+
+Commands:
+  send      send a card to an incoming webhook
+  preview   draw a card in the terminal
+  validate  check cards against the schema and the rules of their version
+  lint      report likely mistakes in cards
+  render    render a card as text, markdown or HTML
+  convert   convert a legacy MessageCard to an Adaptive Card
+  template  expand a card template with data
+
+Run "teams <command> -h" for the flags of a command.
+`
+
 func main() {
-	testMsg := teams.AdaptiveCard{
-		Type:    teams.TypeAdaptiveCard,
-		Version: teams.Version13,
-		Body: []teams.Element{
-			&teams.Container{
-				Type:      teams.TypeContainer,
-				Separator: true,
-				Spacing:   teams.SpacingExtraLarge,
-				Items: []teams.Element{
-					&teams.TextBlock{
-						Type: teams.TypeTextBlock,
-						Text: "**alertWatcher triggered a new Alert**",
-					},
-					&teams.TextBlock{
-						Type:    teams.TypeTextBlock,
-						Text:    "fruit.bananana",
-						Spacing: teams.SpacingNone,
-						Size:    teams.FontSizeSmall,
-					},
-				},
-			},
-			&teams.Container{
-				Type:      teams.TypeContainer,
-				Separator: true,
-				Spacing:   teams.SpacingExtraLarge,
-				Items: []teams.Element{
-					&teams.FactSet{
-						Type: teams.TypeFactSet,
-						Facts: []teams.Fact{
-							{
-								Title: "Ruleset",
-								Value: "bananana",
-							},
-							{
-								Title: "Date/Time",
-								Value: "Fri, 02 Sep 2022 08:11:50 CEST",
-							},
-							{
-								Title: "Severity",
-								Value: "EXTREME",
-							},
-							{
-								Title: "Status",
-								Value: "🔥 FIRING",
-							},
-						},
-					},
-				},
-			},
-			&teams.Container{
-				Type:      teams.TypeContainer,
-				Separator: true,
-				Spacing:   teams.SpacingExtraLarge,
-				Items: []teams.Element{
-					&teams.ActionSet{
-						Type: teams.TypeActionSet,
-						Actions: []teams.Action{
-							&teams.ActionOpenUrl{
-								Type:  teams.TypeActionOpenUrl,
-								Title: "Open in 4Dmetrics",
-								Url:   "https://4dmetrics.4data.ch",
-							},
-						},
-					},
-				},
-			},
-		},
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(exitUsage)
+	}
+
+	switch name := os.Args[1]; name {
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		os.Exit(exitOK)
+	default:
+		command, ok := commands[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "teams: unknown command %q\n\n%s", name, usage)
+			os.Exit(exitUsage)
+		}
+		os.Exit(command(os.Args[2:]))
+	}
+}
+
+// readInput returns the contents of the file at path, or of stdin if path is empty or "-"
+func readInput(path string) ([]byte, error) {
+	if path == "" || path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+// readCard decodes the card in the file at path, or read from stdin if path is empty or "-", written in JSON or
+// YAML
+func readCard(path string) (*teams.AdaptiveCard, error) {
+	data, err := readCardJSON(path)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &teams.AdaptiveCard{}
+	if err := json.Unmarshal(data, card); err != nil {
+		return nil, fmt.Errorf("invalid card: %w", err)
 	}
 
-	webhookUrl := os.Getenv("TEAMS_WEBHOOK")
+	return card, nil
+}
 
-	webhook, err := teams.NewWebhook(webhookUrl)
+// readCardJSON returns the JSON of the card in the file at path, or read from stdin if path is empty or "-"
+func readCardJSON(path string) ([]byte, error) {
+	data, err := readInput(path)
 	if err != nil {
-		log.Println(err)
+		return nil, err
 	}
-	if err := webhook.Send(&testMsg); err != nil {
-		log.Println(err)
+
+	return cardJSON(path, data)
+}
+
+// cardJSON returns the JSON of the card read from path. Files ending in .yaml or .yml, and input that isn’t JSON,
+// are converted from YAML with its shorthands expanded
+func cardJSON(path string, data []byte) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return data, nil
+	case ".yaml", ".yml":
+	default:
+		if json.Valid(data) {
+			return data, nil
+		}
+	}
+
+	data, err := teams.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid card: %w", err)
+	}
+
+	return data, nil
+}
+
+// inputName returns the name of an input file in messages
+func inputName(path string) string {
+	if path == "" || path == "-" {
+		return "<stdin>"
 	}
 
+	return path
 }
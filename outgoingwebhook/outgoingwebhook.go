@@ -0,0 +1,291 @@
+// Package outgoingwebhook receives the messages Teams posts to an outgoing webhook when someone @mentions it in a
+// channel, and replies to them with text or a card.
+//
+// Teams signs every request with the security token shown when the outgoing webhook is created, and shows an error
+// in the channel if the reply takes longer than 5 seconds.
+//
+// Source: https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/add-outgoing-webhook
+package outgoingwebhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+	"github.com/smantel-ch/teams-go/bot"
+)
+
+// DefaultTimeout is the time commands have to reply, leaving a margin to the 5 seconds Teams waits for the reply
+const DefaultTimeout = 4500 * time.Millisecond
+
+// the largest message read by the handler
+const maxMessageSize = 1 << 20
+
+var (
+	// ErrMissingSignature is returned by Verify when the request has no HMAC Authorization header
+	ErrMissingSignature = errors.New("missing HMAC signature")
+	// ErrInvalidSignature is returned by Verify when the signature doesn’t match the body
+	ErrInvalidSignature = errors.New("invalid HMAC signature")
+)
+
+// mentions matches the @mentions in the text of a message, like the one of the outgoing webhook itself
+var mentions = regexp.MustCompile(`(?is)<at[^>]*>.*?</at>`)
+
+// tags matches the remaining HTML tags of the text of a message
+var tags = regexp.MustCompile(`<[^>]+>`)
+
+// A Request is a message posted to the outgoing webhook
+type Request struct {
+	// The first word of the text, lower-cased, e.g. "deploy" for "@Ops deploy api"
+	Command string
+	// The text after the command, e.g. "api" for "@Ops deploy api"
+	Args string
+	// The text of the message without @mentions and HTML
+	Text string
+	// The message activity, with the sender in From, the conversation in Conversation and the team and channel in
+	// ChannelData
+	Activity *bot.Activity
+}
+
+// Fields returns the arguments split around whitespace
+func (r *Request) Fields() []string {
+	return strings.Fields(r.Args)
+}
+
+// A Reply is the answer to a Request, posted in the thread of the message
+type Reply struct {
+	// Text of the reply, may contain markdown. Used if Card is nil
+	Text string
+	// Card of the reply
+	Card *teams.AdaptiveCard
+}
+
+// TextReply returns a Reply with the given text
+func TextReply(text string) *Reply {
+	return &Reply{Text: text}
+}
+
+// CardReply returns a Reply with the given card
+func CardReply(card *teams.AdaptiveCard) *Reply {
+	return &Reply{Card: card}
+}
+
+// message returns the message Teams expects in the response
+func (r *Reply) message() *teams.Message {
+	if r.Card != nil {
+		return teams.NewMessage(r.Card)
+	}
+
+	return &teams.Message{Type: "message", Text: r.Text}
+}
+
+// A CommandFunc handles a command. The context is cancelled when the reply would arrive too late
+type CommandFunc func(ctx context.Context, req *Request) (*Reply, error)
+
+// Handler is an http.Handler receiving the messages of an outgoing webhook. It verifies their signature and
+// dispatches them to the CommandFunc registered for their command
+type Handler struct {
+	// Handles the messages whose command isn’t registered. When nil, they are answered with the list of commands
+	Default CommandFunc
+	// Time the commands have to reply, DefaultTimeout if 0
+	Timeout time.Duration
+	// Text of the reply when a command times out
+	TimeoutText string
+	// Text of the reply when a command returns an error
+	ErrorText string
+	// Logs the errors returned by the commands, the standard logger when nil
+	ErrorLog *log.Logger
+
+	key      []byte
+	mu       sync.RWMutex
+	commands map[string]CommandFunc
+}
+
+// NewHandler returns a Handler verifying the messages with the security token shown by Teams when the outgoing
+// webhook was created
+func NewHandler(securityToken string) (*Handler, error) {
+	key, err := base64.StdEncoding.DecodeString(securityToken)
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("securityToken is invalid; expected the base64 token shown by Teams")
+	}
+
+	return &Handler{
+		TimeoutText: "Sorry, this is taking too long. Please try again later.",
+		ErrorText:   "Sorry, something went wrong.",
+		key:         key,
+		commands:    map[string]CommandFunc{},
+	}, nil
+}
+
+// Handle registers the function handling the given command. Commands are matched case-insensitively
+func (h *Handler) Handle(command string, f CommandFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.commands[strings.ToLower(command)] = f
+}
+
+// Verify checks the Authorization header of a request against the HMAC-SHA256 signature of its body
+func (h *Handler) Verify(authorization string, body []byte) error {
+	scheme, signature, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "HMAC") {
+		return ErrMissingSignature
+	}
+	got, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := h.Verify(r.Header.Get("Authorization"), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var activity bot.Activity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		http.Error(w, fmt.Sprintf("invalid message: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	reply := h.reply(r.Context(), NewRequest(&activity))
+	data, err := json.Marshal(reply.message())
+	if err != nil {
+		h.logf("outgoingwebhook: %v", err)
+		data, _ = json.Marshal(TextReply(h.ErrorText).message())
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(data)
+}
+
+// NewRequest returns the Request of a message activity, with the @mentions and HTML removed from its text
+func NewRequest(activity *bot.Activity) *Request {
+	text := mentions.ReplaceAllString(activity.Text, " ")
+	text = tags.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(html.UnescapeString(text), "\u00a0", " ")
+	text = strings.TrimSpace(text)
+
+	var command, args string
+	if fields := strings.Fields(text); len(fields) > 0 {
+		command = fields[0]
+		args = strings.TrimSpace(text[len(command):])
+	}
+
+	return &Request{
+		Command:  strings.ToLower(command),
+		Args:     strings.TrimSpace(args),
+		Text:     text,
+		Activity: activity,
+	}
+}
+
+// reply runs the command of the request, replacing its reply by the timeout or error text if it takes too long or
+// fails
+func (h *Handler) reply(ctx context.Context, req *Request) *Reply {
+	f := h.command(req.Command)
+	if f == nil {
+		return TextReply(h.usage(req.Command))
+	}
+
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		reply *Reply
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		reply, err := f(ctx, req)
+		done <- result{reply, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			h.logf("outgoingwebhook: command %q: %v", req.Command, res.err)
+			return TextReply(h.ErrorText)
+		}
+		if res.reply == nil {
+			return TextReply("")
+		}
+		return res.reply
+	case <-ctx.Done():
+		h.logf("outgoingwebhook: command %q: %v", req.Command, ctx.Err())
+		return TextReply(h.TimeoutText)
+	}
+}
+
+// command returns the CommandFunc of the command, or the default one
+func (h *Handler) command(name string) CommandFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if f, ok := h.commands[name]; ok {
+		return f
+	}
+
+	return h.Default
+}
+
+// usage returns the reply to an unknown command
+func (h *Handler) usage(command string) string {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.commands))
+	for name := range h.commands {
+		names = append(names, name)
+	}
+	h.mu.RUnlock()
+	sort.Strings(names)
+
+	if command == "" {
+		return "Available commands: " + strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("Unknown command %q. Available commands: %s", command, strings.Join(names, ", "))
+}
+
+func (h *Handler) logf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
@@ -0,0 +1,163 @@
+package outgoingwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smantel-ch/teams-go/bot"
+)
+
+var testToken = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func sign(token string, body []byte) string {
+	key, _ := base64.StdEncoding.DecodeString(token)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return "HMAC " + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewHandlerRejectsInvalidTokens(t *testing.T) {
+	for _, token := range []string{"", "not base64!"} {
+		if _, err := NewHandler(token); err == nil {
+			t.Errorf("NewHandler(%q) = nil error, want an error", token)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	h, err := NewHandler(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"type":"message","text":"<at>Ops</at> deploy api"}`)
+	otherToken := base64.StdEncoding.EncodeToString([]byte("another key"))
+
+	tests := []struct {
+		name          string
+		authorization string
+		body          []byte
+		want          error
+	}{
+		{"valid", sign(testToken, body), body, nil},
+		{"lower-case scheme", "hmac " + sign(testToken, body)[len("HMAC "):], body, nil},
+		{"surrounding spaces", "  " + sign(testToken, body) + "  ", body, nil},
+		{"empty", "", body, ErrMissingSignature},
+		{"no signature", "HMAC", body, ErrMissingSignature},
+		{"bearer", "Bearer abc", body, ErrMissingSignature},
+		{"not base64", "HMAC !!!", body, ErrInvalidSignature},
+		{"other key", sign(otherToken, body), body, ErrInvalidSignature},
+		{"tampered body", sign(testToken, body), append([]byte(" "), body...), ErrInvalidSignature},
+		{"empty body", sign(testToken, body), nil, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := h.Verify(tt.authorization, tt.body); err != tt.want {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	tests := []struct {
+		text    string
+		command string
+		args    string
+	}{
+		{"<at>Ops</at> deploy api", "deploy", "api"},
+		{"<at>Ops</at>&nbsp;Deploy  api prod", "deploy", "api prod"},
+		{"<p><at>Ops</at> status</p>", "status", ""},
+		{"<at>Ops</at>", "", ""},
+		{"restart &lt;svc&gt;", "restart", "<svc>"},
+	}
+	for _, tt := range tests {
+		req := NewRequest(&bot.Activity{Text: tt.text})
+		if req.Command != tt.command || req.Args != tt.args {
+			t.Errorf("NewRequest(%q) = %q, %q, want %q, %q", tt.text, req.Command, req.Args, tt.command, tt.args)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	h, err := NewHandler(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Timeout = 50 * time.Millisecond
+	h.ErrorLog = log.New(io.Discard, "", 0)
+	h.Handle("Echo", func(ctx context.Context, req *Request) (*Reply, error) {
+		return TextReply(req.Args), nil
+	})
+	h.Handle("fail", func(ctx context.Context, req *Request) (*Reply, error) {
+		return nil, errors.New("boom")
+	})
+	h.Handle("slow", func(ctx context.Context, req *Request) (*Reply, error) {
+		<-ctx.Done()
+		return TextReply("too late"), nil
+	})
+
+	tests := []struct {
+		name   string
+		text   string
+		signed bool
+		status int
+		reply  string
+	}{
+		{"command", "<at>Ops</at> echo hello world", true, http.StatusOK, "hello world"},
+		{"unknown command", "<at>Ops</at> deploy", true, http.StatusOK, `Unknown command "deploy". Available commands: echo, fail, slow`},
+		{"error", "<at>Ops</at> fail", true, http.StatusOK, h.ErrorText},
+		{"timeout", "<at>Ops</at> slow", true, http.StatusOK, h.TimeoutText},
+		{"unsigned", "<at>Ops</at> echo hello", false, http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(bot.Activity{Type: "message", Text: tt.text})
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			if tt.signed {
+				r.Header.Set("Authorization", sign(testToken, body))
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var reply struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.Type != "message" || reply.Text != tt.reply {
+				t.Errorf("reply = %+v, want message %q", reply, tt.reply)
+			}
+		})
+	}
+}
+
+func TestServeHTTPRejectsGet(t *testing.T) {
+	h, err := NewHandler(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// preview draws the card in the given file, or read from stdin if the file is missing or "-", in the terminal
+func preview(args []string) int {
+	width := 80
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+
+	flags := flag.NewFlagSet("preview", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: teams preview [flags] [card.json|card.yaml]")
+		flags.PrintDefaults()
+	}
+	flags.IntVar(&width, "width", width, "width of the card in columns, defaults to $COLUMNS")
+	noColor := flags.Bool("no-color", os.Getenv("NO_COLOR") != "", "disable ANSI colours, defaults to true if $NO_COLOR is set")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	card, err := readCard(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	renderer := teams.TerminalRenderer{Width: width, NoColor: *noColor}
+	fmt.Print(renderer.Render(card))
+
+	return exitOK
+}
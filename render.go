@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// render prints the card in the given file, or read from stdin if the file is missing or "-", as text, markdown or
+// HTML
+func render(args []string) int {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: teams render [flags] [card.json|card.yaml]")
+		flags.PrintDefaults()
+	}
+	format := flags.String("format", "text", "output format: text, markdown or html")
+	theme := flags.String("theme", string(teams.ThemeLight), "Teams theme of the html format: light, dark or highContrast")
+	hostConfig := flags.String("host-config", "", "render the html format with the HostConfig JSON at `path` instead of a Teams theme")
+	fragment := flags.Bool("fragment", false, "render only the card instead of a complete HTML document")
+	factsAsTable := flags.Bool("facts-as-table", false, "render FactSets as tables in the markdown format")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	switch *format {
+	case "text", "markdown", "html":
+	default:
+		return usageError(flags, "-format: invalid format %q; expected text, markdown or html", *format)
+	}
+	switch teams.Theme(*theme) {
+	case teams.ThemeLight, teams.ThemeDark, teams.ThemeHighContrast:
+	default:
+		return usageError(flags, "-theme: invalid theme %q", *theme)
+	}
+
+	var hc *teams.HostConfig
+	if *format == "html" {
+		hc = teams.TeamsHostConfig(teams.Theme(*theme))
+		if *hostConfig != "" {
+			c, err := teams.LoadHostConfig(*hostConfig)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return exitError
+			}
+			hc = c
+		}
+	}
+
+	card, err := readCard(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	switch *format {
+	case "text":
+		fmt.Println(teams.RenderText(card))
+	case "markdown":
+		fmt.Println((&teams.MarkdownRenderer{FactsAsTable: *factsAsTable}).Render(card))
+	case "html":
+		fmt.Print((&teams.HTMLRenderer{HostConfig: hc, Fragment: *fragment}).Render(card))
+	}
+
+	return exitOK
+}
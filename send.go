@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// cardColors holds the values accepted by the -color flag of send
+var cardColors = []teams.Colors{
+	teams.ColorDefault, teams.ColorDark, teams.ColorLight, teams.ColorAccent, teams.ColorGood, teams.ColorWarning,
+	teams.ColorAttention,
+}
+
+// pairs is a repeatable flag of key=value pairs
+type pairs [][2]string
+
+func (p *pairs) String() string {
+	var s []string
+	for _, kv := range *p {
+		s = append(s, kv[0]+"="+kv[1])
+	}
+
+	return strings.Join(s, ", ")
+}
+
+func (p *pairs) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	*p = append(*p, [2]string{strings.TrimSpace(key), val})
+
+	return nil
+}
+
+// send posts a card, read from a file or stdin or built from the -title, -text, -fact and -link flags, to an
+// incoming webhook
+func send(args []string) int {
+	flags := flag.NewFlagSet("send", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `Usage: teams send [flags]
+
+Sends the card in -file, read from stdin with -stdin, or built from -title, -text, -fact, -link and -color.
+
+Exit codes: 0 sent, 1 invalid card or other error, 2 invalid flags, 3 rejected by the webhook,
+4 webhook unavailable or throttled (worth retrying)
+
+Flags:`)
+		flags.PrintDefaults()
+	}
+	webhookUrl := flags.String("webhook", os.Getenv("TEAMS_WEBHOOK"), "URL of the incoming webhook, defaults to $TEAMS_WEBHOOK")
+	file := flags.String("file", "", "read the card, in JSON or YAML, from `path`")
+	stdin := flags.Bool("stdin", false, "read the card, in JSON or YAML, from stdin")
+	title := flags.String("title", "", "title of the card")
+	text := flags.String("text", "", "text of the card, may contain markdown")
+	var facts, links pairs
+	flags.Var(&facts, "fact", "add a fact as `name=value`, may be repeated")
+	flags.Var(&links, "link", "add a button opening a URL as `title=url`, may be repeated")
+	color := flags.String("color", "", "color of the title: default, dark, light, accent, good, warning or attention")
+	dryRun := flags.Bool("dry-run", false, "print the payload instead of sending it")
+	timeout := flags.Duration("timeout", 30*time.Second, "give up sending after this long")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	quick := *title != "" || *text != "" || len(facts) > 0 || len(links) > 0 || *color != ""
+	switch {
+	case flags.NArg() > 0:
+		return usageError(flags, "unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	case *file != "" && *stdin:
+		return usageError(flags, "-file and -stdin can’t be combined")
+	case (*file != "" || *stdin) && quick:
+		return usageError(flags, "-file and -stdin can’t be combined with -title, -text, -fact, -link or -color")
+	case *file == "" && !*stdin && !quick:
+		return usageError(flags, "nothing to send; use -file, -stdin or -title, -text, -fact and -link")
+	case !*dryRun && *webhookUrl == "":
+		return usageError(flags, "-webhook or $TEAMS_WEBHOOK is required")
+	}
+
+	var card *teams.AdaptiveCard
+	if quick {
+		c, err := quickCard(*title, *text, facts, links, teams.Colors(*color))
+		if err != nil {
+			return usageError(flags, "%v", err)
+		}
+		card = c
+	} else {
+		path := *file
+		if *stdin {
+			path = "-"
+		}
+		c, err := readCard(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		card = c
+	}
+	if err := card.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid card: %v\n", err)
+		return exitError
+	}
+
+	if *dryRun {
+		payload, err := teams.WebhookPayload(card)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		fmt.Println(string(payload))
+		return exitOK
+	}
+
+	webhook, err := teams.NewWebhookWithClient(*webhookUrl, &http.Client{Timeout: *timeout})
+	if err != nil {
+		return usageError(flags, "-webhook: %v", err)
+	}
+	if err := webhook.Send(card); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		var webhookErr *teams.WebhookError
+		if errors.As(err, &webhookErr) && !webhookErr.Temporary() {
+			return exitRejected
+		}
+		return exitUnavailable
+	}
+
+	return exitOK
+}
+
+// quickCard builds the card of the -title, -text, -fact, -link and -color flags
+func quickCard(title, text string, facts, links pairs, color teams.Colors) (*teams.AdaptiveCard, error) {
+	if color != "" && !containsColor(cardColors, color) {
+		return nil, fmt.Errorf("-color: invalid color %q", color)
+	}
+	if title == "" && text == "" && len(facts) == 0 && len(links) == 0 {
+		return nil, errors.New("-color needs something to color; use -title")
+	}
+
+	card := teams.NewAdaptiveCard()
+	if title != "" {
+		tb := teams.NewTextBlock(title)
+		tb.Size = teams.FontSizeLarge
+		tb.Weight = teams.FontWeightBolder
+		tb.Color = color
+		tb.Wrap = true
+		card.Body = append(card.Body, tb)
+	}
+	if text != "" {
+		tb := teams.NewTextBlock(text)
+		tb.Wrap = true
+		card.Body = append(card.Body, tb)
+	}
+	if len(facts) > 0 {
+		fs := teams.NewFactSet()
+		for _, f := range facts {
+			fs.Facts = append(fs.Facts, teams.Fact{Title: f[0], Value: f[1]})
+		}
+		card.Body = append(card.Body, fs)
+	}
+	for _, l := range links {
+		if u, err := url.Parse(l[1]); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("-link: %q is not an http or https URL", l[1])
+		}
+		a := teams.NewActionOpenUrl()
+		a.Title = l[0]
+		a.Url = l[1]
+		card.Actions = append(card.Actions, a)
+	}
+
+	return card, nil
+}
+
+func containsColor(colors []teams.Colors, c teams.Colors) bool {
+	for _, color := range colors {
+		if color == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// usageError reports an invalid command line and returns exitUsage
+func usageError(flags *flag.FlagSet, format string, args ...interface{}) int {
+	fmt.Fprintf(flags.Output(), "teams %s: %s\n", flags.Name(), fmt.Sprintf(format, args...))
+	fmt.Fprintf(flags.Output(), "Run \"teams %s -h\" for usage.\n", flags.Name())
+
+	return exitUsage
+}
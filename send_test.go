@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSendRejectsInvalidUrls(t *testing.T) {
+	stderr := os.Stderr
+	os.Stderr, _ = os.Open(os.DevNull)
+	defer func() { os.Stderr = stderr }()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"javascript webhook", []string{"-webhook", "javascript:alert(1)", "-title", "t"}, exitUsage},
+		{"relative webhook", []string{"-webhook", "hooks/123", "-title", "t"}, exitUsage},
+		{"ftp webhook", []string{"-webhook", "ftp://example.com/hook", "-title", "t"}, exitUsage},
+		{"javascript link", []string{"-dry-run", "-link", "x=javascript:alert(1)"}, exitUsage},
+		{"link without host", []string{"-dry-run", "-link", "x=https:///path"}, exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := send(tt.args); got != tt.want {
+				t.Errorf("send(%q) = %d, want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+}
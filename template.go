@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// template prints the card template in the given file, or read from stdin if the file is missing or "-", expanded
+// with the data of the -data file
+func template(args []string) int {
+	flags := flag.NewFlagSet("template", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `Usage: teams template -data data.json [flags] [template.json|template.yaml]
+
+Expands the ${...} bindings, $data and $when of a card template with the data and prints the resulting card.
+
+Flags:`)
+		flags.PrintDefaults()
+	}
+	dataPath := flags.String("data", "", "read the data to expand the template with from `path`")
+	check := flags.Bool("validate", false, "fail if the expanded card is invalid")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	switch {
+	case flags.NArg() > 1:
+		flags.Usage()
+		return exitUsage
+	case *dataPath == "":
+		return usageError(flags, "-data is required")
+	case *dataPath == "-" && (flags.Arg(0) == "" || flags.Arg(0) == "-"):
+		return usageError(flags, "the template and -data can’t both be read from stdin")
+	}
+
+	data, err := readInput(*dataPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if !json.Valid(data) {
+		fmt.Fprintf(os.Stderr, "%s: invalid JSON\n", inputName(*dataPath))
+		return exitError
+	}
+	tmpl, err := readCardJSON(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	expanded, err := teams.ExpandTemplate(tmpl, json.RawMessage(data))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if *check {
+		card := &teams.AdaptiveCard{}
+		err := json.Unmarshal(expanded, card)
+		if err == nil {
+			err = card.Validate()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid card: %v\n", err)
+			return exitError
+		}
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, expanded, "", "  "); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	fmt.Println(out.String())
+
+	return exitOK
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateExitCodes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"card.json":    `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"${title}"}]}`,
+		"invalid.json": `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"${title"}]}`,
+		"empty.json":   `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Input.Text","id":"${empty}"}]}`,
+		"data.json":    `{"title":"Hello","empty":""}`,
+		"bad.json":     `{"title":`,
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+		out  string
+	}{
+		{"expanded", []string{"-data", path("data.json"), path("card.json")}, exitOK, `"text": "Hello"`},
+		{"valid expanded card", []string{"-data", path("data.json"), "-validate", path("card.json")}, exitOK, `"text": "Hello"`},
+		{"invalid expanded card", []string{"-data", path("data.json"), "-validate", path("empty.json")}, exitError, ""},
+		{"invalid binding", []string{"-data", path("data.json"), path("invalid.json")}, exitError, ""},
+		{"invalid data", []string{"-data", path("bad.json"), path("card.json")}, exitError, ""},
+		{"missing template", []string{"-data", path("data.json"), path("missing.json")}, exitError, ""},
+		{"missing data", []string{"-data", path("missing.json"), path("card.json")}, exitError, ""},
+		{"without data", []string{path("card.json")}, exitUsage, ""},
+		{"both from stdin", []string{"-data", "-"}, exitUsage, ""},
+		{"too many arguments", []string{"-data", path("data.json"), path("card.json"), path("card.json")}, exitUsage, ""},
+		{"unknown flag", []string{"-nope"}, exitUsage, ""},
+		{"help", []string{"-h"}, exitOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := run(t, template, tt.args...)
+			if code != tt.want {
+				t.Errorf("template(%q) = %d, want %d", tt.args, code, tt.want)
+			}
+			if !strings.Contains(out, tt.out) {
+				t.Errorf("template(%q) printed %s, want %s", tt.args, out, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	teams "github.com/smantel-ch/teams-go/AdaptiveCard"
+)
+
+// validate checks the cards in the given files, or read from stdin if there are none, against the JSON schema of
+// their version and the rules the schema can’t express
+func validate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), `Usage: teams validate [flags] [card.json|card.yaml...]
+
+Checks every card against the JSON schema of its version and the rules the schema can’t express, like elements
+that need a newer version than the card declares. Prints "ok" for valid cards and every problem found otherwise.
+
+Exit codes: 0 all cards are valid, 1 a card is invalid or can’t be read, 2 invalid flags
+
+Flags:`)
+		flags.PrintDefaults()
+	}
+	target := flags.String("target", "", "also require the cards to be supported by hosts implementing this schema `version`, e.g. 1.5")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *target != "" {
+		if _, err := teams.JSONSchema(teams.Version(*target)); err != nil {
+			return usageError(flags, "-target: %v", err)
+		}
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	code := exitOK
+	for _, path := range paths {
+		problems, err := validateCard(path, teams.Version(*target))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", inputName(path), err)
+			code = exitError
+			continue
+		}
+		if len(problems) == 0 {
+			fmt.Printf("%s: ok\n", inputName(path))
+			continue
+		}
+		for _, p := range problems {
+			fmt.Printf("%s: %s\n", inputName(path), p)
+		}
+		code = exitError
+	}
+
+	return code
+}
+
+// validateCard returns the problems of the card in the file at path. The error is only set if the file can’t be
+// read or isn’t JSON or YAML
+func validateCard(path string, target teams.Version) ([]string, error) {
+	data, err := readCardJSON(path)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		var v interface{}
+		return nil, json.Unmarshal(data, &v)
+	}
+
+	var problems []string
+	var schemaErrs teams.SchemaErrors
+	if err := teams.ValidateJSONAgainstSchema(data); errors.As(err, &schemaErrs) {
+		for _, e := range schemaErrs {
+			problems = append(problems, e.Error())
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	var card teams.AdaptiveCard
+	if err := json.Unmarshal(data, &card); err != nil {
+		return append(problems, err.Error()), nil
+	}
+	if err := card.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if target != "" && card.Version.Compare(target) > 0 {
+		problems = append(problems, fmt.Sprintf("/version: %s is newer than the target %s", card.Version, target))
+	}
+
+	return problems, nil
+}